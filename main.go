@@ -1,22 +1,69 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/spf13/cobra"
 
 	"github.com/3atlab/netroub/pkg/events"
+	"github.com/3atlab/netroub/pkg/executor"
+	"github.com/3atlab/netroub/pkg/loadtest"
 	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/model/manifest"
 	"github.com/3atlab/netroub/pkg/network"
+	"github.com/3atlab/netroub/pkg/runtime"
+	"github.com/3atlab/netroub/pkg/telemetry"
 	"github.com/sirupsen/logrus"
-	"github.com/urfave/cli"
 )
 
 var initalSizes map[string]int64
 
+// rootCtx is canceled by main's SIGINT/SIGTERM/SIGQUIT handler, so a Ctrl-C
+// during a run reaches anything it passes a ctx to (e.g.
+// WaitForTopologyReady) instead of leaving it to run to completion.
+var rootCtx = context.Background()
+
+// dryRun is set by runScenario when rootFlags.dryRun (the --dry-run flag)
+// skips deployment outright, or when EmulateNetwork reports
+// runtime.ErrUnsupportedPlatform, so runScenarioAfter knows there's no
+// deployed network or collected logs to tear down.
+var dryRun bool
+
+// scenarioPath, runStart, runEnd, and eventRecords carry facts runScenario
+// gathers as it runs the scenario through to runScenarioAfter, which has no
+// other way to see them, for WriteRunManifest.
+var scenarioPath string
+var runStart, runEnd time.Time
+var eventRecords []manifest.Event
+
+// rootFlags holds the persistent flags every subcommand reads from, bound
+// directly to the root command's flag set in main().
+type rootFlags struct {
+	yaml      bool
+	vars      []string
+	noReuse   bool
+	dockerOpt network.DockerOptions
+
+	parallel     int
+	logLevel     string
+	showProgress bool
+	dryRun       bool
+	failFast     bool
+}
+
+var flags rootFlags
+
 type ConsoleHook struct{}
 
 func (h *ConsoleHook) Fire(entry *logrus.Entry) error {
@@ -41,105 +88,204 @@ func NewConsoleHook() *ConsoleHook {
 }
 
 func main() {
-	app := cli.NewApp()
-	app.Name = "Netroub"
-	app.Usage = "Netroub is a synthetic data generator from network trouble scenarios"
-	app.Version = "0.0.2"
-	app.Authors = []cli.Author{
-		{
-			Name:  "Colin Regal-Mezin",
-			Email: "colin.regalmezin@gmail.com",
-		},
-		{
-			Name:  "Satoru Kobayashi",
-			Email: "sat@okayama-u.ac.jp",
-		},
+	var stop context.CancelFunc
+	rootCtx, stop = signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
+	defer stop()
+
+	model.SudoCheck()
+
+	root := &cobra.Command{
+		Use:     "netroub",
+		Short:   "Netroub is a synthetic data generator from network trouble scenarios",
+		Version: "0.0.2",
+	}
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return fmt.Errorf("%w\nrun '%s --help' for usage", err, cmd.CommandPath())
+	})
+
+	root.PersistentFlags().BoolVar(&flags.yaml, "yaml", false, "Use a .yaml scenario file")
+	root.PersistentFlags().StringSliceVar(&flags.vars, "var", nil, "Set a scenario template variable as key=value (repeatable)")
+	root.PersistentFlags().BoolVar(&flags.noReuse, "no-reuse", false, "Force tearing down and redeploying the topology between trials, even for scenarios with reuse: true")
+	root.PersistentFlags().StringVar(&flags.dockerOpt.Host, "docker-host", "", "Docker daemon URL to target (e.g. tcp://host:2376, ssh://user@host); empty uses the environment (DOCKER_HOST or the local socket)")
+	root.PersistentFlags().StringVar(&flags.dockerOpt.TLSCACert, "tlscacert", "", "Trust certs signed only by this CA, as a file path or raw PEM content")
+	root.PersistentFlags().StringVar(&flags.dockerOpt.TLSCert, "tlscert", "", "Path or raw PEM content of the TLS client certificate")
+	root.PersistentFlags().StringVar(&flags.dockerOpt.TLSKey, "tlskey", "", "Path or raw PEM content of the TLS client certificate's key")
+	root.PersistentFlags().BoolVar(&flags.dockerOpt.TLSVerify, "tlsverify", false, "Verify the Docker daemon's certificate against tlscacert")
+	root.PersistentFlags().IntVar(&flags.parallel, "parallel", 0, "Override a plan's parallel worker count (0 keeps the plan's own value)")
+	root.PersistentFlags().StringVar(&flags.logLevel, "log-level", "debug", "Log level: debug, info, warn, error")
+	root.PersistentFlags().BoolVar(&flags.showProgress, "progress", true, "Show a live progress display for batch commands")
+	root.PersistentFlags().BoolVar(&flags.dryRun, "dry-run", false, "Validate and report what would run without deploying or executing anything")
+	root.PersistentFlags().BoolVar(&flags.failFast, "fail-fast", false, "Stop launching new tasks and cancel in-flight ones after the first task fails, like `go test -failfast`")
+
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return setupLogging()
 	}
-	app.EnableBashCompletion = true
-	if len(os.Args) > 1 {
-		model.SudoCheck()
-		app.Action = runScenario
-		app.After = after
 
+	root.AddCommand(
+		runCommand(),
+		planCommand(),
+		validateCommand(),
+		listCommand(),
+		cleanupCommand(),
+		inspectCommand(),
+		loadtestCommand(),
+		revertCommand(),
+		benchCommand(),
+	)
+
+	if err := root.Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
-	app.Before = before
-	app.CustomAppHelpTemplate = model.ConfigTemplate()
+}
 
-	app.Flags = []cli.Flag{
-		cli.BoolFlag{
-			Name:  "yaml",
-			Usage: "Use a .yaml scenario file",
+// setupLogging configures logrus the way the legacy before() hook did,
+// honoring --log-level instead of always forcing debug.
+func setupLogging() error {
+	level, err := logrus.ParseLevel(flags.logLevel)
+	if err != nil {
+		return fmt.Errorf("invalid --log-level %q: %w", flags.logLevel, err)
+	}
+	logrus.SetLevel(level)
+	logrus.SetFormatter(&logrus.TextFormatter{TimestampFormat: "2006-01-02 15:04:05.000", FullTimestamp: true})
+	logrus.SetOutput(os.Stdout)
+	logrus.AddHook(NewConsoleHook())
+	return nil
+}
+
+// parseVarFlags turns repeated "key=value" --var flags into a map for
+// envsubst-style scenario variable substitution.
+func parseVarFlags(raw []string) map[string]string {
+	vars := make(map[string]string, len(raw))
+	for _, kv := range raw {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			vars[key] = value
+		}
+	}
+	return vars
+}
+
+// runCommand deploys and executes a single scenario or plan file, detecting
+// which via executor.DetectFileType so `netroub run` accepts either, for
+// `netroub run <file>`.
+func runCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "run <file>",
+		Short: "Run a scenario or batch-execution plan",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			fileType, err := executor.DetectFileType(path)
+			if err != nil {
+				return err
+			}
+			switch fileType {
+			case executor.FileTypePlan:
+				return runPlan(path)
+			case executor.FileTypeScenario:
+				return runScenarioFile(path)
+			default:
+				return fmt.Errorf("%s is neither a recognized plan nor scenario file", path)
+			}
 		},
 	}
+}
 
-	err := app.Run(os.Args)
-	if err != nil {
-		fmt.Println(err)
+// runScenarioFile runs the scenario at path the way the legacy runScenario
+// action did, then tears down and writes the manifest the way the legacy
+// after() hook did.
+func runScenarioFile(path string) error {
+	if err := runScenario(path); err != nil {
+		return err
 	}
+	return runScenarioAfter()
 }
 
-func runScenario(c *cli.Context) error {
+func runScenario(path string) error {
 	var err error
 
+	runStart = time.Now()
+
 	controlLogFile, err := os.Create("control.log")
 	if err != nil {
 		fmt.Println("Error while creating control log file")
 		return err
 	}
-	// logrus.SetOutput(controlLogFile)
 	logrus.SetOutput(io.MultiWriter(os.Stdout, controlLogFile))
 
-	//Read the scenario file and sort it by time in an array
-	if c.Bool("yaml") {
-		err = model.ReadYaml()
-		if err != nil {
-			return err
-		}
+	// Read the scenario file and sort it by time in an array, substituting
+	// any ${VAR} references with --var flags or the OS environment.
+	vars := parseVarFlags(flags.vars)
+	scenarioPath = path
+	if flags.yaml {
+		err = model.ReadScenarioYAML(scenarioPath, vars)
 	} else {
-		err = model.ReadJsonScenar()
-		if err != nil {
-			return err
-		}
+		err = model.ReadScenarioJSON(scenarioPath, vars)
+	}
+	if err != nil {
+		return err
 	}
 
-	//Read the dot2net data json file containing device information
+	// Read the dot2net data json file containing device information
 	err = model.ReadJsonData()
 	if err != nil {
 		return err
 	}
+	lab := model.NewLabContext(model.Devices.Name)
 	err = model.ValidateHostNames(model.Scenar.Hosts)
 	if err != nil {
 		return err
 	}
+	err = events.ValidateScenarioEvents(model.Scenar.Event)
+	if err != nil {
+		return err
+	}
 
-	//Set dummy event to control the whole duration of the scenario
+	if flags.dryRun {
+		dryRun = true
+		logrus.Infof("--dry-run: scenario %s was parsed and validated but not deployed", model.Scenar.ScenarioName)
+		return nil
+	}
+
+	// Set dummy event to control the whole duration of the scenario
 	model.Scenar.Event = append(model.Scenar.Event, model.Event{BeginTime: "0s", Type: model.EventTypeDummy})
 
-	//Stock the size of all the log file present in the directory of the topo file
-	path := model.FindTopoPath()
+	// Stock the size of all the log file present in the directory of the topo file
+	topoDir := model.FindTopoPath()
 	initalSizes = make(map[string]int64)
-	initalSizes, err = model.StockInitialSize(initalSizes, path)
+	initalSizes, err = model.StockInitialSize(initalSizes, topoDir)
 	if err != nil {
 		return err
 	}
 
-	//Create the DockerClient which is mandatory for pumba command
-	err = network.CreateDockerClient(c)
+	// Create the DockerClient which is mandatory for pumba command
+	err = network.CreateDockerClient(flags.dockerOpt)
 	if err != nil {
 		return err
 	}
-	//Emulate the network with Containerlab
+	// Emulate the network with Containerlab
 	err = network.EmulateNetwork()
+	if errors.Is(err, runtime.ErrUnsupportedPlatform) {
+		dryRun = true
+		logrus.Warnf("%v; scenario %s was parsed and validated but not deployed", err, model.Scenar.ScenarioName)
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	// nbFile, err := countSubDir()
-	// if err != nil {
-	// 	return err
-	// }
+	// Abort before any event runs if the topology's nodes haven't converged
+	// (BGP up, interfaces configured, etc.) per the scenario's readiness
+	// probes.
+	containerRuntime, err := runtime.NewContainerRuntime(runtime.Engine(model.Scenar.ContainerEngine))
+	if err != nil {
+		return err
+	}
+	if err := network.WaitForTopologyReady(rootCtx, model.Scenar.Readiness, containerRuntime, lab.ClabHostName); err != nil {
+		return fmt.Errorf("scenario aborted: %w", err)
+	}
 
-	//Setup tcpdump logging
+	// Setup tcpdump logging
 	for _, node := range model.Scenar.Hosts {
 		err = network.TcpdumpLog(node)
 		if err != nil {
@@ -147,7 +293,7 @@ func runScenario(c *cli.Context) error {
 		}
 	}
 
-	//Create a channel to verify routine states
+	// Create a channel to verify routine states
 	done := make(chan bool)
 
 	// Load and parse beginTime for each event
@@ -167,7 +313,10 @@ func runScenario(c *cli.Context) error {
 
 	logrus.Debugf("Starting scenario %s\n", model.Scenar.ScenarioName)
 
-	//Run for all the events in the scenario file
+	// Record each event's timing and outcome for WriteRunManifest in runScenarioAfter
+	eventRecords = make([]manifest.Event, len(model.Scenar.Event))
+
+	// Run for all the events in the scenario file
 	for i := 0; i < len(model.Scenar.Event); i++ {
 		logrus.Debugf("Adding new event %d %+v\n", i, model.Scenar.Event[i]) // DEBUG
 		go func(index int) {
@@ -177,9 +326,23 @@ func runScenario(c *cli.Context) error {
 			}
 			logrus.Debugf("Starting event %d\n", index)
 
-			err := events.ExecuteEvent(index)
+			eventStart := time.Now()
+			err := events.ExecuteEvent(rootCtx, index, lab)
+			eventEnd := time.Now()
+
+			errMsg := ""
 			if err != nil {
 				logrus.Errorf("Error executing event %d: %v\n", index, err)
+				errMsg = err.Error()
+			}
+			event := model.Scenar.Event[index]
+			eventRecords[index] = manifest.Event{
+				Index:     index,
+				Type:      event.Type,
+				Host:      event.Host,
+				StartTime: eventStart,
+				EndTime:   eventEnd,
+				Error:     errMsg,
 			}
 
 			logrus.Debugf("Completed event %d\n", index)
@@ -188,34 +351,23 @@ func runScenario(c *cli.Context) error {
 		}(i)
 	}
 
-	//Wait here until all routines are finished
+	// Wait here until all routines are finished
 	for i := 0; i < len(model.Scenar.Event); i++ {
 		<-done
 	}
 
+	runEnd = time.Now()
 	logrus.Debugf("Completed scenario %s\n", model.Scenar.ScenarioName)
 
 	return nil
 }
 
-func before(c *cli.Context) error {
-
-	/*Useless*/
-	c.Args() //Permit to remove an unsed paramater warning
-	/*Useless*/
-	logrus.SetLevel(logrus.DebugLevel)
-	logrus.SetFormatter(&logrus.TextFormatter{TimestampFormat: "2006-01-02 15:04:05.000", FullTimestamp: true})
-	logrus.SetOutput(os.Stdout)
-
-	logrus.AddHook(NewConsoleHook())
-	return nil
-}
-
-func after(c *cli.Context) error {
-
-	/*Useless*/
-	c.Args() //Permit to remove a unsed paramater warning
-	/*Useless*/
+// runScenarioAfter tears down the network and writes manifest.json, the way
+// the legacy after() hook did.
+func runScenarioAfter() error {
+	if dryRun {
+		return nil
+	}
 
 	// Ensure network is destroyed regardless of errors in subsequent operations
 	defer func() {
@@ -224,25 +376,44 @@ func after(c *cli.Context) error {
 		}
 	}()
 
-	//Find the directory to search log file
-	path := model.FindTopoPath()
-	//Fill an array with all log file path
-	logFiles, err := network.SearchFiles(initalSizes, path)
+	// Find the directory to search log file
+	topoDir := model.FindTopoPath()
+	// Fill an array with all log file path
+	logFiles, err := network.SearchFiles(initalSizes, topoDir)
 	if err != nil {
 		return err
 	}
 	logrus.Debugf("Log files: %v\n", logFiles)
-	//Move tcpdump log files
+	// Move tcpdump log files
 	err = network.GetTcpdumpLogs()
 	if err != nil {
 		return err
 	}
 
-	err = network.MoveLogFiles(logFiles, path)
+	runDir, err := network.MoveLogFiles(logFiles)
 	if err != nil {
 		return err
 	}
-	//Flush log files for the next scenario
+
+	// Write a machine-readable manifest.json alongside the collected logs
+	lab := model.NewLabContext(model.Devices.Name)
+	hosts := make([]manifest.Host, 0, len(model.Scenar.Hosts))
+	for _, host := range model.Scenar.Hosts {
+		hosts = append(hosts, manifest.Host{Host: host, Container: lab.ClabHostName(host)})
+	}
+	err = network.WriteRunManifest(runDir, model.Scenar.ScenarioName, network.RunManifestInput{
+		ScenarioPath: scenarioPath,
+		TopologyPath: topoDir,
+		RunStart:     runStart,
+		RunEnd:       runEnd,
+		Hosts:        hosts,
+		Events:       eventRecords,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Flush log files for the next scenario
 	err = network.FlushLogFiles(logFiles)
 	if err != nil {
 		return err
@@ -250,24 +421,490 @@ func after(c *cli.Context) error {
 	return nil
 }
 
-// func countSubDir() (int, error) {
-// 	count := 0
-//
-// 	file, err := os.Open(model.FindTopoPath())
-// 	if err != nil {
-// 		return count, err
-// 	}
-// 	defer file.Close()
-//
-// 	dir, err := file.ReadDir(-1)
-// 	if err != nil {
-// 		fmt.Println("Error while reading topo dir")
-// 		return count, err
-// 	}
-// 	for _, subDir := range dir {
-// 		if subDir.IsDir() {
-// 			count++
-// 		}
-// 	}
-// 	return count, nil
-// }
+// planCommand groups batch-execution plan subcommands under `netroub plan`.
+func planCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Batch-execution plan utilities",
+	}
+	cmd.AddCommand(planRunCommand(), planStatusCommand(), planResumeCommand())
+	return cmd
+}
+
+// reportFlags holds the plan-runner entry point's --report/--report-out
+// flags, bound by addReportFlags on each command that runs a plan.
+var reportFlags struct {
+	format string
+	out    string
+}
+
+// addReportFlags wires --report/--report-out onto cmd, for `netroub plan
+// run`/`netroub plan resume`.
+func addReportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&reportFlags.format, "report", "", "Report format: text, json, or junit (default: text; json when --progress=false)")
+	cmd.Flags().StringVar(&reportFlags.out, "report-out", "", "Write the report to this file instead of stdout")
+}
+
+// planRunCommand runs every task a plan file expands to, through
+// executor.Executor, for `netroub plan run <plan.yaml>`.
+func planRunCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run <plan-file>",
+		Short: "Execute every task a plan expands to",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPlan(args[0])
+		},
+	}
+	addReportFlags(cmd)
+	return cmd
+}
+
+// resumeFlags holds `netroub plan resume`'s --journal/--retry-failed flags.
+var resumeFlags struct {
+	journal     string
+	retryFailed bool
+}
+
+// planResumeCommand re-runs a plan's not-yet-completed tasks, requiring
+// either the plan to set checkpointPath or --journal to be given, for
+// `netroub plan resume <plan.yaml>`.
+func planResumeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume <plan-file>",
+		Short: "Resume a plan, skipping tasks its checkpoint/journal already recorded",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return resumePlan(args[0])
+		},
+	}
+	cmd.Flags().StringVar(&resumeFlags.journal, "journal", "", "Checkpoint/journal file to resume from, overriding the plan's checkpointPath")
+	cmd.Flags().BoolVar(&resumeFlags.retryFailed, "retry-failed", false, "Also re-run tasks the journal recorded as failed, not just never-attempted ones")
+	addReportFlags(cmd)
+	return cmd
+}
+
+// runPlan loads planPath, expands it into tasks (fresh, i.e. ResumeNone),
+// and executes them through executor.Executor, printing a summary.
+func runPlan(planPath string) error {
+	return executePlan(planPath, executor.ResumeNone, "")
+}
+
+// resumePlan is runPlan but filtered through the journal's (--journal, or
+// else the plan's checkpointPath) recorded outcomes: ResumeSkipCompleted by
+// default (only never-attempted tasks run), or ResumeSkipSucceeded with
+// --retry-failed (failed tasks run again too). Fails if neither is set.
+func resumePlan(planPath string) error {
+	plan, err := executor.LoadPlan(planPath)
+	if err != nil {
+		return err
+	}
+	if plan.CheckpointPath == "" && resumeFlags.journal == "" {
+		return fmt.Errorf("plan %s has no checkpointPath set and no --journal given, nothing to resume from", planPath)
+	}
+
+	mode := executor.ResumeSkipCompleted
+	if resumeFlags.retryFailed {
+		mode = executor.ResumeSkipSucceeded
+	}
+	return executePlan(planPath, mode, resumeFlags.journal)
+}
+
+// executePlan is the shared body of runPlan/resumePlan. journalOverride, if
+// non-empty, replaces plan.CheckpointPath (e.g. from resume's --journal
+// flag), so a journal file can be chosen at the CLI without editing the
+// plan.
+func executePlan(planPath string, mode executor.ResumeMode, journalOverride string) error {
+	plan, err := executor.LoadPlan(planPath)
+	if err != nil {
+		return err
+	}
+	if journalOverride != "" {
+		plan.CheckpointPath = journalOverride
+	}
+	baseDir := filepath.Dir(planPath)
+
+	if flags.dryRun {
+		entries, err := plan.ExpandScenarios(baseDir)
+		if err != nil {
+			return err
+		}
+		if problems := executor.ValidateExpandedScenarios(entries); len(problems) > 0 {
+			for _, p := range problems {
+				fmt.Fprintln(os.Stderr, p)
+			}
+			return fmt.Errorf("--dry-run: plan %s is invalid: %d problem(s) found", planPath, len(problems))
+		}
+		fmt.Printf("--dry-run: plan %s expands to %d valid scenario entries\n", planPath, len(entries))
+		return nil
+	}
+
+	tasks, checkpoint, err := executor.GenerateTasksFromPlanWithResume(plan, baseDir, mode)
+	if err != nil {
+		return err
+	}
+
+	parallel := plan.Parallel
+	if flags.parallel > 0 {
+		parallel = flags.parallel
+	}
+
+	runner := executor.NewScenarioRunner(executor.RunnerOptions{
+		NoReuse: flags.noReuse,
+		Vars:    flags.vars,
+		Docker:  flags.dockerOpt,
+		Hosts:   plan.Hosts,
+	})
+	defer func() {
+		if err := runner.CleanupReused(); err != nil {
+			logrus.Warnf("Failed to clean up reused topologies: %v", err)
+		}
+	}()
+
+	exec := executor.NewExecutor(parallel, runner)
+	if checkpoint != nil {
+		exec.SetCheckpoint(checkpoint)
+	}
+	exec.SetFailFast(flags.failFast)
+
+	hostLimits := make(map[string]int, len(plan.Hosts))
+	for name, cfg := range plan.Hosts {
+		hostLimits[name] = cfg.MaxParallel
+	}
+	exec.SetHostLimits(hostLimits)
+
+	results := exec.ExecuteWithProgress(rootCtx, tasks, flags.showProgress)
+	return writeReport(results)
+}
+
+// writeReport renders results through reportFlags.format (defaulting to
+// text, or json when --progress=false so CI systems always get a
+// machine-readable report by default in non-interactive runs) and writes it
+// to reportFlags.out, or stdout if unset.
+func writeReport(results []*executor.Result) error {
+	format := executor.ReportFormat(reportFlags.format)
+	if format == "" {
+		format = executor.ReportFormatText
+		if !flags.showProgress {
+			format = executor.ReportFormatJSON
+		}
+	}
+
+	reporter, err := executor.ReporterFor(format)
+	if err != nil {
+		return err
+	}
+	data := reporter.Report(results)
+
+	if reportFlags.out != "" {
+		return os.WriteFile(reportFlags.out, data, 0644)
+	}
+	fmt.Print(string(data))
+	if len(data) == 0 || data[len(data)-1] != '\n' {
+		fmt.Println()
+	}
+	return nil
+}
+
+// planStatusCommand reports, per scenario pattern, how many of a plan's
+// tasks a checkpoint file already recorded as completed/failed versus still
+// pending, for `netroub plan status`.
+func planStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status <plan-file> <checkpoint-file>",
+		Short: "Report completed/failed/pending task counts by scenario pattern",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			planPath, checkpointPath := args[0], args[1]
+
+			plan, err := executor.LoadPlan(planPath)
+			if err != nil {
+				return err
+			}
+			statuses, err := executor.PlanStatus(plan, filepath.Dir(planPath), checkpointPath)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range statuses {
+				fmt.Printf("%s: %d completed, %d failed, %d pending (of %d)\n", s.Pattern, s.Completed, s.Failed, s.Pending, s.Total)
+			}
+			return nil
+		},
+	}
+}
+
+// validateCommand parses and validates a scenario or plan file without
+// deploying anything, for `netroub validate <file>`.
+func validateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate <file>",
+		Short: "Validate a scenario or plan file without deploying it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			fileType, err := executor.DetectFileType(path)
+			if err != nil {
+				return err
+			}
+
+			switch fileType {
+			case executor.FileTypePlan:
+				plan, err := executor.LoadPlan(path)
+				if err != nil {
+					return err
+				}
+				entries, err := plan.ExpandScenarios(filepath.Dir(path))
+				if err != nil {
+					return fmt.Errorf("plan %s is invalid: %w", path, err)
+				}
+				if problems := executor.ValidateExpandedScenarios(entries); len(problems) > 0 {
+					for _, p := range problems {
+						fmt.Fprintln(os.Stderr, p)
+					}
+					return fmt.Errorf("plan %s is invalid: %d problem(s) found", path, len(problems))
+				}
+				fmt.Printf("%s is a valid plan, expanding to %d scenario entries\n", path, len(entries))
+				return nil
+			default:
+				vars := parseVarFlags(flags.vars)
+				if problems := executor.ValidateScenarioFile(path, flags.yaml, vars); len(problems) > 0 {
+					for _, p := range problems {
+						fmt.Fprintln(os.Stderr, p)
+					}
+					return fmt.Errorf("scenario %s is invalid: %d problem(s) found", path, len(problems))
+				}
+				fmt.Printf("%s is a valid scenario\n", path)
+				return nil
+			}
+		},
+	}
+}
+
+// listCommand groups read-only listing subcommands under `netroub list`.
+func listCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List resolved scenarios/tasks without running them",
+	}
+	cmd.AddCommand(listScenariosCommand())
+	return cmd
+}
+
+// listScenariosCommand expands a plan's scenario entries (globs,
+// matrix/exclude/include sweeps) and prints every resolved scenario path,
+// for `netroub list scenarios <plan.yaml>`.
+func listScenariosCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "scenarios <plan-file>",
+		Short: "Print every scenario path a plan resolves to",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			planPath := args[0]
+			plan, err := executor.LoadPlan(planPath)
+			if err != nil {
+				return err
+			}
+			entries, err := plan.ExpandScenarios(filepath.Dir(planPath))
+			if err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				fmt.Println(entry.Pattern)
+			}
+			return nil
+		},
+	}
+}
+
+// cleanupCommand destroys any leftover containerlab labs/containers/networks
+// matching a name prefix, for `netroub cleanup <prefix>`.
+func cleanupCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cleanup <prefix>",
+		Short: "Destroy leftover containerlab labs matching a name prefix",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			containersRemoved, networksRemoved, err := executor.CleanupLabsWithPrefix(args[0], flags.dryRun)
+			if err != nil {
+				return err
+			}
+			if !flags.dryRun {
+				fmt.Printf("Removed %d containers, %d networks\n", containersRemoved, networksRemoved)
+			}
+			return nil
+		},
+	}
+}
+
+// inspectCommand reads and pretty-prints a collected run's manifest.json,
+// for `netroub inspect <log-dir>`.
+func inspectCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "inspect <log-dir>",
+		Short: "Print a collected run's manifest.json",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestPath := filepath.Join(args[0], "manifest.json")
+			data, err := os.ReadFile(manifestPath)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", manifestPath, err)
+			}
+
+			var m manifest.Manifest
+			if err := json.Unmarshal(data, &m); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", manifestPath, err)
+			}
+
+			fmt.Printf("Scenario: %s (%s)\n", m.ScenarioName, m.ScenarioPath)
+			fmt.Printf("Topology: %s\n", m.TopologyPath)
+			fmt.Printf("Run: %s -> %s\n", m.RunStart.Format(time.RFC3339), m.RunEnd.Format(time.RFC3339))
+			fmt.Printf("Hosts (%d):\n", len(m.Hosts))
+			for _, h := range m.Hosts {
+				fmt.Printf("  %s (%s)\n", h.Host, h.Container)
+			}
+			fmt.Printf("Events (%d):\n", len(m.Events))
+			for _, e := range m.Events {
+				status := "ok"
+				if e.Error != "" {
+					status = "error: " + e.Error
+				}
+				fmt.Printf("  [%d] %s host=%s %s\n", e.Index, e.Type, e.Host, status)
+			}
+			return nil
+		},
+	}
+}
+
+// loadtestCommand drives a JSON/YAML workload spec through pkg/loadtest,
+// for `netroub loadtest`.
+func loadtestCommand() *cobra.Command {
+	var configPath, telemetryKind, telemetryFile, telemetryKafkaTopic string
+	var telemetryKafkaBrokers []string
+
+	cmd := &cobra.Command{
+		Use:   "loadtest",
+		Short: "Run a load test against a mix of scenarios",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if configPath == "" {
+				return fmt.Errorf("usage: netroub loadtest --config <workload-spec-file>")
+			}
+
+			spec, err := loadtest.LoadWorkloadSpec(configPath)
+			if err != nil {
+				return err
+			}
+
+			sink, err := telemetry.NewSink(telemetry.Config{
+				Kind:         telemetry.Kind(telemetryKind),
+				FilePath:     telemetryFile,
+				KafkaBrokers: telemetryKafkaBrokers,
+				KafkaTopic:   telemetryKafkaTopic,
+			})
+			if err != nil {
+				return err
+			}
+			defer sink.Close()
+
+			runner := executor.NewScenarioRunner(executor.RunnerOptions{
+				NoReuse: flags.noReuse,
+				Vars:    flags.vars,
+				Docker:  flags.dockerOpt,
+			})
+			runner.Telemetry = sink
+			report, err := loadtest.NewRunner(*spec, runner).Run(rootCtx)
+			if err != nil {
+				return err
+			}
+
+			report.Print()
+			return runner.CleanupReused()
+		},
+	}
+
+	cmd.Flags().StringVar(&configPath, "config", "", "Workload spec file (YAML or JSON)")
+	cmd.Flags().StringVar(&telemetryKind, "telemetry", "", "Stream task/deploy/event/destroy records to a sink: stdout, file, or kafka (default: disabled)")
+	cmd.Flags().StringVar(&telemetryFile, "telemetry-file", "", "NDJSON file path for --telemetry=file")
+	cmd.Flags().StringSliceVar(&telemetryKafkaBrokers, "telemetry-kafka-broker", nil, "Kafka broker address for --telemetry=kafka (repeatable)")
+	cmd.Flags().StringVar(&telemetryKafkaTopic, "telemetry-kafka-topic", "", "Kafka topic for --telemetry=kafka")
+	return cmd
+}
+
+// revertCommand reverts the config file changes recorded in a
+// ConfigChangeJournal left behind by a scenario that crashed mid-run,
+// for `netroub revert <journal-file>`. journal-file is the
+// config-journal-<runID>.json events.JournalPath wrote alongside that
+// run's control.log.
+func revertCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revert <journal-file>",
+		Short: "Roll back a crashed scenario's config file changes from its journal",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			journalPath := args[0]
+
+			journal, err := events.LoadConfigChangeJournal(journalPath)
+			if err != nil {
+				return err
+			}
+			if err := journal.Restore(); err != nil {
+				return fmt.Errorf("revert: %w", err)
+			}
+			if err := os.Remove(journalPath); err != nil && !os.IsNotExist(err) {
+				logrus.Warnf("Failed to remove journal %s after revert: %v", journalPath, err)
+			}
+
+			fmt.Printf("Reverted %d file(s) from %s\n", len(journal.Files), journalPath)
+			return nil
+		},
+	}
+}
+
+// benchCommand times deploy/destroy/event operations against a scenario's
+// topology, for `netroub bench <scenario> --ops deploy,destroy,event
+// --iterations N --warmup W`. It calls the same Deploy/Destroy/
+// EventExecutor.Execute paths a normal run uses, so its numbers track the
+// cost of the networkOpMu-serialized deploy path the same way a
+// repeat/loadtest run's wall-clock does, but broken out per phase and
+// printed in `go test -bench`/benchstat-compatible format instead.
+func benchCommand() *cobra.Command {
+	var benchYAML bool
+	var ops string
+	var iterations, warmup int
+
+	cmd := &cobra.Command{
+		Use:   "bench <scenario>",
+		Short: "Benchmark deploy/destroy/event operations against a scenario's topology",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var benchOps []executor.BenchOp
+			for _, op := range strings.Split(ops, ",") {
+				benchOps = append(benchOps, executor.BenchOp(strings.TrimSpace(op)))
+			}
+
+			cfg := executor.BenchConfig{
+				ScenarioPath:   args[0],
+				YAML:           benchYAML,
+				Ops:            benchOps,
+				Iterations:     iterations,
+				Warmup:         warmup,
+				DockerEndpoint: network.NewDockerEndpoint(flags.dockerOpt),
+			}
+
+			results, err := executor.RunBench(rootCtx, cfg)
+			if err != nil {
+				return err
+			}
+			for _, result := range results {
+				fmt.Println(result)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&benchYAML, "yaml", false, "Scenario file is YAML")
+	cmd.Flags().StringVar(&ops, "ops", "deploy,destroy,event", "Comma-separated ops to benchmark: deploy, destroy, event")
+	cmd.Flags().IntVar(&iterations, "iterations", 10, "Measured iterations per op")
+	cmd.Flags().IntVar(&warmup, "warmup", 1, "Discarded iterations run before the measured ones")
+	return cmd
+}
@@ -0,0 +1,209 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/runtime"
+	"github.com/sirupsen/logrus"
+)
+
+// AssertResult records the outcome of one host's assert event check, for the
+// JUnit/JSON trial reports execAssert writes under TrialLogDir.
+type AssertResult struct {
+	Event          int           `json:"event"`
+	Host           string        `json:"host"`
+	Command        string        `json:"command"`
+	Passed         bool          `json:"passed"`
+	FailureMessage string        `json:"failureMessage,omitempty"`
+	Duration       time.Duration `json:"durationNs"`
+}
+
+// execAssert runs event.Command on every host in event.GetHosts(), retrying
+// every PollInterval until its exit code and output match event's Expect*
+// fields or Timeout elapses, and records one AssertResult per host. Results
+// accumulate across every assert event in the trial and are (re-)written in
+// full, as JUnit XML and JSON, to TrialLogDir after each assert event so a
+// report exists even if a later event aborts the scenario. Execute returns
+// an error if any host's assertion failed, so `netroub run`'s exit status
+// can gate a CI pipeline on recovery actually happening, not just the fault
+// having been injected.
+func (e *EventExecutor) execAssert(ctx context.Context, index int) error {
+	event := e.Scenario.Event[index]
+
+	if e.TrialLogDir == "" {
+		return fmt.Errorf("TrialLogDir is not set for assert event")
+	}
+	if event.Command == "" {
+		return fmt.Errorf("assert event requires command")
+	}
+
+	timeout, err := parseDurationOrDefault(event.Timeout, defaultWaitTimeout)
+	if err != nil {
+		return fmt.Errorf("invalid assert timeout %q: %w", event.Timeout, err)
+	}
+	pollInterval, err := parseDurationOrDefault(event.PollInterval, defaultWaitPollInterval)
+	if err != nil {
+		return fmt.Errorf("invalid assert pollInterval %q: %w", event.PollInterval, err)
+	}
+
+	var stdoutRe *regexp.Regexp
+	if event.ExpectStdoutRegex != "" {
+		stdoutRe, err = regexp.Compile(event.ExpectStdoutRegex)
+		if err != nil {
+			return fmt.Errorf("invalid expectStdoutRegex %q: %w", event.ExpectStdoutRegex, err)
+		}
+	}
+
+	assertErr := e.forEachHost(ctx, event, func(ctx context.Context, host, containerName string) error {
+		start := time.Now()
+		passed, failureMessage := e.assertUntil(ctx, containerName, event, timeout, pollInterval, stdoutRe)
+
+		e.addAssertResult(AssertResult{
+			Event:          index,
+			Host:           host,
+			Command:        event.Command,
+			Passed:         passed,
+			FailureMessage: failureMessage,
+			Duration:       time.Since(start),
+		})
+
+		if !passed {
+			return fmt.Errorf("assertion failed: %s", failureMessage)
+		}
+		return nil
+	})
+
+	if err := e.writeAssertReports(); err != nil {
+		logrus.Warnf("Event %d: failed to write assert report: %v", index, err)
+	}
+
+	return assertErr
+}
+
+// assertUntil retries event.Command against containerName every
+// pollInterval until it matches event's Expect* fields or timeout elapses,
+// returning whether it ultimately passed and, if not, why.
+func (e *EventExecutor) assertUntil(ctx context.Context, containerName string, event model.Event, timeout, pollInterval time.Duration, stdoutRe *regexp.Regexp) (passed bool, failureMessage string) {
+	deadline := time.Now().Add(timeout)
+	for {
+		stdout, stderr, exitCode, _ := e.ContainerRuntime.Exec(ctx, containerName, []string{"/bin/sh", "-c", event.Command}, runtime.ExecOptions{})
+
+		msg := assertFailureMessage(event, stdout, stderr, exitCode, stdoutRe)
+		if msg == "" {
+			return true, ""
+		}
+		if time.Now().After(deadline) {
+			return false, msg
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err().Error()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// assertFailureMessage checks stdout/stderr/exitCode against event's
+// Expect* fields, returning "" if they all match or a description of the
+// first mismatch found.
+func assertFailureMessage(event model.Event, stdout, stderr string, exitCode int, stdoutRe *regexp.Regexp) string {
+	if exitCode != event.ExpectExitCode {
+		return fmt.Sprintf("exit code %d, expected %d", exitCode, event.ExpectExitCode)
+	}
+	if event.ExpectStdoutContains != "" && !strings.Contains(stdout, event.ExpectStdoutContains) {
+		return fmt.Sprintf("stdout %q does not contain %q", stdout, event.ExpectStdoutContains)
+	}
+	if stdoutRe != nil && !stdoutRe.MatchString(stdout) {
+		return fmt.Sprintf("stdout %q does not match %q", stdout, stdoutRe.String())
+	}
+	if event.ExpectStderrContains != "" && !strings.Contains(stderr, event.ExpectStderrContains) {
+		return fmt.Sprintf("stderr %q does not contain %q", stderr, event.ExpectStderrContains)
+	}
+	return ""
+}
+
+// addAssertResult appends result to e.assertResults.
+func (e *EventExecutor) addAssertResult(result AssertResult) {
+	e.assertMu.Lock()
+	e.assertResults = append(e.assertResults, result)
+	e.assertMu.Unlock()
+}
+
+// junitTestSuite and junitTestCase model just enough of the JUnit XML schema
+// for CI tooling (Jenkins, GitLab, GitHub Actions) to render assert results.
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+// writeAssertReports writes every AssertResult recorded so far in the trial
+// as JUnit XML (assert-report.xml) and JSON (assert-report.json) under
+// TrialLogDir. It overwrites both files in full each time it's called, so
+// the reports always reflect the complete, consistent set of assert events
+// the trial has run so far, even if a later event aborts the scenario.
+func (e *EventExecutor) writeAssertReports() error {
+	e.assertMu.Lock()
+	results := append([]AssertResult(nil), e.assertResults...)
+	e.assertMu.Unlock()
+
+	if err := e.writeAssertJSON(results); err != nil {
+		return err
+	}
+	return e.writeAssertJUnit(results)
+}
+
+func (e *EventExecutor) writeAssertJSON(results []AssertResult) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling assert report: %w", err)
+	}
+	return os.WriteFile(filepath.Join(e.TrialLogDir, "assert-report.json"), data, 0644)
+}
+
+func (e *EventExecutor) writeAssertJUnit(results []AssertResult) error {
+	suite := junitTestSuite{Name: "netroub-assert"}
+	for _, r := range results {
+		tc := junitTestCase{
+			ClassName: r.Host,
+			Name:      r.Command,
+			Time:      r.Duration.Seconds(),
+		}
+		if !r.Passed {
+			suite.Failures++
+			tc.Failure = &junitFailure{Message: r.FailureMessage}
+		}
+		suite.Tests++
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling assert JUnit report: %w", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	return os.WriteFile(filepath.Join(e.TrialLogDir, "assert-report.xml"), data, 0644)
+}
@@ -0,0 +1,128 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventExecutor_Execute_Assert_Pass(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{execStdout: "BGP state = Established"}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:                 model.EventTypeAssert,
+				Host:                 "r1",
+				Command:              "vtysh -c 'show bgp summary'",
+				ExpectStdoutContains: "Established",
+				Timeout:              "1s",
+				PollInterval:         "1ms",
+			},
+		},
+	}
+	devices := &model.Data{}
+	logDir := t.TempDir()
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	executor.SetTrialLogDir(logDir)
+	err := executor.Execute(context.Background(), 0)
+
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(logDir, "assert-report.json"))
+	require.NoError(t, err)
+	var results []AssertResult
+	require.NoError(t, json.Unmarshal(data, &results))
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Passed)
+	assert.Equal(t, "r1", results[0].Host)
+
+	_, err = os.Stat(filepath.Join(logDir, "assert-report.xml"))
+	assert.NoError(t, err)
+}
+
+func TestEventExecutor_Execute_Assert_FailureRetriesThenReports(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{execStdout: "BGP state = Idle"}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:                 model.EventTypeAssert,
+				Host:                 "r1",
+				Command:              "vtysh -c 'show bgp summary'",
+				ExpectStdoutContains: "Established",
+				Timeout:              "5ms",
+				PollInterval:         "1ms",
+			},
+		},
+	}
+	devices := &model.Data{}
+	logDir := t.TempDir()
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	executor.SetTrialLogDir(logDir)
+	err := executor.Execute(context.Background(), 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "assertion failed")
+
+	data, readErr := os.ReadFile(filepath.Join(logDir, "assert-report.json"))
+	require.NoError(t, readErr)
+	var results []AssertResult
+	require.NoError(t, json.Unmarshal(data, &results))
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Passed)
+	assert.Contains(t, results[0].FailureMessage, "does not contain")
+}
+
+func TestEventExecutor_Execute_Assert_ExitCodeMismatch(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{execErr: errors.New("exit status 1")}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:           model.EventTypeAssert,
+				Host:           "r1",
+				Command:        "test -f /etc/frr/frr.conf",
+				ExpectExitCode: 0,
+				Timeout:        "5ms",
+				PollInterval:   "1ms",
+			},
+		},
+	}
+	devices := &model.Data{}
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	executor.SetTrialLogDir(t.TempDir())
+	err := executor.Execute(context.Background(), 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "exit code 1, expected 0")
+}
+
+func TestEventExecutor_Execute_Assert_RequiresTrialLogDir(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:    model.EventTypeAssert,
+				Host:    "r1",
+				Command: "echo hello",
+			},
+		},
+	}
+	devices := &model.Data{}
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "TrialLogDir")
+}
@@ -8,12 +8,13 @@ import (
 	"strings"
 
 	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/runtime"
 	"github.com/sirupsen/logrus"
 )
 
-func ExecConfigCommand(index int) error {
+func ExecConfigCommand(index int, lab model.LabContext) error {
 	if model.Scenar.Event[index].VtyshChanges != nil {
-		err := ExecVtyshChanges(index)
+		err := ExecVtyshChanges(index, lab)
 		if err != nil {
 			return err
 		}
@@ -86,9 +87,9 @@ func ExecConfigFileChanges(index int) error {
 	return nil
 }
 
-func ExecVtyshChanges(index int) error {
+func ExecVtyshChanges(index int, lab model.LabContext) error {
 	host := model.Scenar.Event[index].Host
-	containerName := model.ClabHostName(host)
+	containerName := lab.ClabHostName(host)
 
 	// Build vtysh command with multiple -c options
 	// Example: vtysh -c 'conf t' -c 'interface net0' -c 'ip ospf cost 100'
@@ -101,7 +102,13 @@ func ExecVtyshChanges(index int) error {
 		}).Debug("Adding vtysh command:")
 	}
 
-	cmd := exec.Command("sudo", args...)
+	elevator, err := runtime.NewPrivilegeElevator()
+	if err != nil {
+		return err
+	}
+	name, elevatedArgs := elevator.Elevate(args[0], args[1:]...)
+
+	cmd := exec.Command(name, elevatedArgs...)
 	logrus.Debugf("Event %d: Execute %s", index, cmd.String())
 
 	output, err := cmd.CombinedOutput()
@@ -1,39 +1,151 @@
 package events
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/3atlab/netroub/pkg/model"
 )
 
-func ExecuteEvent(index int) error {
-	switch model.Scenar.Event[index].Type {
-	case model.EventTypeDummy:
-		err := ExecDummyCommand(index)
-		if err != nil {
-			return err
-		}
-	case model.EventTypePumba:
-		err := ExecPumbaCommand(index)
-		if err != nil {
-			return err
+// EventHandler implements one event type's validation and execution for the
+// legacy, global-Scenar-based ExecuteEvent path (see EventExecutor.Execute
+// for the newer, dependency-injected equivalent used by pkg/executor).
+// Adding an event type means implementing EventHandler and calling
+// RegisterEventHandler, instead of editing ExecuteEvent's dispatch.
+type EventHandler interface {
+	// Validate checks ev's type-specific fields. ValidateScenarioEvents
+	// calls this for every event right after a scenario is parsed, so a
+	// malformed event is rejected before the run starts rather than
+	// partway through it.
+	Validate(ev *model.Event) error
+	// Execute runs the event at index against the package-level model.Scenar,
+	// resolving container names against lab. ctx is the scenario runner's
+	// root context; only shellHandler currently observes cancellation
+	// through it (see ExecShellCommand), but every handler takes it so
+	// EventHandler stays a single interface.
+	Execute(ctx context.Context, index int, lab model.LabContext) error
+}
+
+// eventHandlers is the EventHandler registry ExecuteEvent and
+// ValidateScenarioEvents dispatch through, keyed by model.Event.Type.
+var eventHandlers = map[string]EventHandler{}
+
+// RegisterEventHandler registers h to run events of type eventType,
+// overwriting any handler already registered for it. Downstream consumers
+// use this to add their own event types (traffic generators, BGP-neighbor
+// toggles, ...) without forking ExecuteEvent.
+func RegisterEventHandler(eventType string, h EventHandler) {
+	eventHandlers[eventType] = h
+}
+
+func init() {
+	RegisterEventHandler(model.EventTypeDummy, dummyHandler{})
+	RegisterEventHandler(model.EventTypePumba, pumbaHandler{})
+	RegisterEventHandler(model.EventTypeShell, shellHandler{})
+	RegisterEventHandler(model.EventTypeConfig, configHandler{})
+}
+
+// ValidateScenarioEvents runs Validate on every event in events against its
+// registered handler, failing on the first unregistered type or invalid
+// event. Callers parsing a scenario (see main.go's runScenario) should call
+// this alongside model.ValidateHostNames, before any event runs.
+func ValidateScenarioEvents(events []model.Event) error {
+	for i, ev := range events {
+		h, ok := eventHandlers[ev.Type]
+		if !ok {
+			return fmt.Errorf("event %d: invalid event type %s", i, ev.Type)
 		}
-	case model.EventTypeShell:
-		err := ExecShellCommand(index)
-		if err != nil {
-			return err
+		if err := h.Validate(&ev); err != nil {
+			return fmt.Errorf("event %d: %w", i, err)
 		}
-	case model.EventTypeConfig:
-		err := ExecConfigCommand(index)
-		if err != nil {
-			return err
+	}
+	return nil
+}
+
+// ExecuteEvent runs the event at index against the package-level
+// model.Scenar, dispatching to whichever EventHandler is registered for its
+// Type and resolving container names against lab. ctx is the scenario
+// runner's root context, propagated so Ctrl-C/scenario-abort can cancel an
+// in-flight shell event (see EventHandler.Execute).
+func ExecuteEvent(ctx context.Context, index int, lab model.LabContext) error {
+	event := model.Scenar.Event[index]
+	h, ok := eventHandlers[event.Type]
+	if !ok {
+		return fmt.Errorf("invalid event type %s", event.Type)
+	}
+	return h.Execute(ctx, index, lab)
+}
+
+// dummyHandler runs a dummy event, which just holds the scenario open for
+// Scenar.Duration.
+type dummyHandler struct{}
+
+func (dummyHandler) Validate(ev *model.Event) error { return nil }
+func (dummyHandler) Execute(ctx context.Context, index int, lab model.LabContext) error {
+	return ExecDummyCommand(index)
+}
+
+// pumbaHandler runs a pumba event via the existing Pumba integration.
+type pumbaHandler struct{}
+
+func (pumbaHandler) Validate(ev *model.Event) error { return nil }
+func (pumbaHandler) Execute(ctx context.Context, index int, lab model.LabContext) error {
+	return ExecPumbaCommand(index, lab)
+}
+
+// shellHandler runs a shell event's ShellCommands in each of its hosts'
+// containers.
+type shellHandler struct{}
+
+func (shellHandler) Validate(ev *model.Event) error { return nil }
+func (shellHandler) Execute(ctx context.Context, index int, lab model.LabContext) error {
+	_, err := ExecShellCommand(ctx, index, lab)
+	return err
+}
+
+// configHandler composes vtyshHandler and configFileHandler: a config
+// event's VtyshChanges and ConfigFileChanges are independent, and a
+// scenario may set either or both.
+type configHandler struct{}
+
+func (configHandler) Validate(ev *model.Event) error {
+	if err := (vtyshHandler{}).Validate(ev); err != nil {
+		return err
+	}
+	return (configFileHandler{}).Validate(ev)
+}
+func (configHandler) Execute(ctx context.Context, index int, lab model.LabContext) error {
+	return ExecConfigCommand(index, lab)
+}
+
+// vtyshHandler runs a config event's VtyshChanges.
+type vtyshHandler struct{}
+
+func (vtyshHandler) Validate(ev *model.Event) error {
+	if len(ev.VtyshChanges) > 0 && ev.Host == "" {
+		return fmt.Errorf("vtyshChanges requires host to be set")
+	}
+	return nil
+}
+func (vtyshHandler) Execute(ctx context.Context, index int, lab model.LabContext) error {
+	return ExecVtyshChanges(index, lab)
+}
+
+// configFileHandler runs a config event's ConfigFileChanges.
+type configFileHandler struct{}
+
+func (configFileHandler) Validate(ev *model.Event) error {
+	for _, c := range ev.ConfigFileChanges {
+		if c.Line <= 0 {
+			return fmt.Errorf("configFileChanges: line must be >= 1, got %d", c.Line)
 		}
-	default:
-		return fmt.Errorf("invalid event type %s", model.Scenar.Event[index].Type)
 	}
 	return nil
 }
+func (configFileHandler) Execute(ctx context.Context, index int, lab model.LabContext) error {
+	return ExecConfigFileChanges(index)
+}
 
 func ExecDummyCommand(index int) error {
 	dur, err := time.ParseDuration(model.Scenar.Duration)
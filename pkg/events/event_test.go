@@ -0,0 +1,86 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateScenarioEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		events  []model.Event
+		wantErr bool
+	}{
+		{
+			name:   "dummy event is valid",
+			events: []model.Event{{Type: model.EventTypeDummy}},
+		},
+		{
+			name:    "unregistered event type is rejected",
+			events:  []model.Event{{Type: "nonexistent"}},
+			wantErr: true,
+		},
+		{
+			name: "vtyshChanges without host is rejected",
+			events: []model.Event{{
+				Type:         model.EventTypeConfig,
+				VtyshChanges: []string{"conf t"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "vtyshChanges with host is valid",
+			events: []model.Event{{
+				Type:         model.EventTypeConfig,
+				Host:         "r1",
+				VtyshChanges: []string{"conf t"},
+			}},
+		},
+		{
+			name: "configFileChanges with line < 1 is rejected",
+			events: []model.Event{{
+				Type:              model.EventTypeConfig,
+				Host:              "r1",
+				ConfigFileChanges: []model.ConfigFileChanges{{File: "ospfd.conf", Line: 0, Command: "!"}},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateScenarioEvents(tt.events)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// customHandler is a minimal EventHandler used to exercise
+// RegisterEventHandler from outside the package's built-ins.
+type customHandler struct{ executed bool }
+
+func (h *customHandler) Validate(ev *model.Event) error { return nil }
+func (h *customHandler) Execute(ctx context.Context, index int, lab model.LabContext) error {
+	h.executed = true
+	return nil
+}
+
+func TestRegisterEventHandler(t *testing.T) {
+	handler := &customHandler{}
+	RegisterEventHandler("custom", handler)
+	defer delete(eventHandlers, "custom")
+
+	model.Scenar.Event = []model.Event{{Type: "custom"}}
+
+	err := ExecuteEvent(context.Background(), 0, model.NewLabContext("testlab"))
+
+	assert.NoError(t, err)
+	assert.True(t, handler.executed)
+}
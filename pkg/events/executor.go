@@ -7,31 +7,106 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/3atlab/netroub/pkg/model"
 	"github.com/3atlab/netroub/pkg/runtime"
+	"github.com/3atlab/netroub/pkg/telemetry"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// Defaults for wait (and wait-gated collect) events when Timeout or
+// PollInterval are not set.
+const (
+	defaultWaitTimeout      = 30 * time.Second
+	defaultWaitPollInterval = 2 * time.Second
 )
 
 // EventExecutor executes scenario events with injected dependencies.
 // This enables testing without actual Docker/containerlab.
 type EventExecutor struct {
-	Scenario    *model.Scenario
-	Devices     *model.Data
-	LabName     string
-	Runner      runtime.CommandRunner
+	Scenario *model.Scenario
+	Devices  *model.Data
+	LabName  string
+	// Runner executes host-side commands (chown/chmod on copied-out files)
+	// that have no container-engine equivalent.
+	Runner runtime.CommandRunner
+	// ContainerRuntime execs commands and copies files in and out of
+	// containers, via whichever engine (Docker, Podman, ...) the scenario
+	// selects.
+	ContainerRuntime runtime.ContainerRuntime
+	// SSHClient runs shell commands and copies files against an ssh event's
+	// SSH/SFTP target (SSHHost/SSHUser/IdentityFile/KnownHosts) instead of a
+	// containerlab container. Lazily defaulted to runtime.NewSSHClient() the
+	// first time an ssh event runs, so scenarios that never use
+	// EventTypeSSH don't need to supply one.
+	SSHClient   runtime.SSHClient
 	TrialLogDir string // Log directory for the current trial (for collect event)
+
+	// snapshotMu guards copySnapshots and vtyshHosts, which execCopy and
+	// execVtyshChanges populate from concurrently-running events (see
+	// ScenarioRunner.executeEvents) so that Reset can undo them afterwards.
+	snapshotMu    sync.Mutex
+	copySnapshots []copySnapshot
+	vtyshHosts    map[string]bool
+
+	// assertMu guards assertResults, which execAssert appends to (from
+	// concurrently-running hosts, via forEachHost) and reads back to write
+	// the trial's JUnit/JSON assert reports.
+	assertMu      sync.Mutex
+	assertResults []AssertResult
+
+	// configJournal snapshots every file execConfigFileChanges is about to
+	// overwrite, so RestoreConfigChanges (called by the scenario runner at
+	// teardown) can revert them instead of the change leaking into whatever
+	// runs against the same host paths next. Lazily created by journal() on
+	// first use, keyed by LabName.
+	configJournal *ConfigChangeJournal
+
+	// Telemetry, if set, receives event_started/event_finished records around
+	// each Execute call, keyed by LabName as RunID. Unset, telemetry is a
+	// no-op.
+	Telemetry telemetry.Sink
+
+	// VtyshSessions, if set, routes execVtyshChanges through a persistent
+	// vtysh process per container (see VtyshSessionPool) instead of forking
+	// a fresh one every event. Set by ScenarioRunner when the scenario opts
+	// into Scenario.VtyshMode == "session" and the container runtime
+	// supports it; nil falls back to the one-shot exec path.
+	VtyshSessions *VtyshSessionPool
+}
+
+// sink returns e.Telemetry, or telemetry.NoopSink{} if none is configured.
+func (e *EventExecutor) sink() telemetry.Sink {
+	if e.Telemetry == nil {
+		return telemetry.NoopSink{}
+	}
+	return e.Telemetry
+}
+
+// copySnapshot records what copyToContainer overwrote, so Reset can put it
+// back: either the container-side content that was there before (existed
+// true), or nothing, meaning the file did not exist and Reset should remove
+// it instead.
+type copySnapshot struct {
+	containerName string
+	dstPath       string
+	existed       bool
+	content       []byte
 }
 
 // NewEventExecutor creates a new EventExecutor instance.
-func NewEventExecutor(scenario *model.Scenario, devices *model.Data, labName string, runner runtime.CommandRunner) *EventExecutor {
+func NewEventExecutor(scenario *model.Scenario, devices *model.Data, labName string, runner runtime.CommandRunner, containerRuntime runtime.ContainerRuntime) *EventExecutor {
 	return &EventExecutor{
-		Scenario: scenario,
-		Devices:  devices,
-		LabName:  labName,
-		Runner:   runner,
+		Scenario:         scenario,
+		Devices:          devices,
+		LabName:          labName,
+		Runner:           runner,
+		ContainerRuntime: containerRuntime,
 	}
 }
 
@@ -40,27 +115,74 @@ func (e *EventExecutor) SetTrialLogDir(logDir string) {
 	e.TrialLogDir = logDir
 }
 
-// ClabHostName returns the containerlab container name for a host.
+// ClabHostName returns the containerlab container name for a host, as named
+// by the configured ContainerRuntime.
 func (e *EventExecutor) ClabHostName(host string) string {
-	return "clab-" + e.LabName + "-" + host
+	return e.ContainerRuntime.ContainerName(e.LabName, host)
 }
 
-// Execute runs the event at the given index.
-func (e *EventExecutor) Execute(index int) error {
+// Execute runs the event at the given index. ctx bounds the run: canceling
+// it (e.g. Ctrl-C via the CLI, or a ProgressTracker-driven abort) stops any
+// in-flight per-host fan-out in execShell, execCopy, and execCollect once
+// their current host finishes.
+func (e *EventExecutor) Execute(ctx context.Context, index int) error {
 	event := e.Scenario.Event[index]
+	startTime := time.Now()
+	if err := e.sink().Publish(ctx, telemetry.Record{
+		Type:       telemetry.RecordEventStarted,
+		Time:       startTime,
+		RunID:      e.LabName,
+		EventIndex: index,
+		EventType:  event.Type,
+	}); err != nil {
+		logrus.Debugf("Telemetry publish failed: %v", err)
+	}
+
+	err := e.execute(ctx, index, event)
+
+	finishedRec := telemetry.Record{
+		Type:       telemetry.RecordEventFinished,
+		Time:       time.Now(),
+		RunID:      e.LabName,
+		EventIndex: index,
+		EventType:  event.Type,
+		Duration:   time.Since(startTime),
+	}
+	if err != nil {
+		finishedRec.Error = err.Error()
+	}
+	if pubErr := e.sink().Publish(ctx, finishedRec); pubErr != nil {
+		logrus.Debugf("Telemetry publish failed: %v", pubErr)
+	}
+
+	return err
+}
+
+// execute dispatches event to its handler. Split out of Execute so Execute
+// can wrap every event type's run with the same event_started/event_finished
+// telemetry, instead of duplicating that into each case.
+func (e *EventExecutor) execute(ctx context.Context, index int, event model.Event) error {
 	switch event.Type {
 	case model.EventTypeDummy:
 		return e.execDummy(index)
 	case model.EventTypePumba:
 		return e.execPumba(index)
 	case model.EventTypeShell:
-		return e.execShell(index)
+		return e.execShell(ctx, index)
 	case model.EventTypeConfig:
 		return e.execConfig(index)
 	case model.EventTypeCopy:
-		return e.execCopy(index)
+		return e.execCopy(ctx, index)
 	case model.EventTypeCollect:
-		return e.execCollect(index)
+		return e.execCollect(ctx, index)
+	case model.EventTypeWait:
+		return e.execWait(ctx, index)
+	case model.EventTypeSSH:
+		return e.execSSH(ctx, index)
+	case model.EventTypeAssert:
+		return e.execAssert(ctx, index)
+	case model.EventTypeRevert:
+		return e.RestoreConfigChanges()
 	default:
 		return fmt.Errorf("invalid event type %s", event.Type)
 	}
@@ -78,108 +200,200 @@ func (e *EventExecutor) execDummy(index int) error {
 	return nil
 }
 
-// execShell executes shell commands in containers.
-func (e *EventExecutor) execShell(index int) error {
+// execShell executes shell commands in containers, fanning out across hosts
+// per forEachHost.
+func (e *EventExecutor) execShell(ctx context.Context, index int) error {
 	event := e.Scenario.Event[index]
 	shell := event.ShellPath
 	if shell == "" {
 		shell = "/bin/sh"
 	}
 
-	for _, host := range event.GetHosts() {
-		containerName := e.ClabHostName(host)
+	return e.forEachHost(ctx, event, func(ctx context.Context, host, containerName string) error {
 		for _, shellCommand := range event.ShellCommands {
-			escapedCommand := strings.ReplaceAll(shellCommand, `'`, `'"'"'`)
-			input := fmt.Sprintf(`docker exec %s %s -c '%s'`, containerName, shell, escapedCommand)
-
-			logrus.Debugf("Event %d: Execute command: sh -c %s", index, input)
-			_, err := e.Runner.Run("sh", "-c", input)
+			logrus.Debugf("Event %d: Exec in %s: %s -c %s", index, containerName, shell, shellCommand)
+			_, stderr, _, err := e.ContainerRuntime.Exec(ctx, containerName, []string{shell, "-c", shellCommand}, runtime.ExecOptions{})
 			if err != nil {
-				logrus.Warnf("Error while running %s: %s", shellCommand, err)
+				return fmt.Errorf("running %q: %w (stderr: %s)", shellCommand, err, strings.TrimSpace(stderr))
 			}
 		}
+		return nil
+	})
+}
+
+// eventParallelism bounds how many hosts execShell, execCopy, and
+// execCollect process at once for event: event.Parallelism if set, else
+// e.Scenario.Parallelism, else 1 (serial, matching their behavior before
+// per-event fan-out existed).
+func (e *EventExecutor) eventParallelism(event model.Event) int {
+	if event.Parallelism > 0 {
+		return event.Parallelism
 	}
-	return nil
+	if e.Scenario.Parallelism > 0 {
+		return e.Scenario.Parallelism
+	}
+	return 1
+}
+
+// forEachHost runs fn once per host in event.GetHosts(), passing each the
+// host name and its containerlab container name, with at most
+// eventParallelism(event) running at a time. A failing host never stops the
+// others: its error is wrapped in a HostError and aggregated into a single
+// multiError, so a caller can tell whether (and on which hosts, via
+// FailedHosts) the event failed, while every other host still runs to
+// completion. Canceling ctx stops any host whose fn observes it (e.g.
+// through ContainerRuntime calls) and prevents hosts not yet started from
+// starting.
+func (e *EventExecutor) forEachHost(ctx context.Context, event model.Event, fn func(ctx context.Context, host, containerName string) error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, e.eventParallelism(event))
+
+	var mu sync.Mutex
+	var errs multiError
+
+hosts:
+	for _, host := range event.GetHosts() {
+		host := host
+		// Take the slot before starting the goroutine, so hosts start in
+		// GetHosts order (one after another when the bound is 1).
+		select {
+		case sem <- struct{}{}:
+		case <-gctx.Done():
+			mu.Lock()
+			errs.add(gctx.Err())
+			mu.Unlock()
+			break hosts
+		}
+		g.Go(func() error {
+			defer func() { <-sem }()
+
+			containerName := e.ClabHostName(host)
+			if err := fn(gctx, host, containerName); err != nil {
+				mu.Lock()
+				errs.add(&HostError{Host: host, Container: containerName, Err: err})
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		errs.add(err)
+	}
+	return errs.errOrNil()
 }
 
-// execCopy executes file copy operations between host and containers.
-func (e *EventExecutor) execCopy(index int) error {
+// execCopy executes file copy operations between host and containers,
+// fanning out across hosts per forEachHost.
+func (e *EventExecutor) execCopy(ctx context.Context, index int) error {
 	event := e.Scenario.Event[index]
 
-	for _, host := range event.GetHosts() {
-		containerName := e.ClabHostName(host)
+	return e.forEachHost(ctx, event, func(ctx context.Context, host, containerName string) error {
+		var errs multiError
 
 		// Process toContainer (host -> container)
 		for _, fc := range event.ToContainer {
-			if err := e.copyToContainer(index, containerName, fc); err != nil {
-				logrus.Warnf("Error copying to container %s: %s", containerName, err)
+			if err := e.copyToContainer(ctx, index, containerName, fc, event, host); err != nil {
+				errs.add(fmt.Errorf("copying to container: %w", err))
 			}
 		}
 
 		// Process fromContainer (container -> host)
 		for _, fc := range event.FromContainer {
-			if err := e.copyFromContainer(index, containerName, fc); err != nil {
-				logrus.Warnf("Error copying from container %s: %s", containerName, err)
+			if err := e.copyFromContainer(ctx, index, containerName, fc); err != nil {
+				errs.add(fmt.Errorf("copying from container: %w", err))
 			}
 		}
-	}
-	return nil
+
+		return errs.errOrNil()
+	})
 }
 
-// copyToContainer copies a file from host to container.
-func (e *EventExecutor) copyToContainer(index int, containerName string, fc model.FileCopy) error {
-	dst := fmt.Sprintf("%s:%s", containerName, fc.Dst)
-	logrus.Debugf("Event %d: Execute docker cp %s %s", index, fc.Src, dst)
+// copyToContainer copies a file from host to container. If fc.Src is
+// templated (see isTemplatedSrc), it is first rendered against host's
+// model.Nodes/Connections entry and event's Vars/PerHostVars, and the
+// rendered file is copied in its place.
+func (e *EventExecutor) copyToContainer(ctx context.Context, index int, containerName string, fc model.FileCopy, event model.Event, host string) error {
+	srcPath, dst := fc.Src, fc.Dst
 
-	output, err := e.Runner.Run("docker", "cp", fc.Src, dst)
-	if err != nil {
-		return fmt.Errorf("docker cp from %s to %s failed: %w, output: %s", fc.Src, dst, err, strings.TrimSpace(string(output)))
+	if isTemplatedSrc(fc.Src) {
+		rendered, cleanup, err := e.renderCopyTemplate(host, fc, event)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		srcPath = rendered
+		if strings.HasSuffix(fc.Dst, "/") {
+			dst = filepath.Join(fc.Dst, renderedName(fc.Src))
+		}
 	}
 
+	logrus.Debugf("Event %d: Copy %s to %s:%s", index, srcPath, containerName, dst)
+
 	// Determine the destination path for chown/chmod
-	dstPath := fc.Dst
-	if strings.HasSuffix(fc.Dst, "/") {
-		dstPath = filepath.Join(fc.Dst, filepath.Base(fc.Src))
+	dstPath := dst
+	if strings.HasSuffix(dst, "/") {
+		dstPath = filepath.Join(dst, filepath.Base(srcPath))
 	}
 
-	// Apply owner if specified
-	if fc.Owner != "" {
-		logrus.Debugf("Event %d: Execute docker exec %s chown %s %s", index, containerName, fc.Owner, dstPath)
-		output, err := e.Runner.Run("docker", "exec", containerName, "chown", fc.Owner, dstPath)
-		if err != nil {
-			return fmt.Errorf("chown failed: %s, output: %s", err, string(output))
-		}
+	if e.Scenario.Reuse {
+		e.snapshotCopyDest(containerName, dstPath)
 	}
 
-	// Apply mode if specified
-	if fc.Mode != "" {
-		logrus.Debugf("Event %d: Execute docker exec %s chmod %s %s", index, containerName, fc.Mode, dstPath)
-		output, err := e.Runner.Run("docker", "exec", containerName, "chmod", fc.Mode, dstPath)
-		if err != nil {
-			return fmt.Errorf("chmod failed: %s, output: %s", err, string(output))
+	opts := runtime.CopyOptions{Owner: fc.Owner, Mode: fc.Mode}
+	if err := e.ContainerRuntime.CopyTo(ctx, containerName, srcPath, dst, opts); err != nil {
+		return err
+	}
+
+	// A named owner (e.g. "frr:frr") can't be resolved to a uid/gid without
+	// asking the container, so CopyTo leaves it unapplied; fall back to a
+	// chown exec for that case. Numeric owners are already applied via the
+	// copy's tar archive header.
+	if fc.Owner != "" {
+		if _, _, ok := runtime.ParseNumericOwner(fc.Owner); !ok {
+			logrus.Debugf("Event %d: Exec in %s: chown %s %s", index, containerName, fc.Owner, dstPath)
+			if _, stderr, _, err := e.ContainerRuntime.Exec(ctx, containerName, []string{"chown", fc.Owner, dstPath}, runtime.ExecOptions{}); err != nil {
+				return fmt.Errorf("chown failed: %w, output: %s", err, strings.TrimSpace(stderr))
+			}
 		}
 	}
 
 	return nil
 }
 
-// copyFromContainer copies a file from container to host.
-func (e *EventExecutor) copyFromContainer(index int, containerName string, fc model.FileCopy) error {
-	// Ensure destination directory exists
-	dstDir := fc.Dst
-	if !strings.HasSuffix(fc.Dst, "/") {
-		dstDir = filepath.Dir(fc.Dst)
+// snapshotCopyDest records dstPath's content inside containerName before
+// copyToContainer overwrites it, so Reset can restore it afterwards. If
+// dstPath does not exist yet, it records that instead, so Reset knows to
+// remove the file rather than restore it.
+func (e *EventExecutor) snapshotCopyDest(containerName, dstPath string) {
+	tmp, err := os.CreateTemp("", "netroub-reset-*")
+	if err != nil {
+		logrus.Warnf("Reset snapshot: failed to create temp file for %s:%s: %v", containerName, dstPath, err)
+		return
 	}
-	if err := os.MkdirAll(dstDir, 0755); err != nil {
-		return fmt.Errorf("failed to create destination directory %s: %w", dstDir, err)
+	tmpPath := tmp.Name()
+	tmp.Close()
+	defer os.Remove(tmpPath)
+
+	snap := copySnapshot{containerName: containerName, dstPath: dstPath}
+	if err := e.ContainerRuntime.CopyFrom(context.Background(), containerName, dstPath, tmpPath); err == nil {
+		if content, readErr := os.ReadFile(tmpPath); readErr == nil {
+			snap.existed = true
+			snap.content = content
+		}
 	}
 
-	src := fmt.Sprintf("%s:%s", containerName, fc.Src)
-	logrus.Debugf("Event %d: Execute docker cp %s %s", index, src, fc.Dst)
+	e.snapshotMu.Lock()
+	e.copySnapshots = append(e.copySnapshots, snap)
+	e.snapshotMu.Unlock()
+}
+
+// copyFromContainer copies a file from container to host.
+func (e *EventExecutor) copyFromContainer(ctx context.Context, index int, containerName string, fc model.FileCopy) error {
+	logrus.Debugf("Event %d: Copy %s:%s to %s", index, containerName, fc.Src, fc.Dst)
 
-	output, err := e.Runner.Run("docker", "cp", src, fc.Dst)
-	if err != nil {
-		return fmt.Errorf("docker cp from %s to %s failed: %w, output: %s", src, fc.Dst, err, strings.TrimSpace(string(output)))
+	if err := e.ContainerRuntime.CopyFrom(ctx, containerName, fc.Src, fc.Dst); err != nil {
+		return err
 	}
 
 	// Determine the destination path for chown/chmod
@@ -191,7 +405,7 @@ func (e *EventExecutor) copyFromContainer(index int, containerName string, fc mo
 	// Apply owner if specified (on host side)
 	if fc.Owner != "" {
 		logrus.Debugf("Event %d: Execute chown %s %s", index, fc.Owner, dstPath)
-		output, err := e.Runner.Run("chown", fc.Owner, dstPath)
+		output, err := e.Runner.Run(ctx, "chown", fc.Owner, dstPath)
 		if err != nil {
 			return fmt.Errorf("chown failed: %s, output: %s", err, string(output))
 		}
@@ -200,7 +414,7 @@ func (e *EventExecutor) copyFromContainer(index int, containerName string, fc mo
 	// Apply mode if specified (on host side)
 	if fc.Mode != "" {
 		logrus.Debugf("Event %d: Execute chmod %s %s", index, fc.Mode, dstPath)
-		output, err := e.Runner.Run("chmod", fc.Mode, dstPath)
+		output, err := e.Runner.Run(ctx, "chmod", fc.Mode, dstPath)
 		if err != nil {
 			return fmt.Errorf("chmod failed: %s, output: %s", err, string(output))
 		}
@@ -226,33 +440,170 @@ func (e *EventExecutor) execConfig(index int) error {
 	return nil
 }
 
-// execVtyshChanges executes vtysh commands.
+// execVtyshChanges executes vtysh commands, through the session pool when
+// one is configured (see VtyshSessions) and falling back to one process per
+// call otherwise, or if the session path itself fails.
 func (e *EventExecutor) execVtyshChanges(index int) error {
 	event := e.Scenario.Event[index]
 	containerName := e.ClabHostName(event.Host)
 
+	if e.Scenario.Reuse {
+		e.snapshotMu.Lock()
+		if e.vtyshHosts == nil {
+			e.vtyshHosts = make(map[string]bool)
+		}
+		e.vtyshHosts[event.Host] = true
+		e.snapshotMu.Unlock()
+	}
+
+	if e.VtyshSessions != nil {
+		_, err := e.VtyshSessions.Run(context.Background(), containerName, event.VtyshChanges)
+		if err != nil {
+			logrus.Warnf("Event %d: vtysh session failed on %s, falling back to one-shot exec: %v", index, containerName, err)
+		} else {
+			logrus.Debugf("Event %d: vtysh session in %s: %s", index, containerName, strings.Join(event.VtyshChanges, "; "))
+			logrus.Info("configuration changes applied")
+			return nil
+		}
+	}
+
 	// Build vtysh command with multiple -c options
-	args := []string{"docker", "exec", containerName, "vtysh"}
+	cmd := []string{"vtysh"}
 	for _, vtyCommand := range event.VtyshChanges {
-		args = append(args, "-c", vtyCommand)
+		cmd = append(cmd, "-c", vtyCommand)
 		logrus.WithFields(logrus.Fields{
 			"command":   vtyCommand,
 			"container": event.Host,
 		}).Debug("Adding vtysh command")
 	}
 
-	logrus.Debugf("Event %d: Execute sudo %s", index, strings.Join(args, " "))
-	output, err := e.Runner.Run("sudo", args...)
+	logrus.Debugf("Event %d: Exec in %s: %s", index, containerName, strings.Join(cmd, " "))
+	_, stderr, _, err := e.ContainerRuntime.Exec(context.Background(), containerName, cmd, runtime.ExecOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to run vtysh command on %s: %w, command: sudo %s, output: %s",
-			containerName, err, strings.Join(args, " "), strings.TrimSpace(string(output)))
+		return fmt.Errorf("failed to run vtysh command on %s: %w, command: %s, output: %s",
+			containerName, err, strings.Join(cmd, " "), strings.TrimSpace(stderr))
 	}
 
 	logrus.Info("configuration changes applied")
 	return nil
 }
 
-// execConfigFileChanges modifies configuration files.
+// Reset undoes the mutable state this trial touched — files copied via
+// execCopy, vtysh changes applied via execVtyshChanges, and config files
+// edited via execConfigFileChanges (see RestoreConfigChanges) — then runs
+// Scenario.ResetHooks on every measured host. It is meant to run between
+// trial repetitions of a Scenario with Reuse set, in place of tearing the
+// topology down and redeploying it. Reset clears its snapshot state, so the
+// next trial starts from a clean slate.
+func (e *EventExecutor) Reset(ctx context.Context) error {
+	e.snapshotMu.Lock()
+	snapshots := e.copySnapshots
+	e.copySnapshots = nil
+	vtyshHosts := e.vtyshHosts
+	e.vtyshHosts = nil
+	e.snapshotMu.Unlock()
+
+	var lastErr error
+
+	if err := e.RestoreConfigChanges(); err != nil {
+		logrus.Warnf("Reset: failed to restore config file changes: %v", err)
+		lastErr = err
+	}
+
+	// Undo copied files in reverse order, so a destination copied to more
+	// than once during the trial ends up back at its pre-trial state rather
+	// than at some intermediate state.
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		snap := snapshots[i]
+		if snap.existed {
+			if err := e.restoreCopySnapshot(ctx, snap); err != nil {
+				logrus.Warnf("Reset: failed to restore %s:%s: %v", snap.containerName, snap.dstPath, err)
+				lastErr = err
+			}
+			continue
+		}
+		if _, stderr, _, err := e.ContainerRuntime.Exec(ctx, snap.containerName, []string{"rm", "-f", snap.dstPath}, runtime.ExecOptions{}); err != nil {
+			logrus.Warnf("Reset: failed to remove %s:%s: %v (stderr: %s)", snap.containerName, snap.dstPath, err, strings.TrimSpace(stderr))
+			lastErr = err
+		}
+	}
+
+	// Reload each host's startup-config over any running-config changes
+	// vtysh applied during the trial.
+	for host := range vtyshHosts {
+		containerName := e.ClabHostName(host)
+		if _, stderr, _, err := e.ContainerRuntime.Exec(ctx, containerName, []string{"vtysh", "-c", "copy startup-config running-config"}, runtime.ExecOptions{}); err != nil {
+			logrus.Warnf("Reset: failed to reload startup-config on %s: %v (stderr: %s)", containerName, err, strings.TrimSpace(stderr))
+			lastErr = err
+		}
+	}
+
+	// Run scenario-configured cleanup hooks on every measured host, for
+	// state Reset cannot infer on its own (routes, qdiscs, ...).
+	for _, host := range e.Scenario.Hosts {
+		containerName := e.ClabHostName(host)
+		for _, hook := range e.Scenario.ResetHooks {
+			if _, stderr, _, err := e.ContainerRuntime.Exec(ctx, containerName, []string{"/bin/sh", "-c", hook}, runtime.ExecOptions{}); err != nil {
+				logrus.Warnf("Reset: hook %q failed on %s: %v (stderr: %s)", hook, containerName, err, strings.TrimSpace(stderr))
+				lastErr = err
+			}
+		}
+	}
+
+	return lastErr
+}
+
+// restoreCopySnapshot writes snap's pre-trial content back to snap.dstPath
+// inside snap.containerName.
+func (e *EventExecutor) restoreCopySnapshot(ctx context.Context, snap copySnapshot) error {
+	tmp, err := os.CreateTemp("", "netroub-reset-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(snap.content); err != nil {
+		tmp.Close()
+		return err
+	}
+	tmp.Close()
+
+	return e.ContainerRuntime.CopyTo(ctx, snap.containerName, tmpPath, snap.dstPath, runtime.CopyOptions{})
+}
+
+// journal returns e's ConfigChangeJournal, creating it (keyed by LabName)
+// on first use.
+func (e *EventExecutor) journal() *ConfigChangeJournal {
+	if e.configJournal == nil {
+		e.configJournal = NewConfigChangeJournal(e.LabName)
+	}
+	return e.configJournal
+}
+
+// RestoreConfigChanges reverts every config file execConfigFileChanges has
+// overwritten since e was created (or since the last RestoreConfigChanges),
+// and removes the persisted journal, if any. Callers run this at scenario
+// teardown (EventExecutor.Reset, for a reused scenario between trials; the
+// scenario runner, for a one-shot scenario after events finish), whether
+// the run succeeded or failed, so a config-file change never leaks into the
+// next run against the same host paths.
+func (e *EventExecutor) RestoreConfigChanges() error {
+	if e.configJournal == nil {
+		return nil
+	}
+	err := e.configJournal.Restore()
+	if e.TrialLogDir != "" {
+		if rmErr := os.Remove(JournalPath(e.TrialLogDir, e.configJournal.RunID)); rmErr != nil && !os.IsNotExist(rmErr) {
+			logrus.Warnf("Failed to remove config change journal: %v", rmErr)
+		}
+	}
+	e.configJournal = nil
+	return err
+}
+
+// execConfigFileChanges modifies configuration files, journaling each
+// file's pre-change content first so RestoreConfigChanges can revert it.
 func (e *EventExecutor) execConfigFileChanges(index int) error {
 	event := e.Scenario.Event[index]
 	host := event.Host
@@ -261,6 +612,15 @@ func (e *EventExecutor) execConfigFileChanges(index int) error {
 		topoPath := e.findTopoPath()
 		filePath := topoPath + host + "/" + modif.File
 
+		if err := e.journal().Snapshot(filePath); err != nil {
+			return fmt.Errorf("journaling %s before change: %w", filePath, err)
+		}
+		if e.TrialLogDir != "" {
+			if err := e.configJournal.WriteFile(JournalPath(e.TrialLogDir, e.configJournal.RunID)); err != nil {
+				logrus.Warnf("Failed to persist config change journal: %v", err)
+			}
+		}
+
 		file, err := os.Open(filePath)
 		if err != nil {
 			return fmt.Errorf("error opening config file %s: %w", filePath, err)
@@ -294,28 +654,151 @@ func (e *EventExecutor) findTopoPath() string {
 // Note: This method currently delegates to the global Pumba functions
 // because Pumba has its own dependency injection (chaos.DockerClient).
 // Full integration with EventExecutor would require refactoring Pumba usage.
+// The netem effects themselves (delay/loss/corrupt/duplicate/rate) already
+// go through the pluggable NetemDriver (see netem.go) selected by
+// model.Scenario.NetemDriver/Event.NetemDriver.
 func (e *EventExecutor) execPumba(index int) error {
 	// For now, delegate to the existing implementation
 	// This maintains compatibility while we migrate other events
-	return ExecPumbaCommand(index)
+	return ExecPumbaCommand(index, model.NewLabContext(e.LabName))
+}
+
+// execWait blocks until every host in the event is ready per event.For
+// (WaitForHealthy polls ContainerRuntime.Inspect's health status,
+// WaitForCommand runs Command and optionally matches CommandRegex against
+// its stdout), or returns an error once Timeout elapses for any host.
+func (e *EventExecutor) execWait(ctx context.Context, index int) error {
+	event := e.Scenario.Event[index]
+
+	timeout, pollInterval, re, err := resolveWaitCheck(event)
+	if err != nil {
+		return err
+	}
+
+	for _, host := range event.GetHosts() {
+		containerName := e.ClabHostName(host)
+		logrus.Debugf("Event %d: waiting for %s to become %s", index, containerName, waitDescription(event))
+		if err := e.waitForHost(ctx, containerName, event, timeout, pollInterval, re); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveWaitCheck parses event's Timeout, PollInterval and CommandRegex
+// once, up front, so waitForHost doesn't redo it on every poll.
+func resolveWaitCheck(event model.Event) (timeout, pollInterval time.Duration, re *regexp.Regexp, err error) {
+	timeout, err = parseDurationOrDefault(event.Timeout, defaultWaitTimeout)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid wait timeout %q: %w", event.Timeout, err)
+	}
+	pollInterval, err = parseDurationOrDefault(event.PollInterval, defaultWaitPollInterval)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid wait pollInterval %q: %w", event.PollInterval, err)
+	}
+
+	if event.For == model.WaitForCommand {
+		if event.Command == "" {
+			return 0, 0, nil, fmt.Errorf("wait event requires command when for is %q", model.WaitForCommand)
+		}
+		if event.CommandRegex != "" {
+			re, err = regexp.Compile(event.CommandRegex)
+			if err != nil {
+				return 0, 0, nil, fmt.Errorf("invalid commandRegex %q: %w", event.CommandRegex, err)
+			}
+		}
+	}
+	return timeout, pollInterval, re, nil
+}
+
+// parseDurationOrDefault parses s as a duration, or returns def if s is
+// empty.
+func parseDurationOrDefault(s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+	return time.ParseDuration(s)
 }
 
-// PumbaClient interface for future Pumba abstraction
-type PumbaClient interface {
-	RunNetem(ctx context.Context, containers []string, params interface{}) error
-	RunStress(ctx context.Context, container string, params interface{}) error
+// waitDescription names the condition waitForHost polls for, defaulting to
+// WaitForHealthy when event.For is empty.
+func waitDescription(event model.Event) string {
+	if event.For == "" {
+		return model.WaitForHealthy
+	}
+	return event.For
+}
+
+// waitForHost polls containerName until it is ready per event.For, sleeping
+// pollInterval between checks, or returns an error once timeout elapses.
+func (e *EventExecutor) waitForHost(ctx context.Context, containerName string, event model.Event, timeout, pollInterval time.Duration, re *regexp.Regexp) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		ready, checkErr := e.checkReady(ctx, containerName, event, re)
+		if checkErr == nil && ready {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if checkErr != nil {
+				return fmt.Errorf("timed out waiting for %s to become %s: %w", containerName, waitDescription(event), checkErr)
+			}
+			return fmt.Errorf("timed out waiting for %s to become %s", containerName, waitDescription(event))
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// checkReady runs a single readiness check for event.For against
+// containerName.
+func (e *EventExecutor) checkReady(ctx context.Context, containerName string, event model.Event, re *regexp.Regexp) (bool, error) {
+	if event.For == model.WaitForCommand {
+		stdout, _, _, err := e.ContainerRuntime.Exec(ctx, containerName, []string{"/bin/sh", "-c", event.Command}, runtime.ExecOptions{})
+		if err != nil {
+			return false, err
+		}
+		if re == nil {
+			return true, nil
+		}
+		return re.MatchString(stdout), nil
+	}
+
+	info, err := e.ContainerRuntime.Inspect(ctx, containerName)
+	if err != nil {
+		return false, err
+	}
+	return info.HealthStatus == model.WaitForHealthy, nil
 }
 
-// execCollect collects files from containers to the trial log directory.
-func (e *EventExecutor) execCollect(index int) error {
+// execCollect collects files from containers to the trial log directory,
+// fanning out across hosts per forEachHost. If event.For is set, it waits
+// for each host to become ready (see execWait) before collecting from it,
+// so collection doesn't race against the daemon coming up or BGP converging
+// inside FRR containers.
+func (e *EventExecutor) execCollect(ctx context.Context, index int) error {
 	event := e.Scenario.Event[index]
 
 	if e.TrialLogDir == "" {
 		return fmt.Errorf("TrialLogDir is not set for collect event")
 	}
 
-	for _, host := range event.GetHosts() {
-		containerName := e.ClabHostName(host)
+	var timeout, pollInterval time.Duration
+	var re *regexp.Regexp
+	if event.For != "" {
+		var err error
+		timeout, pollInterval, re, err = resolveWaitCheck(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.forEachHost(ctx, event, func(ctx context.Context, host, containerName string) error {
+		if event.For != "" {
+			logrus.Debugf("Event %d: waiting for %s to become %s before collecting", index, containerName, waitDescription(event))
+			if err := e.waitForHost(ctx, containerName, event, timeout, pollInterval, re); err != nil {
+				return fmt.Errorf("collect event %d: %w", index, err)
+			}
+		}
+
 		hostLogDir := filepath.Join(e.TrialLogDir, host)
 
 		// Ensure host log directory exists
@@ -323,26 +806,64 @@ func (e *EventExecutor) execCollect(index int) error {
 			return fmt.Errorf("failed to create log directory %s: %w", hostLogDir, err)
 		}
 
+		var errs multiError
 		for _, file := range event.Files {
-			if err := e.collectFile(index, containerName, file, hostLogDir); err != nil {
-				logrus.Warnf("Error collecting file %s from %s: %v", file, containerName, err)
+			if err := e.collectFile(ctx, index, containerName, file, hostLogDir); err != nil {
+				errs.add(fmt.Errorf("collecting %s: %w", file, err))
 			}
 		}
-	}
-	return nil
+		return errs.errOrNil()
+	})
 }
 
 // collectFile copies a single file from container to the host log directory.
-func (e *EventExecutor) collectFile(index int, containerName, srcPath, hostLogDir string) error {
-	src := fmt.Sprintf("%s:%s", containerName, srcPath)
+func (e *EventExecutor) collectFile(ctx context.Context, index int, containerName, srcPath, hostLogDir string) error {
 	dst := filepath.Join(hostLogDir, filepath.Base(srcPath))
 
-	logrus.Debugf("Event %d: Collect docker cp %s %s", index, src, dst)
+	logrus.Debugf("Event %d: Collect %s:%s to %s", index, containerName, srcPath, dst)
+
+	if err := e.ContainerRuntime.CopyFrom(ctx, containerName, srcPath, dst); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// execSSH runs ShellCommands and ToContainer/FromContainer file copies
+// against event's SSH/SFTP target instead of a containerlab container, for
+// physical devices or VMs mixed into the same scenario.
+func (e *EventExecutor) execSSH(ctx context.Context, index int) error {
+	event := e.Scenario.Event[index]
 
-	output, err := e.Runner.Run("docker", "cp", src, dst)
+	if e.SSHClient == nil {
+		e.SSHClient = runtime.NewSSHClient()
+	}
+
+	session, err := e.SSHClient.Dial(ctx, event.SSHHost, event.SSHUser, event.IdentityFile, event.KnownHosts)
 	if err != nil {
-		return fmt.Errorf("docker cp from %s to %s failed: %w, output: %s",
-			src, dst, err, strings.TrimSpace(string(output)))
+		return fmt.Errorf("ssh %s: %w", event.SSHHost, err)
+	}
+	defer session.Close()
+
+	for _, shellCommand := range event.ShellCommands {
+		logrus.Debugf("Event %d: SSH %s: %s", index, event.SSHHost, shellCommand)
+		if _, stderr, err := session.Run(ctx, shellCommand); err != nil {
+			return fmt.Errorf("running %q on %s: %w (stderr: %s)", shellCommand, event.SSHHost, err, strings.TrimSpace(stderr))
+		}
+	}
+
+	for _, fc := range event.ToContainer {
+		logrus.Debugf("Event %d: SCP %s to %s:%s", index, fc.Src, event.SSHHost, fc.Dst)
+		if err := session.CopyTo(ctx, fc.Src, fc.Dst); err != nil {
+			return fmt.Errorf("copying %s to %s:%s: %w", fc.Src, event.SSHHost, fc.Dst, err)
+		}
+	}
+
+	for _, fc := range event.FromContainer {
+		logrus.Debugf("Event %d: SCP %s:%s to %s", index, event.SSHHost, fc.Src, fc.Dst)
+		if err := session.CopyFrom(ctx, fc.Src, fc.Dst); err != nil {
+			return fmt.Errorf("copying %s:%s to %s: %w", event.SSHHost, fc.Src, fc.Dst, err)
+		}
 	}
 
 	return nil
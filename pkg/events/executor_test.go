@@ -1,12 +1,20 @@
 package events
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
+	"os"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/runtime"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // mockRunner records command calls for testing
@@ -16,7 +24,7 @@ type mockRunner struct {
 	output []byte     // output to return
 }
 
-func (m *mockRunner) Run(name string, args ...string) ([]byte, error) {
+func (m *mockRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
 	call := append([]string{name}, args...)
 	m.calls = append(m.calls, call)
 	return m.output, m.err
@@ -46,10 +54,217 @@ func (m *mockRunner) callContains(substrings ...string) bool {
 	return false
 }
 
+// execCall records one fakeContainerRuntime.Exec call.
+type execCall struct {
+	container string
+	cmd       []string
+}
+
+// copyCall records one fakeContainerRuntime Copy{To,From} call. opts is the
+// zero value for CopyFrom calls, which take no CopyOptions.
+type copyCall struct {
+	container string
+	src       string
+	dst       string
+	opts      runtime.CopyOptions
+}
+
+// fakeContainerRuntime is an in-memory stand-in for runtime.ContainerRuntime.
+// It never touches a real daemon or filesystem, matching how mockRunner
+// stood in for shelling out to the docker CLI. mu guards its recorded-call
+// state since execShell/execCopy/execCollect now fan out across hosts
+// concurrently.
+type fakeContainerRuntime struct {
+	mu            sync.Mutex
+	execCalls     []execCall
+	copyToCalls   []copyCall
+	copyFromCalls []copyCall
+	execErr       error
+	copyErr       error
+
+	// copyFromContent, when set, makes CopyFrom write this content to
+	// dstPath, simulating a pre-existing destination file for Reset's
+	// before-copy snapshot. copyFromNotFound simulates no destination file.
+	copyFromContent  []byte
+	copyFromNotFound bool
+
+	// execStdout is returned as stdout by every Exec call, for tests driving
+	// a wait event's command-mode readiness probe.
+	execStdout string
+
+	// inspectHealthStatuses, when set, returns one status per successive
+	// Inspect call (holding the last entry once exhausted), so tests can
+	// drive a wait event's healthy-mode readiness probe through a few
+	// unhealthy polls before becoming healthy. inspectErr, if set, is
+	// returned by every Inspect call instead.
+	inspectHealthStatuses []string
+	inspectCalls          int
+	inspectErr            error
+
+	// concurrencyProbe, if set, is called (after mu is released) on every Exec, so tests
+	// can observe how many hosts execShell/execCopy/execCollect run at once.
+	concurrencyProbe func()
+}
+
+func (f *fakeContainerRuntime) ContainerName(labName, host string) string {
+	return "clab-" + labName + "-" + host
+}
+
+func (f *fakeContainerRuntime) Exec(ctx context.Context, container string, cmd []string, opts runtime.ExecOptions) (string, string, int, error) {
+	f.mu.Lock()
+	f.execCalls = append(f.execCalls, execCall{container: container, cmd: cmd})
+	probe := f.concurrencyProbe
+	execErr := f.execErr
+	execStdout := f.execStdout
+	f.mu.Unlock()
+
+	if probe != nil {
+		probe()
+	}
+	if execErr != nil {
+		return "", execErr.Error(), 1, execErr
+	}
+	return execStdout, "", 0, nil
+}
+
+func (f *fakeContainerRuntime) CopyTo(ctx context.Context, container, srcPath, dstPath string, opts runtime.CopyOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.copyToCalls = append(f.copyToCalls, copyCall{container: container, src: srcPath, dst: dstPath, opts: opts})
+	return f.copyErr
+}
+
+func (f *fakeContainerRuntime) CopyFrom(ctx context.Context, container, srcPath, dstPath string) error {
+	f.mu.Lock()
+	f.copyFromCalls = append(f.copyFromCalls, copyCall{container: container, src: srcPath, dst: dstPath})
+	copyFromNotFound := f.copyFromNotFound
+	copyErr := f.copyErr
+	copyFromContent := f.copyFromContent
+	f.mu.Unlock()
+
+	if copyFromNotFound {
+		return fmt.Errorf("no such file: %s", srcPath)
+	}
+	if copyErr != nil {
+		return copyErr
+	}
+	if copyFromContent != nil {
+		return os.WriteFile(dstPath, copyFromContent, 0644)
+	}
+	return nil
+}
+
+// StreamExecOutput is not exercised by any EventExecutor path (only
+// NetworkController's tcpdump capture uses it), so this just satisfies
+// runtime.ContainerRuntime without recording anything.
+func (f *fakeContainerRuntime) StreamExecOutput(ctx context.Context, container string, cmd []string, opts runtime.ExecOptions, w io.Writer) error {
+	return f.execErr
+}
+
+func (f *fakeContainerRuntime) Inspect(ctx context.Context, container string) (runtime.ContainerInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.inspectErr != nil {
+		return runtime.ContainerInfo{}, f.inspectErr
+	}
+
+	status := ""
+	if len(f.inspectHealthStatuses) > 0 {
+		idx := f.inspectCalls
+		if idx >= len(f.inspectHealthStatuses) {
+			idx = len(f.inspectHealthStatuses) - 1
+		}
+		status = f.inspectHealthStatuses[idx]
+	}
+	f.inspectCalls++
+
+	return runtime.ContainerInfo{Running: true, HealthStatus: status}, nil
+}
+
+// execContains reports whether any recorded exec call targets container and
+// whose cmd joins into a string containing every substring.
+func (f *fakeContainerRuntime) execContains(container string, substrings ...string) bool {
+	for _, call := range f.execCalls {
+		if container != "" && call.container != container {
+			continue
+		}
+		joined := strings.Join(call.cmd, " ")
+		allFound := true
+		for _, sub := range substrings {
+			if !strings.Contains(joined, sub) {
+				allFound = false
+				break
+			}
+		}
+		if allFound {
+			return true
+		}
+	}
+	return false
+}
+
+// sshDialCall records one fakeSSHClient.Dial call.
+type sshDialCall struct {
+	addr, user, identityFile, knownHosts string
+}
+
+// fakeSSHClient is an in-memory stand-in for runtime.SSHClient.
+type fakeSSHClient struct {
+	dialCalls []sshDialCall
+	dialErr   error
+	session   *fakeSSHSession
+}
+
+func (f *fakeSSHClient) Dial(ctx context.Context, addr, user, identityFile, knownHosts string) (runtime.SSHSession, error) {
+	f.dialCalls = append(f.dialCalls, sshDialCall{addr: addr, user: user, identityFile: identityFile, knownHosts: knownHosts})
+	if f.dialErr != nil {
+		return nil, f.dialErr
+	}
+	if f.session == nil {
+		f.session = &fakeSSHSession{}
+	}
+	return f.session, nil
+}
+
+// fakeSSHSession is an in-memory stand-in for runtime.SSHSession.
+type fakeSSHSession struct {
+	runCmds       []string
+	runErr        error
+	copyToCalls   []copyCall
+	copyFromCalls []copyCall
+	copyErr       error
+	closed        bool
+}
+
+func (f *fakeSSHSession) Run(ctx context.Context, cmd string) (string, string, error) {
+	f.runCmds = append(f.runCmds, cmd)
+	if f.runErr != nil {
+		return "", f.runErr.Error(), f.runErr
+	}
+	return "", "", nil
+}
+
+func (f *fakeSSHSession) CopyTo(ctx context.Context, srcPath, dstPath string) error {
+	f.copyToCalls = append(f.copyToCalls, copyCall{src: srcPath, dst: dstPath})
+	return f.copyErr
+}
+
+func (f *fakeSSHSession) CopyFrom(ctx context.Context, srcPath, dstPath string) error {
+	f.copyFromCalls = append(f.copyFromCalls, copyCall{src: srcPath, dst: dstPath})
+	return f.copyErr
+}
+
+func (f *fakeSSHSession) Close() error {
+	f.closed = true
+	return nil
+}
+
 // --- EventExecutor Tests ---
 
 func TestEventExecutor_Execute_Shell(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Event: []model.Event{
 			{
@@ -61,17 +276,17 @@ func TestEventExecutor_Execute_Shell(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
-	err := executor.Execute(0)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.NoError(t, err)
-	assert.Equal(t, 1, len(mock.calls))
-	// Verify docker exec command was called
-	assert.True(t, mock.callContains("sh", "-c", "docker exec clab-test-lab-r1"))
+	assert.Equal(t, 1, len(docker.execCalls))
+	assert.True(t, docker.execContains("clab-test-lab-r1", "/bin/sh", "-c", "echo hello"))
 }
 
 func TestEventExecutor_Execute_Shell_MultipleHosts(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Event: []model.Event{
 			{
@@ -83,17 +298,18 @@ func TestEventExecutor_Execute_Shell_MultipleHosts(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
-	err := executor.Execute(0)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.NoError(t, err)
-	assert.Equal(t, 2, len(mock.calls))
-	assert.True(t, mock.callContains("clab-test-lab-r1"))
-	assert.True(t, mock.callContains("clab-test-lab-r2"))
+	assert.Equal(t, 2, len(docker.execCalls))
+	assert.True(t, docker.execContains("clab-test-lab-r1"))
+	assert.True(t, docker.execContains("clab-test-lab-r2"))
 }
 
 func TestEventExecutor_Execute_Shell_MultipleCommands(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Event: []model.Event{
 			{
@@ -105,15 +321,117 @@ func TestEventExecutor_Execute_Shell_MultipleCommands(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
-	err := executor.Execute(0)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.NoError(t, err)
-	assert.Equal(t, 2, len(mock.calls))
+	assert.Equal(t, 2, len(docker.execCalls))
+}
+
+func TestEventExecutor_Execute_Shell_ErrorsAggregateAcrossHosts(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{execErr: errors.New("container not found")}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:          model.EventTypeShell,
+				Hosts:         []string{"r1", "r2"},
+				ShellCommands: []string{"echo hello"},
+				Parallelism:   2,
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "clab-test-lab-r1")
+	assert.Contains(t, err.Error(), "clab-test-lab-r2")
+	assert.ElementsMatch(t, []string{"r1", "r2"}, FailedHosts(err))
+}
+
+func TestEventExecutor_Execute_Shell_ParallelismBoundsConcurrency(t *testing.T) {
+	runner := &mockRunner{}
+	var mu sync.Mutex
+	current, maxSeen := 0, 0
+	docker := &fakeContainerRuntime{}
+	docker.concurrencyProbe = func() {
+		mu.Lock()
+		current++
+		if current > maxSeen {
+			maxSeen = current
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		current--
+		mu.Unlock()
+	}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:          model.EventTypeShell,
+				Hosts:         []string{"r1", "r2", "r3", "r4"},
+				ShellCommands: []string{"echo hello"},
+				Parallelism:   2,
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(docker.execCalls))
+	assert.LessOrEqual(t, maxSeen, 2)
+	assert.Greater(t, maxSeen, 1) // sanity: hosts did actually overlap
+}
+
+func TestEventExecutor_Execute_Shell_DefaultParallelismIsSerial(t *testing.T) {
+	runner := &mockRunner{}
+	var mu sync.Mutex
+	current, maxSeen := 0, 0
+	docker := &fakeContainerRuntime{}
+	docker.concurrencyProbe = func() {
+		mu.Lock()
+		current++
+		if current > maxSeen {
+			maxSeen = current
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		current--
+		mu.Unlock()
+	}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:          model.EventTypeShell,
+				Hosts:         []string{"r1", "r2", "r3"},
+				ShellCommands: []string{"echo hello"},
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, maxSeen)
 }
 
 func TestEventExecutor_Execute_Copy_ToContainer(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Event: []model.Event{
 			{
@@ -127,16 +445,19 @@ func TestEventExecutor_Execute_Copy_ToContainer(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
-	err := executor.Execute(0)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.NoError(t, err)
-	assert.Equal(t, 1, len(mock.calls))
-	assert.True(t, mock.callContains("docker", "cp", "./config.conf", "clab-test-lab-r1:/etc/frr/"))
+	assert.Equal(t, 1, len(docker.copyToCalls))
+	assert.Equal(t, "clab-test-lab-r1", docker.copyToCalls[0].container)
+	assert.Equal(t, "./config.conf", docker.copyToCalls[0].src)
+	assert.Equal(t, "/etc/frr/", docker.copyToCalls[0].dst)
 }
 
 func TestEventExecutor_Execute_Copy_WithOwner(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Event: []model.Event{
 			{
@@ -150,18 +471,19 @@ func TestEventExecutor_Execute_Copy_WithOwner(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
-	err := executor.Execute(0)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.NoError(t, err)
-	// docker cp + docker exec chown
-	assert.Equal(t, 2, len(mock.calls))
-	assert.True(t, mock.callContains("docker", "cp"))
-	assert.True(t, mock.callContains("docker", "exec", "chown", "frr:frr"))
+	assert.Equal(t, 1, len(docker.copyToCalls))
+	assert.Equal(t, "frr:frr", docker.copyToCalls[0].opts.Owner)
+	assert.Equal(t, 1, len(docker.execCalls))
+	assert.True(t, docker.execContains("clab-test-lab-r1", "chown", "frr:frr"))
 }
 
 func TestEventExecutor_Execute_Copy_WithOwnerAndMode(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Event: []model.Event{
 			{
@@ -175,19 +497,50 @@ func TestEventExecutor_Execute_Copy_WithOwnerAndMode(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
-	err := executor.Execute(0)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.NoError(t, err)
-	// docker cp + docker exec chown + docker exec chmod
-	assert.Equal(t, 3, len(mock.calls))
-	assert.True(t, mock.callContains("docker", "cp"))
-	assert.True(t, mock.callContains("chown", "frr:frr"))
-	assert.True(t, mock.callContains("chmod", "644"))
+	assert.Equal(t, 1, len(docker.copyToCalls))
+	assert.Equal(t, "644", docker.copyToCalls[0].opts.Mode)
+	assert.Equal(t, "frr:frr", docker.copyToCalls[0].opts.Owner)
+	// A named owner like "frr:frr" can't be resolved to a uid/gid without
+	// asking the container, so it still needs a chown exec; Mode is applied
+	// via the copy's tar header and needs no follow-up exec.
+	assert.Equal(t, 1, len(docker.execCalls))
+	assert.True(t, docker.execContains("clab-test-lab-r1", "chown", "frr:frr"))
+}
+
+func TestEventExecutor_Execute_Copy_WithNumericOwner_NoChownExec(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type: model.EventTypeCopy,
+				Host: "r1",
+				ToContainer: []model.FileCopy{
+					{Src: "./config.conf", Dst: "/etc/frr/", Owner: "1000:1000", Mode: "644"},
+				},
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(docker.copyToCalls))
+	assert.Equal(t, "1000:1000", docker.copyToCalls[0].opts.Owner)
+	// A numeric owner is fully applied via the copy's tar header, so no
+	// chown exec round-trip is needed.
+	assert.Equal(t, 0, len(docker.execCalls))
 }
 
 func TestEventExecutor_Execute_Config_Vtysh(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Event: []model.Event{
 			{
@@ -199,19 +552,20 @@ func TestEventExecutor_Execute_Config_Vtysh(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
-	err := executor.Execute(0)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.NoError(t, err)
-	assert.Equal(t, 1, len(mock.calls))
+	assert.Equal(t, 1, len(docker.execCalls))
 	// Verify vtysh command with multiple -c options
-	assert.True(t, mock.callContains("sudo", "docker", "exec", "clab-test-lab-r1", "vtysh"))
-	assert.True(t, mock.callContains("-c", "conf t"))
-	assert.True(t, mock.callContains("-c", "router bgp 65001"))
+	assert.True(t, docker.execContains("clab-test-lab-r1", "vtysh"))
+	assert.True(t, docker.execContains("clab-test-lab-r1", "-c", "conf t"))
+	assert.True(t, docker.execContains("clab-test-lab-r1", "-c", "router bgp 65001"))
 }
 
 func TestEventExecutor_Execute_Dummy(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Duration: "10ms", // Short duration for test
 		Event: []model.Event{
@@ -222,16 +576,18 @@ func TestEventExecutor_Execute_Dummy(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
-	err := executor.Execute(0)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.NoError(t, err)
 	// Dummy event should not call any commands
-	assert.Equal(t, 0, len(mock.calls))
+	assert.Equal(t, 0, len(docker.execCalls))
+	assert.Equal(t, 0, len(docker.copyToCalls))
 }
 
 func TestEventExecutor_Execute_InvalidType(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Event: []model.Event{
 			{
@@ -241,16 +597,17 @@ func TestEventExecutor_Execute_InvalidType(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
-	err := executor.Execute(0)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid event type")
 }
 
 func TestEventExecutor_Execute_CommandError(t *testing.T) {
-	mock := &mockRunner{
-		err: errors.New("container not found"),
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{
+		execErr: errors.New("container not found"),
 	}
 	scenario := &model.Scenario{
 		Event: []model.Event{
@@ -263,8 +620,8 @@ func TestEventExecutor_Execute_CommandError(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
-	err := executor.Execute(0)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
 
 	// Config event should return error
 	assert.Error(t, err)
@@ -272,14 +629,15 @@ func TestEventExecutor_Execute_CommandError(t *testing.T) {
 }
 
 func TestEventExecutor_ClabHostName(t *testing.T) {
-	executor := &EventExecutor{LabName: "my-lab"}
+	executor := &EventExecutor{LabName: "my-lab", ContainerRuntime: &fakeContainerRuntime{}}
 
 	assert.Equal(t, "clab-my-lab-r1", executor.ClabHostName("r1"))
 	assert.Equal(t, "clab-my-lab-router", executor.ClabHostName("router"))
 }
 
 func TestEventExecutor_Execute_Shell_CustomShell(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Event: []model.Event{
 			{
@@ -292,15 +650,16 @@ func TestEventExecutor_Execute_Shell_CustomShell(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
-	err := executor.Execute(0)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.NoError(t, err)
-	assert.True(t, mock.callContains("/bin/bash"))
+	assert.True(t, docker.execContains("clab-test-lab-r1", "/bin/bash"))
 }
 
 func TestEventExecutor_Execute_Collect(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Event: []model.Event{
 			{
@@ -312,19 +671,22 @@ func TestEventExecutor_Execute_Collect(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
 	executor.SetTrialLogDir("/tmp/test-logs")
-	err := executor.Execute(0)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.NoError(t, err)
-	// Should have 2 docker cp calls (one per file)
-	assert.Equal(t, 2, len(mock.calls))
-	assert.True(t, mock.callContains("docker", "cp", "clab-test-lab-r1:/var/log/frr/frr.log"))
-	assert.True(t, mock.callContains("docker", "cp", "clab-test-lab-r1:/tmp/result.txt"))
+	// Should have 2 copy-from-container calls (one per file)
+	assert.Equal(t, 2, len(docker.copyFromCalls))
+	assert.Equal(t, "clab-test-lab-r1", docker.copyFromCalls[0].container)
+	assert.Equal(t, "/var/log/frr/frr.log", docker.copyFromCalls[0].src)
+	assert.Equal(t, "clab-test-lab-r1", docker.copyFromCalls[1].container)
+	assert.Equal(t, "/tmp/result.txt", docker.copyFromCalls[1].src)
 }
 
 func TestEventExecutor_Execute_Collect_MultipleHosts(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Event: []model.Event{
 			{
@@ -336,19 +698,20 @@ func TestEventExecutor_Execute_Collect_MultipleHosts(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
 	executor.SetTrialLogDir("/tmp/test-logs")
-	err := executor.Execute(0)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.NoError(t, err)
-	// Should have 2 docker cp calls (one per host)
-	assert.Equal(t, 2, len(mock.calls))
-	assert.True(t, mock.callContains("docker", "cp", "clab-test-lab-r1:/var/log/frr/frr.log"))
-	assert.True(t, mock.callContains("docker", "cp", "clab-test-lab-r2:/var/log/frr/frr.log"))
+	// Should have 2 copy-from-container calls (one per host)
+	assert.Equal(t, 2, len(docker.copyFromCalls))
+	assert.Equal(t, "clab-test-lab-r1", docker.copyFromCalls[0].container)
+	assert.Equal(t, "clab-test-lab-r2", docker.copyFromCalls[1].container)
 }
 
 func TestEventExecutor_Execute_Collect_NoTrialLogDir(t *testing.T) {
-	mock := &mockRunner{}
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
 	scenario := &model.Scenario{
 		Event: []model.Event{
 			{
@@ -360,10 +723,371 @@ func TestEventExecutor_Execute_Collect_NoTrialLogDir(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	executor := NewEventExecutor(scenario, devices, "test-lab", mock)
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
 	// TrialLogDir is not set
-	err := executor.Execute(0)
+	err := executor.Execute(context.Background(), 0)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "TrialLogDir is not set")
 }
+
+// --- Wait Tests ---
+
+func TestEventExecutor_Execute_Wait_Healthy(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{inspectHealthStatuses: []string{"starting", "starting", "healthy"}}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:         model.EventTypeWait,
+				Host:         "r1",
+				For:          model.WaitForHealthy,
+				Timeout:      "1s",
+				PollInterval: "1ms",
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, docker.inspectCalls)
+}
+
+func TestEventExecutor_Execute_Wait_Healthy_Timeout(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{inspectHealthStatuses: []string{"unhealthy"}}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:         model.EventTypeWait,
+				Host:         "r1",
+				For:          model.WaitForHealthy,
+				Timeout:      "5ms",
+				PollInterval: "1ms",
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out waiting for clab-test-lab-r1 to become healthy")
+}
+
+func TestEventExecutor_Execute_Wait_Command(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{execStdout: "BGP state = Established"}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:         model.EventTypeWait,
+				Host:         "r1",
+				For:          model.WaitForCommand,
+				Command:      "vtysh -c 'show bgp summary'",
+				CommandRegex: "Established",
+				Timeout:      "1s",
+				PollInterval: "1ms",
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(docker.execCalls))
+	assert.Equal(t, "clab-test-lab-r1", docker.execCalls[0].container)
+}
+
+func TestEventExecutor_Execute_Wait_Command_RegexMismatch(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{execStdout: "BGP state = Idle"}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:         model.EventTypeWait,
+				Host:         "r1",
+				For:          model.WaitForCommand,
+				Command:      "vtysh -c 'show bgp summary'",
+				CommandRegex: "Established",
+				Timeout:      "5ms",
+				PollInterval: "1ms",
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out waiting for clab-test-lab-r1 to become command")
+}
+
+func TestEventExecutor_Execute_Wait_InvalidTimeout(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:    model.EventTypeWait,
+				Host:    "r1",
+				Timeout: "not-a-duration",
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid wait timeout")
+}
+
+func TestEventExecutor_Execute_Collect_WaitsForHealthy(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{inspectHealthStatuses: []string{"starting", "healthy"}}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:         model.EventTypeCollect,
+				Host:         "r1",
+				Files:        []string{"/var/log/frr/frr.log"},
+				For:          model.WaitForHealthy,
+				Timeout:      "1s",
+				PollInterval: "1ms",
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	executor.SetTrialLogDir("/tmp/test-logs")
+	err := executor.Execute(context.Background(), 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, docker.inspectCalls)
+	assert.Equal(t, 1, len(docker.copyFromCalls))
+}
+
+// --- SSH Event Tests ---
+
+func TestEventExecutor_Execute_SSH_ShellAndCopy(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
+	sshClient := &fakeSSHClient{}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:          model.EventTypeSSH,
+				SSHHost:       "tor1.example.com",
+				SSHUser:       "admin",
+				IdentityFile:  "/home/user/.ssh/id_rsa",
+				KnownHosts:    "/home/user/.ssh/known_hosts",
+				ShellCommands: []string{"show running-config"},
+				ToContainer:   []model.FileCopy{{Src: "./tor.conf", Dst: "/etc/tor.conf"}},
+				FromContainer: []model.FileCopy{{Src: "/var/log/tor.log", Dst: "./tor.log"}},
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	executor.SSHClient = sshClient
+	err := executor.Execute(context.Background(), 0)
+
+	assert.NoError(t, err)
+	require.Len(t, sshClient.dialCalls, 1)
+	assert.Equal(t, "tor1.example.com", sshClient.dialCalls[0].addr)
+	assert.Equal(t, "admin", sshClient.dialCalls[0].user)
+	assert.Equal(t, "/home/user/.ssh/id_rsa", sshClient.dialCalls[0].identityFile)
+	assert.Equal(t, "/home/user/.ssh/known_hosts", sshClient.dialCalls[0].knownHosts)
+
+	require.NotNil(t, sshClient.session)
+	assert.Equal(t, []string{"show running-config"}, sshClient.session.runCmds)
+	require.Len(t, sshClient.session.copyToCalls, 1)
+	assert.Equal(t, "./tor.conf", sshClient.session.copyToCalls[0].src)
+	assert.Equal(t, "/etc/tor.conf", sshClient.session.copyToCalls[0].dst)
+	require.Len(t, sshClient.session.copyFromCalls, 1)
+	assert.Equal(t, "/var/log/tor.log", sshClient.session.copyFromCalls[0].src)
+	assert.True(t, sshClient.session.closed)
+}
+
+func TestEventExecutor_Execute_SSH_DialError(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
+	sshClient := &fakeSSHClient{dialErr: errors.New("connection refused")}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{Type: model.EventTypeSSH, SSHHost: "tor1.example.com"},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	executor.SSHClient = sshClient
+	err := executor.Execute(context.Background(), 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
+
+func TestEventExecutor_Execute_SSH_CommandError(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
+	sshClient := &fakeSSHClient{session: &fakeSSHSession{runErr: errors.New("permission denied")}}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{Type: model.EventTypeSSH, SSHHost: "tor1.example.com", ShellCommands: []string{"reload"}},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	executor.SSHClient = sshClient
+	err := executor.Execute(context.Background(), 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "permission denied")
+	assert.True(t, sshClient.session.closed)
+}
+
+// --- Reset Tests ---
+
+func TestEventExecutor_Reset_RestoresOverwrittenFile(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{copyFromContent: []byte("original content")}
+	scenario := &model.Scenario{
+		Reuse: true,
+		Event: []model.Event{
+			{
+				Type:        model.EventTypeCopy,
+				Host:        "r1",
+				ToContainer: []model.FileCopy{{Src: "/tmp/new.conf", Dst: "/etc/frr/frr.conf"}},
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	require := assert.New(t)
+
+	err := executor.Execute(context.Background(), 0)
+	require.NoError(err)
+	require.Len(docker.copyToCalls, 1)
+
+	err = executor.Reset(context.Background())
+	require.NoError(err)
+
+	// Reset should have restored the pre-trial content, i.e. issued a
+	// second CopyTo to the same destination.
+	require.Len(docker.copyToCalls, 2)
+	require.Equal("clab-test-lab-r1", docker.copyToCalls[1].container)
+	require.Equal("/etc/frr/frr.conf", docker.copyToCalls[1].dst)
+
+	restored, err := os.ReadFile(docker.copyToCalls[1].src)
+	require.NoError(err)
+	require.Equal("original content", string(restored))
+}
+
+func TestEventExecutor_Reset_RemovesNewlyCreatedFile(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{copyFromNotFound: true}
+	scenario := &model.Scenario{
+		Reuse: true,
+		Event: []model.Event{
+			{
+				Type:        model.EventTypeCopy,
+				Host:        "r1",
+				ToContainer: []model.FileCopy{{Src: "/tmp/new.conf", Dst: "/etc/frr/extra.conf"}},
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+
+	err := executor.Execute(context.Background(), 0)
+	assert.NoError(t, err)
+
+	err = executor.Reset(context.Background())
+	assert.NoError(t, err)
+
+	assert.True(t, docker.execContains("clab-test-lab-r1", "rm", "-f", "/etc/frr/extra.conf"))
+}
+
+func TestEventExecutor_Reset_ReloadsVtyshStartupConfig(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
+	scenario := &model.Scenario{
+		Reuse: true,
+		Event: []model.Event{
+			{
+				Type:         model.EventTypeConfig,
+				Host:         "r1",
+				VtyshChanges: []string{"interface eth0", "shutdown"},
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+	assert.NoError(t, err)
+
+	err = executor.Reset(context.Background())
+	assert.NoError(t, err)
+
+	assert.True(t, docker.execContains("clab-test-lab-r1", "vtysh", "-c", "copy startup-config running-config"))
+}
+
+func TestEventExecutor_Reset_RunsResetHooks(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
+	scenario := &model.Scenario{
+		Reuse:      true,
+		Hosts:      []string{"r1", "r2"},
+		ResetHooks: []string{"ip route flush table all"},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+
+	err := executor.Reset(context.Background())
+	assert.NoError(t, err)
+
+	assert.True(t, docker.execContains("clab-test-lab-r1", "ip route flush table all"))
+	assert.True(t, docker.execContains("clab-test-lab-r2", "ip route flush table all"))
+}
+
+func TestEventExecutor_Reset_NoopWithoutReuse(t *testing.T) {
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{copyFromContent: []byte("original content")}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type:        model.EventTypeCopy,
+				Host:        "r1",
+				ToContainer: []model.FileCopy{{Src: "/tmp/new.conf", Dst: "/etc/frr/frr.conf"}},
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+	assert.NoError(t, err)
+
+	// Reuse is not set, so nothing was snapshotted and Reset has nothing to
+	// undo.
+	err = executor.Reset(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, docker.copyToCalls, 1)
+}
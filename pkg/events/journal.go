@@ -0,0 +1,122 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// fileBackup is one file's pre-change snapshot inside a ConfigChangeJournal:
+// enough to put it back exactly as it was, or remove it if it did not exist
+// before.
+type fileBackup struct {
+	Path    string      `json:"path"`
+	Content []byte      `json:"content"`
+	Mode    os.FileMode `json:"mode"`
+	Existed bool        `json:"existed"`
+}
+
+// ConfigChangeJournal snapshots every file a config event's
+// ConfigFileChanges is about to overwrite, so Restore can revert them once
+// the scenario is done, instead of the modified file leaking into whatever
+// runs against the same topology next. Keyed by RunID so each deployed
+// topology instance journals independently. The journal is also persisted
+// to disk (see WriteFile/LoadConfigChangeJournal), so a process that
+// crashes mid-scenario can be rolled back by a later one.
+type ConfigChangeJournal struct {
+	RunID string       `json:"runId"`
+	Files []fileBackup `json:"files"`
+}
+
+// NewConfigChangeJournal returns an empty journal for runID.
+func NewConfigChangeJournal(runID string) *ConfigChangeJournal {
+	return &ConfigChangeJournal{RunID: runID}
+}
+
+// Snapshot records path's current content and mode, unless path is already
+// recorded, so the journal always holds path's pre-scenario state rather
+// than whatever an earlier event in the same run already changed it to.
+func (j *ConfigChangeJournal) Snapshot(path string) error {
+	for _, f := range j.Files {
+		if f.Path == path {
+			return nil
+		}
+	}
+
+	backup := fileBackup{Path: path}
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			j.Files = append(j.Files, backup)
+			return nil
+		}
+		return fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s for config change journal: %w", path, err)
+	}
+
+	backup.Content = content
+	backup.Mode = info.Mode()
+	backup.Existed = true
+	j.Files = append(j.Files, backup)
+	return nil
+}
+
+// Restore reverts every file in the journal to its snapshot, in reverse
+// snapshot order, so a path modified more than once during the run ends up
+// back at its true pre-scenario state. A failure restoring one file does
+// not stop the rest; all errors are aggregated.
+func (j *ConfigChangeJournal) Restore() error {
+	var errs multiError
+	for i := len(j.Files) - 1; i >= 0; i-- {
+		f := j.Files[i]
+		if !f.Existed {
+			if err := os.Remove(f.Path); err != nil && !os.IsNotExist(err) {
+				errs.add(fmt.Errorf("removing %s: %w", f.Path, err))
+			}
+			continue
+		}
+		if err := os.WriteFile(f.Path, f.Content, f.Mode); err != nil {
+			errs.add(fmt.Errorf("restoring %s: %w", f.Path, err))
+		}
+	}
+	return errs.errOrNil()
+}
+
+// JournalPath returns where a journal for runID is persisted under logPath,
+// so a scenario's ConfigChangeJournal and its control.log/pcaps live
+// alongside each other.
+func JournalPath(logPath, runID string) string {
+	return filepath.Join(logPath, fmt.Sprintf("config-journal-%s.json", runID))
+}
+
+// WriteFile persists j to path as JSON, so a later process can complete the
+// rollback after a crash (see LoadConfigChangeJournal).
+func (j *ConfigChangeJournal) WriteFile(path string) error {
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling config change journal: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing config change journal %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadConfigChangeJournal reads a journal previously written by WriteFile,
+// e.g. for `netroub revert <journal-file>` to replay after a crash.
+func LoadConfigChangeJournal(path string) (*ConfigChangeJournal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config change journal %s: %w", path, err)
+	}
+	var j ConfigChangeJournal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("parsing config change journal %s: %w", path, err)
+	}
+	return &j, nil
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigChangeJournal_RestoreRewritesModifiedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ospfd.conf")
+	require.NoError(t, os.WriteFile(path, []byte("original\n"), 0644))
+
+	j := NewConfigChangeJournal("run-1")
+	require.NoError(t, j.Snapshot(path))
+	require.NoError(t, os.WriteFile(path, []byte("modified\n"), 0644))
+
+	require.NoError(t, j.Restore())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "original\n", string(content))
+}
+
+func TestConfigChangeJournal_RestoreRemovesFileThatDidNotExist(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "new.conf")
+
+	j := NewConfigChangeJournal("run-1")
+	require.NoError(t, j.Snapshot(path))
+	require.NoError(t, os.WriteFile(path, []byte("created by scenario\n"), 0644))
+
+	require.NoError(t, j.Restore())
+
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestConfigChangeJournal_SnapshotIsIdempotentPerPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ospfd.conf")
+	require.NoError(t, os.WriteFile(path, []byte("original\n"), 0644))
+
+	j := NewConfigChangeJournal("run-1")
+	require.NoError(t, j.Snapshot(path))
+	require.NoError(t, os.WriteFile(path, []byte("first edit\n"), 0644))
+	require.NoError(t, j.Snapshot(path)) // second snapshot of same path is a no-op
+
+	assert.Len(t, j.Files, 1)
+
+	require.NoError(t, os.WriteFile(path, []byte("second edit\n"), 0644))
+	require.NoError(t, j.Restore())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "original\n", string(content))
+}
+
+func TestConfigChangeJournal_WriteFileAndLoadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	confPath := filepath.Join(dir, "ospfd.conf")
+	require.NoError(t, os.WriteFile(confPath, []byte("original\n"), 0644))
+
+	j := NewConfigChangeJournal("run-1")
+	require.NoError(t, j.Snapshot(confPath))
+
+	journalPath := JournalPath(dir, "run-1")
+	require.NoError(t, j.WriteFile(journalPath))
+
+	loaded, err := LoadConfigChangeJournal(journalPath)
+	require.NoError(t, err)
+	assert.Equal(t, "run-1", loaded.RunID)
+	require.Len(t, loaded.Files, 1)
+	assert.Equal(t, confPath, loaded.Files[0].Path)
+
+	require.NoError(t, os.WriteFile(confPath, []byte("modified\n"), 0644))
+	require.NoError(t, loaded.Restore())
+
+	content, err := os.ReadFile(confPath)
+	require.NoError(t, err)
+	assert.Equal(t, "original\n", string(content))
+}
@@ -0,0 +1,90 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// multiError aggregates errors from per-host work fanned out by
+// forEachHost, so one host's failure doesn't mask another's. The zero value
+// is ready to use.
+type multiError struct {
+	errs []error
+}
+
+// add appends err to m if it is non-nil.
+func (m *multiError) add(err error) {
+	if err != nil {
+		m.errs = append(m.errs, err)
+	}
+}
+
+// errOrNil returns m if it holds any errors, or nil otherwise, so callers
+// can return the result of errOrNil directly without a separate length
+// check.
+func (m *multiError) errOrNil() error {
+	if len(m.errs) == 0 {
+		return nil
+	}
+	return m
+}
+
+func (m *multiError) Error() string {
+	msgs := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap exposes the aggregated errors to errors.Is/errors.As via Go's
+// multi-error unwrapping (errors.Join-style).
+func (m *multiError) Unwrap() []error {
+	return m.errs
+}
+
+// HostError reports that Err occurred while forEachHost ran an event
+// against Host (and its containerlab container, Container). Wrapping
+// per-host errors in this type, rather than a plain fmt.Errorf, lets a
+// caller recover which hosts failed programmatically via FailedHosts
+// instead of string-matching the aggregated multiError message.
+type HostError struct {
+	Host      string
+	Container string
+	Err       error
+}
+
+func (e *HostError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Container, e.Err)
+}
+
+func (e *HostError) Unwrap() error {
+	return e.Err
+}
+
+// FailedHosts extracts the Host field of every HostError wrapped (directly
+// or via multiError) in err, for callers that want to know which hosts an
+// event failed on without parsing the aggregated error message. Returns nil
+// if err is nil or wraps no HostError.
+func FailedHosts(err error) []string {
+	if err == nil {
+		return nil
+	}
+	switch e := err.(type) {
+	case *HostError:
+		return []string{e.Host}
+	case *multiError:
+		var hosts []string
+		for _, sub := range e.errs {
+			hosts = append(hosts, FailedHosts(sub)...)
+		}
+		return hosts
+	default:
+		var hostErr *HostError
+		if errors.As(err, &hostErr) {
+			return []string{hostErr.Host}
+		}
+		return nil
+	}
+}
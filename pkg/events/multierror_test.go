@@ -0,0 +1,28 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostError_Error(t *testing.T) {
+	err := &HostError{Host: "r1", Container: "clab-test-lab-r1", Err: errors.New("boom")}
+
+	assert.Equal(t, "clab-test-lab-r1: boom", err.Error())
+	assert.Equal(t, "boom", errors.Unwrap(err).Error())
+}
+
+func TestFailedHosts(t *testing.T) {
+	var m multiError
+	m.add(&HostError{Host: "r1", Container: "clab-test-lab-r1", Err: errors.New("boom")})
+	m.add(&HostError{Host: "r2", Container: "clab-test-lab-r2", Err: errors.New("bang")})
+
+	assert.ElementsMatch(t, []string{"r1", "r2"}, FailedHosts(m.errOrNil()))
+}
+
+func TestFailedHosts_NoHostError(t *testing.T) {
+	assert.Nil(t, FailedHosts(nil))
+	assert.Nil(t, FailedHosts(errors.New("plain error")))
+}
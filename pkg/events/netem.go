@@ -0,0 +1,166 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/sirupsen/logrus"
+)
+
+// NetemDriverKind identifies which backend applies a pumba event's netem
+// effect (delay, loss, corrupt, duplicate, rate).
+type NetemDriverKind string
+
+const (
+	// NetemDriverPumba runs the effect through a per-command Pumba helper
+	// container, as netroub has always done. This is the default.
+	NetemDriverPumba NetemDriverKind = "pumba"
+
+	// NetemDriverNetlink programs the target container's qdiscs directly
+	// over its network namespace, avoiding a helper-container spin-up per
+	// event. It falls back to NetemDriverPumba for commands it can't
+	// express (stop/pause) or when the kernel/namespace doesn't support the
+	// qdisc a command needs.
+	NetemDriverNetlink NetemDriverKind = "netlink"
+)
+
+// DefaultNetemDriverKind is used when a scenario or event does not specify
+// one.
+const DefaultNetemDriverKind = NetemDriverPumba
+
+// NetemEffect is a driver-agnostic description of a single pumba event's
+// netem/container effect, parsed from model.Event.PumbaCommand by
+// parseNetemEffect. A NetemDriver applies it to Containers and reverts it
+// once Duration elapses.
+type NetemEffect struct {
+	// Command is the pumba command name: "delay", "corrupt", "duplicate",
+	// "loss", "rate", "stop", or "pause".
+	Command    string
+	Containers []string
+	Iface      string
+	Duration   time.Duration
+
+	// Time, Jitter, Correlation, Distribution configure "delay".
+	Time         int
+	Jitter       int
+	Correlation  float64
+	Distribution string
+
+	// Percent configures "corrupt", "duplicate", and "loss" packet rates.
+	Percent float64
+
+	// Rate, PacketOverhead, CellSize, CellOverhead configure "rate".
+	Rate           string
+	PacketOverhead int
+	CellSize       int
+	CellOverhead   int
+
+	// Limit bounds the number of containers pumba's random/pattern
+	// selection would touch; unused by the direct Containers list but
+	// carried through for the Pumba driver's docker/stop/pause commands.
+	Limit int
+}
+
+// NetemDriver applies a NetemEffect to its Containers and reverts it once
+// Duration elapses (or ctx is canceled), blocking until that happens.
+type NetemDriver interface {
+	Apply(ctx context.Context, effect NetemEffect) error
+}
+
+// errNetlinkUnsupported is wrapped into errors returned by netlinkDriver.Apply
+// when the effect or environment isn't one NetemDriverNetlink can handle, so
+// NewNetemDriver's fallback wrapper knows to retry against Pumba instead of
+// failing the event outright.
+var errNetlinkUnsupported = errors.New("netem: command not supported by the netlink driver")
+
+// NewNetemDriver returns the NetemDriver implementation for kind. Empty
+// defaults to NetemDriverPumba. NetemDriverNetlink is wrapped so that any
+// errNetlinkUnsupported failure (an unsupported command, or a kernel/netns
+// that rejects the qdisc) is retried against NetemDriverPumba rather than
+// failing the event.
+func NewNetemDriver(kind NetemDriverKind) (NetemDriver, error) {
+	switch kind {
+	case "", NetemDriverPumba:
+		return &PumbaDriver{}, nil
+	case NetemDriverNetlink:
+		netlink, err := newNetlinkDriver()
+		if err != nil {
+			return nil, err
+		}
+		return &fallbackNetemDriver{primary: netlink, fallback: &PumbaDriver{}}, nil
+	default:
+		return nil, fmt.Errorf("unknown netem driver %q", kind)
+	}
+}
+
+// fallbackNetemDriver tries primary first and retries the whole effect
+// against fallback if primary reports errNetlinkUnsupported.
+type fallbackNetemDriver struct {
+	primary  NetemDriver
+	fallback NetemDriver
+}
+
+func (d *fallbackNetemDriver) Apply(ctx context.Context, effect NetemEffect) error {
+	err := d.primary.Apply(ctx, effect)
+	if err == nil || !errors.Is(err, errNetlinkUnsupported) {
+		return err
+	}
+	logrus.Debugf("netem: falling back to pumba for %q on %v: %v", effect.Command, effect.Containers, err)
+	return d.fallback.Apply(ctx, effect)
+}
+
+// netemDriverForEvent resolves the NetemDriver for event index, preferring
+// its own NetemDriver override and falling back to the scenario-wide
+// setting.
+func netemDriverForEvent(index int) (NetemDriver, error) {
+	kind := NetemDriverKind(model.Scenar.NetemDriver)
+	if override := model.Scenar.Event[index].NetemDriver; override != "" {
+		kind = NetemDriverKind(override)
+	}
+	return NewNetemDriver(kind)
+}
+
+// parseNetemEffect builds the driver-agnostic NetemEffect for event index,
+// validating and resolving its hosts into container names the same way the
+// Pumba-only parseNetemCommands used to.
+func parseNetemEffect(index int, lab model.LabContext) (NetemEffect, error) {
+	event := model.Scenar.Event[index]
+	cmdOption := event.PumbaCommand.Options
+
+	dur, err := time.ParseDuration(cmdOption.Duration)
+	if err != nil {
+		return NetemEffect{}, err
+	}
+
+	hosts := event.GetHosts()
+	if len(hosts) == 0 {
+		return NetemEffect{}, fmt.Errorf("no hosts specified for Pumba command")
+	}
+	containerNames := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		if err := model.ValidateHostNames([]string{host}); err != nil {
+			return NetemEffect{}, err
+		}
+		containerNames = append(containerNames, lab.ClabHostName(host))
+	}
+
+	return NetemEffect{
+		Command:        event.PumbaCommand.Name,
+		Containers:     containerNames,
+		Iface:          cmdOption.Interface,
+		Duration:       dur,
+		Time:           cmdOption.Time,
+		Jitter:         cmdOption.Jitter,
+		Correlation:    cmdOption.Correlation,
+		Distribution:   cmdOption.Distribution,
+		Percent:        cmdOption.Percent,
+		Rate:           cmdOption.Rate,
+		PacketOverhead: cmdOption.PacketOverhead,
+		CellSize:       cmdOption.CellSize,
+		CellOverhead:   cmdOption.CellOverhead,
+		Limit:          cmdOption.Limit,
+	}, nil
+}
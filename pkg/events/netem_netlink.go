@@ -0,0 +1,194 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3atlab/netroub/pkg/runtime"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// pidResolver is the subset of runtime.SDKDockerClient netlinkDriver depends
+// on, so tests can supply a fake without a real daemon.
+type pidResolver interface {
+	ContainerPID(ctx context.Context, container string) (int, error)
+}
+
+// netlinkDriver programs netem/tbf qdiscs directly inside a target
+// container's network namespace (opened via /proc/<pid>/ns/net, with the
+// PID resolved through the Docker SDK), instead of spinning up a Pumba
+// helper container per event.
+type netlinkDriver struct {
+	docker pidResolver
+}
+
+// newNetlinkDriver connects to the Docker daemon resolved from the
+// environment, the same way the rest of netroub's runtime backends do.
+func newNetlinkDriver() (*netlinkDriver, error) {
+	cli, err := runtime.NewDockerClient()
+	if err != nil {
+		return nil, err
+	}
+	return &netlinkDriver{docker: cli}, nil
+}
+
+// Apply programs effect's qdisc on every container's Iface, blocks for
+// Duration (or until ctx is canceled), then removes it. Commands this driver
+// can't express (stop/pause) or a kernel/namespace that rejects the qdisc
+// are reported as errNetlinkUnsupported so the caller can fall back to
+// PumbaDriver.
+func (d *netlinkDriver) Apply(ctx context.Context, effect NetemEffect) error {
+	switch effect.Command {
+	case "delay", "corrupt", "duplicate", "loss", "rate":
+	default:
+		return fmt.Errorf("%w: %q", errNetlinkUnsupported, effect.Command)
+	}
+
+	applied := make([]appliedQdisc, 0, len(effect.Containers))
+	for _, container := range effect.Containers {
+		aq, err := d.addQdisc(ctx, container, effect)
+		if err != nil {
+			revertAll(applied)
+			return err
+		}
+		applied = append(applied, aq)
+	}
+
+	select {
+	case <-ctx.Done():
+		revertAll(applied)
+		return ctx.Err()
+	case <-time.After(effect.Duration):
+	}
+
+	revertAll(applied)
+	return nil
+}
+
+// appliedQdisc is what Apply needs to revert a qdisc it successfully added.
+type appliedQdisc struct {
+	handle *netlink.Handle
+	qdisc  netlink.Qdisc
+}
+
+func revertAll(applied []appliedQdisc) {
+	for _, aq := range applied {
+		_ = aq.handle.QdiscDel(aq.qdisc)
+		aq.handle.Delete()
+	}
+}
+
+// addQdisc resolves container's network namespace and programs effect's
+// qdisc on effect.Iface within it.
+func (d *netlinkDriver) addQdisc(ctx context.Context, container string, effect NetemEffect) (appliedQdisc, error) {
+	pid, err := d.docker.ContainerPID(ctx, container)
+	if err != nil {
+		return appliedQdisc{}, fmt.Errorf("resolving netns for %s: %w", container, err)
+	}
+
+	ns, err := netns.GetFromPid(pid)
+	if err != nil {
+		return appliedQdisc{}, fmt.Errorf("%w: opening netns for %s: %v", errNetlinkUnsupported, container, err)
+	}
+	defer ns.Close()
+
+	handle, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return appliedQdisc{}, fmt.Errorf("%w: opening netlink handle for %s: %v", errNetlinkUnsupported, container, err)
+	}
+
+	link, err := handle.LinkByName(effect.Iface)
+	if err != nil {
+		handle.Delete()
+		return appliedQdisc{}, fmt.Errorf("finding interface %s in %s: %w", effect.Iface, container, err)
+	}
+
+	qdisc, err := buildQdisc(link, effect)
+	if err != nil {
+		handle.Delete()
+		return appliedQdisc{}, err
+	}
+
+	if err := handle.QdiscAdd(qdisc); err != nil {
+		handle.Delete()
+		return appliedQdisc{}, fmt.Errorf("%w: adding qdisc on %s: %v", errNetlinkUnsupported, container, err)
+	}
+
+	return appliedQdisc{handle: handle, qdisc: qdisc}, nil
+}
+
+// buildQdisc translates effect into the netlink qdisc that implements it:
+// netem for delay/jitter/loss/corrupt/duplicate, tbf for rate.
+func buildQdisc(link netlink.Link, effect NetemEffect) (netlink.Qdisc, error) {
+	attrs := netlink.QdiscAttrs{
+		LinkIndex: link.Attrs().Index,
+		Handle:    netlink.MakeHandle(1, 0),
+		Parent:    netlink.HANDLE_ROOT,
+	}
+
+	switch effect.Command {
+	case "delay":
+		return netlink.NewNetem(attrs, netlink.NetemQdiscAttrs{
+			Latency:   uint32(time.Duration(effect.Time) * time.Millisecond / time.Microsecond),
+			Jitter:    uint32(time.Duration(effect.Jitter) * time.Millisecond / time.Microsecond),
+			DelayCorr: float32(effect.Correlation),
+		}), nil
+	case "loss":
+		return netlink.NewNetem(attrs, netlink.NetemQdiscAttrs{
+			Loss:     float32(effect.Percent),
+			LossCorr: float32(effect.Correlation),
+		}), nil
+	case "duplicate":
+		return netlink.NewNetem(attrs, netlink.NetemQdiscAttrs{
+			Duplicate:     float32(effect.Percent),
+			DuplicateCorr: float32(effect.Correlation),
+		}), nil
+	case "corrupt":
+		return netlink.NewNetem(attrs, netlink.NetemQdiscAttrs{
+			CorruptProb: float32(effect.Percent),
+			CorruptCorr: float32(effect.Correlation),
+		}), nil
+	case "rate":
+		rateBps, err := parseRate(effect.Rate)
+		if err != nil {
+			return nil, err
+		}
+		return netlink.NewTbf(attrs, rateBps, rateBps/8, uint32(rateBps/8)), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errNetlinkUnsupported, effect.Command)
+	}
+}
+
+// parseRate parses a Pumba-style rate string (e.g. "100kbit", "10mbit") into
+// bytes per second, the unit netlink.NewTbf expects.
+func parseRate(rate string) (uint32, error) {
+	rate = strings.ToLower(strings.TrimSpace(rate))
+	multiplier := uint64(1)
+	switch {
+	case strings.HasSuffix(rate, "kbit"):
+		multiplier = 1000
+		rate = strings.TrimSuffix(rate, "kbit")
+	case strings.HasSuffix(rate, "mbit"):
+		multiplier = 1000 * 1000
+		rate = strings.TrimSuffix(rate, "mbit")
+	case strings.HasSuffix(rate, "gbit"):
+		multiplier = 1000 * 1000 * 1000
+		rate = strings.TrimSuffix(rate, "gbit")
+	case strings.HasSuffix(rate, "bit"):
+		rate = strings.TrimSuffix(rate, "bit")
+	default:
+		return 0, fmt.Errorf("unrecognized rate %q", rate)
+	}
+
+	value, err := strconv.ParseUint(rate, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unrecognized rate %q: %w", rate, err)
+	}
+
+	bytesPerSec := (value * multiplier) / 8
+	return uint32(bytesPerSec), nil
+}
@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+
+	"github.com/alexei-led/pumba/pkg/chaos"
+	"github.com/alexei-led/pumba/pkg/chaos/docker"
+	"github.com/alexei-led/pumba/pkg/chaos/netem"
+	"github.com/pkg/errors"
+)
+
+// PumbaDriver is the original NetemDriver implementation: it translates a
+// NetemEffect into a Pumba chaos.Command and runs it via
+// chaos.RunChaosCommand, which blocks for the command's duration and then
+// reverts it itself.
+type PumbaDriver struct{}
+
+// Apply runs effect through Pumba, blocking until it completes (or ctx is
+// canceled).
+func (d *PumbaDriver) Apply(ctx context.Context, effect NetemEffect) error {
+	globalParams := chaos.GlobalParams{
+		Random:     false,
+		Labels:     nil,
+		Pattern:    "",
+		Names:      effect.Containers,
+		Interval:   0,
+		DryRun:     false,
+		SkipErrors: false,
+	}
+	netemParams := netem.Params{
+		Iface:    effect.Iface,
+		Ips:      nil,
+		Sports:   nil,
+		Dports:   nil,
+		Duration: effect.Duration,
+		Image:    "",
+		Pull:     true,
+		Limit:    0,
+	}
+
+	cmd, err := pumbaCommandFor(effect, &globalParams, &netemParams)
+	if err != nil {
+		return errors.Wrap(err, "error creating netem command")
+	}
+
+	if err := chaos.RunChaosCommand(ctx, cmd, &globalParams); err != nil {
+		return errors.Wrap(err, "error running netem command")
+	}
+	return nil
+}
+
+// pumbaCommandFor builds the chaos.Command for effect.Command, mirroring the
+// switch parseNetemCommands used before the NetemDriver abstraction existed.
+func pumbaCommandFor(effect NetemEffect, globalParams *chaos.GlobalParams, netemParams *netem.Params) (chaos.Command, error) {
+	switch effect.Command {
+	case "delay":
+		return netem.NewDelayCommand(chaos.DockerClient, globalParams, netemParams, effect.Time, effect.Jitter, effect.Correlation, effect.Distribution)
+	case "corrupt":
+		return netem.NewCorruptCommand(chaos.DockerClient, globalParams, netemParams, effect.Percent, effect.Correlation)
+	case "duplicate":
+		return netem.NewDuplicateCommand(chaos.DockerClient, globalParams, netemParams, effect.Percent, effect.Correlation)
+	case "loss":
+		return netem.NewLossCommand(chaos.DockerClient, globalParams, netemParams, effect.Percent, effect.Correlation)
+	case "stop":
+		return docker.NewPauseCommand(chaos.DockerClient, globalParams, netemParams.Duration, effect.Limit), nil
+	case "pause":
+		return docker.NewStopCommand(chaos.DockerClient, globalParams, true, netemParams.Duration, 0, effect.Limit), nil
+	case "rate":
+		return netem.NewRateCommand(chaos.DockerClient, globalParams, netemParams, effect.Rate, effect.PacketOverhead, effect.CellSize, effect.CellOverhead)
+	default:
+		return nil, nil
+	}
+}
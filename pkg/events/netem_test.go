@@ -0,0 +1,133 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubNetemDriver records the effects it was asked to Apply and returns a
+// fixed error.
+type stubNetemDriver struct {
+	applied []NetemEffect
+	err     error
+}
+
+func (d *stubNetemDriver) Apply(ctx context.Context, effect NetemEffect) error {
+	d.applied = append(d.applied, effect)
+	return d.err
+}
+
+func TestNewNetemDriver_Pumba(t *testing.T) {
+	driver, err := NewNetemDriver(NetemDriverPumba)
+	require.NoError(t, err)
+	assert.IsType(t, &PumbaDriver{}, driver)
+}
+
+func TestNewNetemDriver_Empty_DefaultsToPumba(t *testing.T) {
+	driver, err := NewNetemDriver("")
+	require.NoError(t, err)
+	assert.IsType(t, &PumbaDriver{}, driver)
+}
+
+func TestNewNetemDriver_UnknownKind(t *testing.T) {
+	_, err := NewNetemDriver("bogus")
+	assert.Error(t, err)
+}
+
+// TestFallbackNetemDriver_Matrix checks every pumba command's fallback
+// behavior: primary errors wrapping errNetlinkUnsupported retry against the
+// fallback driver, while any other primary outcome (success or an
+// unrelated error) is returned as-is.
+func TestFallbackNetemDriver_Matrix(t *testing.T) {
+	effect := NetemEffect{Command: "delay", Containers: []string{"clab-t-r1"}}
+
+	cases := []struct {
+		name         string
+		primaryErr   error
+		wantFallback bool
+		wantErr      bool
+	}{
+		{name: "unsupported command falls back", primaryErr: errNetlinkUnsupported, wantFallback: true},
+		{name: "unsupported kernel falls back", primaryErr: fmt.Errorf("%w: no qdisc support", errNetlinkUnsupported), wantFallback: true},
+		{name: "success does not fall back", primaryErr: nil, wantFallback: false},
+		{name: "unrelated error does not fall back", primaryErr: errors.New("boom"), wantFallback: false, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			primary := &stubNetemDriver{err: tc.primaryErr}
+			fallback := &stubNetemDriver{}
+			driver := &fallbackNetemDriver{primary: primary, fallback: fallback}
+
+			err := driver.Apply(context.Background(), effect)
+
+			require.Len(t, primary.applied, 1)
+			if tc.wantFallback {
+				require.Len(t, fallback.applied, 1)
+				assert.Equal(t, effect, fallback.applied[0])
+			} else {
+				assert.Empty(t, fallback.applied)
+			}
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else if !tc.wantFallback {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNetemDriverForEvent_ScenarioAndEventOverride(t *testing.T) {
+	orig := model.Scenar
+	defer func() { model.Scenar = orig }()
+
+	model.Scenar = model.Scenario{
+		NetemDriver: string(NetemDriverNetlink),
+		Event: []model.Event{
+			{},                                      // inherits scenario-level "netlink"
+			{NetemDriver: string(NetemDriverPumba)}, // overrides back to "pumba"
+		},
+	}
+
+	driver, err := netemDriverForEvent(1)
+	require.NoError(t, err)
+	assert.IsType(t, &PumbaDriver{}, driver)
+}
+
+func TestParseNetemEffect(t *testing.T) {
+	orig := model.Scenar
+	defer func() { model.Scenar = orig }()
+
+	model.Scenar = model.Scenario{
+		Event: []model.Event{
+			{
+				Type: model.EventTypePumba,
+				Host: "r1",
+				PumbaCommand: model.PumbaCommand{
+					Name: "delay",
+					Options: model.CommandOptions{
+						Duration:    "30s",
+						Interface:   "eth0",
+						Time:        100,
+						Jitter:      10,
+						Correlation: 50,
+					},
+				},
+			},
+		},
+	}
+
+	lab := model.NewLabContext("testlab")
+	effect, err := parseNetemEffect(0, lab)
+	require.NoError(t, err)
+	assert.Equal(t, "delay", effect.Command)
+	assert.Equal(t, []string{lab.ClabHostName("r1")}, effect.Containers)
+	assert.Equal(t, "eth0", effect.Iface)
+	assert.Equal(t, 100, effect.Time)
+}
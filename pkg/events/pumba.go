@@ -2,7 +2,6 @@ package events
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,65 +9,28 @@ import (
 
 	"github.com/3atlab/netroub/pkg/model"
 	"github.com/alexei-led/pumba/pkg/chaos"
-	"github.com/alexei-led/pumba/pkg/chaos/docker"
-	"github.com/alexei-led/pumba/pkg/chaos/netem"
 	"github.com/alexei-led/pumba/pkg/chaos/stress"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
-func ExecNetemCommand(index int) error {
-	dur, err := time.ParseDuration(model.Scenar.Event[index].PumbaCommand.Options.Duration)
+// ExecNetemCommand applies event index's netem effect (delay, corrupt,
+// duplicate, loss, rate, stop, or pause) through the NetemDriver the
+// scenario/event selects (see model.Scenario.NetemDriver), blocking until
+// the effect has run its duration and been reverted.
+func ExecNetemCommand(index int, lab model.LabContext) error {
+	effect, err := parseNetemEffect(index, lab)
 	if err != nil {
 		return err
 	}
 
-	hosts := model.Scenar.Event[index].GetHosts()
-	if len(hosts) == 0 {
-		return fmt.Errorf("no hosts specified for Pumba command")
-	}
-	containerNames := make([]string, 0, len(hosts))
-	for _, host := range hosts {
-		err = model.ValidateHostNames([]string{host})
-		if err != nil {
-			return err
-		}
-		containerNames = append(containerNames, model.ClabHostName(host))
-	}
-
-	globalParams := chaos.GlobalParams{
-		Random:     false,
-		Labels:     nil,
-		Pattern:    "",
-		Names:      containerNames,
-		Interval:   0,
-		DryRun:     false,
-		SkipErrors: false,
-	}
-	netemParams := netem.Params{
-		Iface:    model.Scenar.Event[index].PumbaCommand.Options.Interface,
-		Ips:      nil,
-		Sports:   nil,
-		Dports:   nil,
-		Duration: dur,
-		Image:    "",
-		Pull:     true,
-		Limit:    0,
-	}
-
-	ctx := handleSignals()
-
-	delayCmd, err := parseNetemCommands(index, globalParams, netemParams)
+	driver, err := netemDriverForEvent(index)
 	if err != nil {
-		return errors.Wrap(err, "error creating netem delay command")
+		return err
 	}
 
-	err = chaos.RunChaosCommand(ctx, delayCmd, &globalParams)
-	if err != nil {
-		return errors.Wrap(err, "error running netem delay command")
-
-	}
-	return nil
+	ctx := handleSignals()
+	return driver.Apply(ctx, effect)
 }
 
 func ExecStressCommand(index int) error {
@@ -116,31 +78,6 @@ func handleSignals() context.Context {
 	return ctx
 }
 
-func parseNetemCommands(index int, globalParams chaos.GlobalParams, netemParams netem.Params) (chaos.Command, error) {
-
-	cmdOption := model.Scenar.Event[index].PumbaCommand.Options
-
-	switch model.Scenar.Event[index].PumbaCommand.Name {
-	case "delay":
-		return netem.NewDelayCommand(chaos.DockerClient, &globalParams, &netemParams, cmdOption.Time, cmdOption.Jitter, cmdOption.Correlation, cmdOption.Distribution)
-	case "corrupt":
-		return netem.NewCorruptCommand(chaos.DockerClient, &globalParams, &netemParams, cmdOption.Percent, cmdOption.Correlation)
-	case "duplicate":
-		return netem.NewDuplicateCommand(chaos.DockerClient, &globalParams, &netemParams, cmdOption.Percent, cmdOption.Correlation)
-	case "loss":
-		return netem.NewLossCommand(chaos.DockerClient, &globalParams, &netemParams, cmdOption.Percent, cmdOption.Correlation)
-	case "stop":
-		return docker.NewPauseCommand(chaos.DockerClient, &globalParams, netemParams.Duration, cmdOption.Limit), nil
-	case "pause":
-		return docker.NewStopCommand(chaos.DockerClient, &globalParams, true, netemParams.Duration, 0, cmdOption.Limit), nil
-	case "rate":
-		return netem.NewRateCommand(chaos.DockerClient, &globalParams, &netemParams, cmdOption.Rate, cmdOption.PacketOverhead, cmdOption.CellSize, cmdOption.CellOverhead)
-	default:
-		return nil, nil
-	}
-
-}
-
 func parseStressCommands(index int, globalParams chaos.GlobalParams) (chaos.Command, error) {
 	cmdOption := model.Scenar.Event[index].PumbaCommand.Options
 
@@ -157,10 +94,10 @@ func parseStressCommands(index int, globalParams chaos.GlobalParams) (chaos.Comm
 	}
 }
 
-func ExecPumbaCommand(index int) error {
+func ExecPumbaCommand(index int, lab model.LabContext) error {
 	switch model.Scenar.Event[index].PumbaCommand.Name {
 	case "delay", "corrupt", "duplicate", "loss", "rate", "stop", "pause":
-		return ExecNetemCommand(index)
+		return ExecNetemCommand(index, lab)
 	case "stress":
 		return ExecStressCommand(index)
 	default:
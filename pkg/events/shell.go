@@ -1,33 +1,261 @@
 package events
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/runtime"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 )
 
-func ExecShellCommand(index int) error {
-	shell := model.Scenar.Event[index].ShellPath
+// ShellCommandResult is one ShellCommand invocation's outcome against one
+// host, collected by ExecShellCommand so callers can assert on it instead of
+// only reading logs.
+type ShellCommandResult struct {
+	Host     string
+	Command  string
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Err      error
+}
+
+// ExecShellCommand runs event index's ShellCommands against each of its
+// hosts, fanning out across hosts with at most shellParallelism(event)
+// running at a time via errgroup.SetLimit (see EventExecutor.eventParallelism
+// for the newer, per-EventExecutor equivalent of this same knob). ctx is
+// expected to be the scenario runner's root context, so Ctrl-C/scenario-abort
+// cancels any in-flight docker exec and stops hosts not yet started from
+// starting. If event.FailFast is set, the first failing command cancels
+// every other in-flight host/command (errgroup's standard semantics);
+// otherwise every host/command still runs to completion, every failure is
+// reported in the returned results instead of aborting the event, and once
+// all have finished the failures are returned together as the error.
+//
+// Each ShellCommand invocation is individually bounded by event.Timeout (no
+// deadline if empty) and, if it exits non-zero, retried up to event.Retries
+// additional times with exponential backoff starting at event.RetryBackoff
+// (see runCommandWithRetry). A command that still fails after every retry is
+// reported as a *ShellCommandError, recording how many attempts were made and
+// the last stderr, instead of just the bare exec error.
+func ExecShellCommand(ctx context.Context, index int, lab model.LabContext) ([]ShellCommandResult, error) {
+	event := model.Scenar.Event[index]
+	shell := event.ShellPath
 	if shell == "" {
 		shell = "/bin/sh" // Default shell if not specified
 	}
 
-	for _, host := range model.Scenar.Event[index].GetHosts() {
-		containerName := model.ClabHostName(host)
-		for _, shellCommand := range model.Scenar.Event[index].ShellCommands {
-			escapedCommand := strings.ReplaceAll(shellCommand, `'`, `'"'"'`) // Escape single quotes
-			input := fmt.Sprintf(`docker exec %s %s -c '%s'`, containerName, shell, escapedCommand)
-			cmd := exec.Command("sh", "-c", input)
+	var apiRunner *DockerExecRunner
+	if event.Runner != model.RunnerShell {
+		var err error
+		apiRunner, err = NewDockerExecRunner()
+		if err != nil {
+			return nil, fmt.Errorf("creating Docker exec runner: %w", err)
+		}
+	}
+
+	timeout, err := parseDurationOrDefault(event.Timeout, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeout %q: %w", event.Timeout, err)
+	}
+	retryBackoff, err := parseDurationOrDefault(event.RetryBackoff, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid retryBackoff %q: %w", event.RetryBackoff, err)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(shellParallelism(event))
+
+	var mu sync.Mutex
+	var results []ShellCommandResult
+
+	for _, host := range event.GetHosts() {
+		host := host
+		containerName := lab.ClabHostName(host)
+		g.Go(func() error {
+			for _, shellCommand := range event.ShellCommands {
+				res, attempts := runCommandWithRetry(gctx, apiRunner, index, host, containerName, shell, shellCommand, event.Retries, timeout, retryBackoff)
+				if res.Err != nil {
+					res.Err = &ShellCommandError{
+						Host:     host,
+						Command:  shellCommand,
+						Attempts: attempts,
+						Stderr:   res.Stderr,
+						Err:      res.Err,
+					}
+				}
+
+				mu.Lock()
+				results = append(results, res)
+				mu.Unlock()
+
+				if res.Err != nil {
+					logrus.Warnf("Error while running %s on %s: %s\n", shellCommand, host, res.Err)
+					if event.FailFast {
+						return res.Err
+					}
+				}
+			}
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	if err == nil {
+		var errs multiError
+		for _, r := range results {
+			errs.add(r.Err)
+		}
+		err = errs.errOrNil()
+	}
+	return results, err
+}
+
+// ShellCommandError is the error ExecShellCommand reports for a ShellCommand
+// that still failed after exhausting its retries, so callers/logs see which
+// command, on which host, how many attempts were made, and the last stderr,
+// instead of just the bare exec/Docker error.
+type ShellCommandError struct {
+	Host     string
+	Command  string
+	Attempts int
+	Stderr   string
+	Err      error
+}
+
+func (e *ShellCommandError) Error() string {
+	return fmt.Sprintf("command %q on host %s failed after %d attempt(s): %v (stderr: %s)",
+		e.Command, e.Host, e.Attempts, e.Err, strings.TrimSpace(e.Stderr))
+}
+
+func (e *ShellCommandError) Unwrap() error { return e.Err }
+
+// runCommandWithRetry runs one ShellCommand via apiRunner (if non-nil) or
+// runShellCommand, retrying up to retries additional times while it exits
+// non-zero. Each attempt gets its own timeout deadline (no deadline if
+// timeout is 0); the delay before each retry doubles starting at backoff (no
+// delay if backoff is 0). It returns the last ShellCommandResult and the
+// total number of attempts made.
+func runCommandWithRetry(ctx context.Context, apiRunner *DockerExecRunner, index int, host, containerName, shell, shellCommand string, retries int, timeout, backoff time.Duration) (ShellCommandResult, int) {
+	var res ShellCommandResult
+	for attempt := 1; attempt <= retries+1; attempt++ {
+		attemptCtx := ctx
+		var cancel context.CancelFunc
+		if timeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+		}
+		if apiRunner != nil {
+			res = apiRunner.Run(attemptCtx, host, containerName, shell, shellCommand)
+		} else {
+			res = runShellCommand(attemptCtx, index, host, containerName, shell, shellCommand)
+		}
+		if cancel != nil {
+			cancel()
+		}
+
+		if res.Err == nil || attempt > retries {
+			return res, attempt
+		}
 
-			logrus.Debugf(`Event %d: Execute command "%s"`, index, cmd)
-			_, err := cmd.CombinedOutput()
-			if err != nil {
-				logrus.Warnf("Error while running %s: %s\n", shellCommand, err)
+		if backoff > 0 {
+			delay := backoff * time.Duration(1<<uint(attempt-1))
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return res, attempt
 			}
 		}
 	}
-	return nil
+	return res, retries + 1
+}
+
+// dockerExecAPI is the subset of runtime.DockerClient DockerExecRunner
+// depends on. It mirrors interactiveStarter in vtysh_session.go: kept as its
+// own narrow, unexported interface here rather than requiring the full
+// runtime.DockerClient, since nothing in this file needs its Copy*/
+// StreamExecOutput methods.
+type dockerExecAPI interface {
+	ExecInContainer(ctx context.Context, container string, cmd []string, opts runtime.ExecOptions) (stdout, stderr string, exitCode int, err error)
+}
+
+// DockerExecRunner is model.RunnerAPI: it runs a shell event's commands
+// directly against the Docker Engine API (see runtime.SDKDockerClient),
+// passing Cmd as a real []string instead of concatenating a shell line and
+// escaping quotes into it the way runShellCommand (model.RunnerShell) does.
+type DockerExecRunner struct {
+	Client dockerExecAPI
+}
+
+// NewDockerExecRunner creates a DockerExecRunner backed by the Docker daemon
+// resolved from the environment (see runtime.NewDockerClient).
+func NewDockerExecRunner() (*DockerExecRunner, error) {
+	cli, err := runtime.NewDockerClient()
+	if err != nil {
+		return nil, err
+	}
+	return &DockerExecRunner{Client: cli}, nil
+}
+
+// Run execs shell -c command inside containerName via the Docker API,
+// returning its real exit code (from ContainerExecInspect, via
+// ExecInContainer) instead of runShellCommand's os/exec.ExitError fallback.
+func (r *DockerExecRunner) Run(ctx context.Context, host, containerName, shell, command string) ShellCommandResult {
+	stdout, stderr, exitCode, err := r.Client.ExecInContainer(ctx, containerName, []string{shell, "-c", command}, runtime.ExecOptions{})
+	return ShellCommandResult{
+		Host:     host,
+		Command:  command,
+		Stdout:   stdout,
+		Stderr:   stderr,
+		ExitCode: exitCode,
+		Err:      err,
+	}
+}
+
+// runShellCommand is model.RunnerShell: it runs a single shell command in
+// containerName over a forked `docker exec` shell string, capturing stdout
+// and stderr separately instead of the combined stream the pre-fan-out
+// implementation logged, and canceled via ctx (exec.CommandContext)
+// alongside every other in-flight host.
+func runShellCommand(ctx context.Context, index int, host, containerName, shell, shellCommand string) ShellCommandResult {
+	escapedCommand := strings.ReplaceAll(shellCommand, `'`, `'"'"'`) // Escape single quotes
+	input := fmt.Sprintf(`docker exec %s %s -c '%s'`, containerName, shell, escapedCommand)
+	cmd := exec.CommandContext(ctx, "sh", "-c", input)
+
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	logrus.Debugf(`Event %d: Execute command "%s"`, index, cmd)
+	err := cmd.Run()
+
+	result := ShellCommandResult{
+		Host:    host,
+		Command: shellCommand,
+		Stdout:  stdout.String(),
+		Stderr:  stderr.String(),
+		Err:     err,
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		result.ExitCode = exitErr.ExitCode()
+	}
+	return result
+}
+
+// shellParallelism bounds how many hosts ExecShellCommand processes at once
+// for event: event.Parallelism if set, else model.Scenar.Parallelism, else 1
+// (serial, matching ExecShellCommand's behavior before fan-out existed).
+func shellParallelism(event model.Event) int {
+	if event.Parallelism > 0 {
+		return event.Parallelism
+	}
+	if model.Scenar.Parallelism > 0 {
+		return model.Scenar.Parallelism
+	}
+	return 1
 }
@@ -1,93 +1,26 @@
 package events
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
 	"testing"
+	"time"
 
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/runtime"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-// TestBuildShellCommand tests command generation for shell events
-func TestBuildShellCommand(t *testing.T) {
-	tests := []struct {
-		name          string
-		containerName string
-		shell         string
-		command       string
-		wantArgs      string
-	}{
-		{
-			name:          "simple echo command",
-			containerName: "clab-topo-r1",
-			shell:         "/bin/sh",
-			command:       "echo hello",
-			wantArgs:      "docker exec clab-topo-r1 /bin/sh -c 'echo hello'",
-		},
-		{
-			name:          "command with single quotes",
-			containerName: "clab-topo-r1",
-			shell:         "/bin/sh",
-			command:       "echo 'hello world'",
-			wantArgs:      "docker exec clab-topo-r1 /bin/sh -c 'echo '\"'\"'hello world'\"'\"''",
-		},
-		{
-			name:          "vtysh command",
-			containerName: "clab-topo-r1",
-			shell:         "/bin/sh",
-			command:       "vtysh -c 'show ip bgp summary'",
-			wantArgs:      "docker exec clab-topo-r1 /bin/sh -c 'vtysh -c '\"'\"'show ip bgp summary'\"'\"''",
-		},
-		{
-			name:          "redirect to file",
-			containerName: "clab-topo-r1",
-			shell:         "/bin/bash",
-			command:       "vtysh -c 'show running-config' > /tmp/config.txt",
-			wantArgs:      "docker exec clab-topo-r1 /bin/bash -c 'vtysh -c '\"'\"'show running-config'\"'\"' > /tmp/config.txt'",
-		},
-		{
-			name:          "ip route command",
-			containerName: "clab-topo-r2",
-			shell:         "/bin/sh",
-			command:       "ip route show",
-			wantArgs:      "docker exec clab-topo-r2 /bin/sh -c 'ip route show'",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := buildShellCommandString(tt.containerName, tt.shell, tt.command)
-			assert.Equal(t, tt.wantArgs, got, "shell command string mismatch")
-		})
-	}
-}
-
-// buildShellCommandString builds the shell command string for testing
-// This mirrors the logic in ExecShellCommand
-func buildShellCommandString(containerName, shell, command string) string {
-	// Escape single quotes (same as in shell.go)
-	escapedCommand := escapeForSingleQuotes(command)
-	return "docker exec " + containerName + " " + shell + " -c '" + escapedCommand + "'"
-}
-
-// escapeForSingleQuotes escapes single quotes for shell command
-// Replaces ' with '"'"'
-func escapeForSingleQuotes(s string) string {
-	result := ""
-	for _, c := range s {
-		if c == '\'' {
-			result += "'\"'\"'"
-		} else {
-			result += string(c)
-		}
-	}
-	return result
-}
-
 // TestDefaultShell tests that default shell is used when not specified
 func TestDefaultShell(t *testing.T) {
 	tests := []struct {
-		name       string
-		shellPath  string
-		wantShell  string
+		name      string
+		shellPath string
+		wantShell string
 	}{
 		{
 			name:      "empty shell uses default",
@@ -116,3 +49,218 @@ func TestDefaultShell(t *testing.T) {
 		})
 	}
 }
+
+func TestShellParallelism(t *testing.T) {
+	orig := model.Scenar
+	defer func() { model.Scenar = orig }()
+
+	tests := []struct {
+		name              string
+		eventParallelism  int
+		scenarParallelism int
+		want              int
+	}{
+		{name: "defaults to serial", want: 1},
+		{name: "event overrides scenario", eventParallelism: 4, scenarParallelism: 2, want: 4},
+		{name: "falls back to scenario", scenarParallelism: 3, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model.Scenar.Parallelism = tt.scenarParallelism
+			got := shellParallelism(model.Event{Parallelism: tt.eventParallelism})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// installFakeDocker puts a "docker" executable on PATH that drops the "exec
+// <container>" prefix ExecShellCommand always sends and runs whatever
+// command follows locally, so ExecShellCommand can be tested end-to-end
+// without a real containerlab deployment.
+func installFakeDocker(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	script := "#!/bin/sh\nshift\nshift\nexec \"$@\"\n"
+	path := filepath.Join(dir, "docker")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestExecShellCommand_RunsEveryHostAndCommand(t *testing.T) {
+	installFakeDocker(t)
+
+	orig := model.Scenar
+	defer func() { model.Scenar = orig }()
+	model.Scenar = model.Scenario{
+		Event: []model.Event{
+			{
+				Type:          model.EventTypeShell,
+				Runner:        model.RunnerShell,
+				Hosts:         []string{"r1", "r2"},
+				ShellCommands: []string{"echo one", "echo two"},
+			},
+		},
+	}
+
+	lab := model.NewLabContext("testlab")
+	results, err := ExecShellCommand(context.Background(), 0, lab)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Host != results[j].Host {
+			return results[i].Host < results[j].Host
+		}
+		return results[i].Command < results[j].Command
+	})
+	assert.Equal(t, "r1", results[0].Host)
+	assert.Equal(t, "echo one\n", results[0].Stdout)
+	assert.Equal(t, 0, results[0].ExitCode)
+}
+
+func TestExecShellCommand_FailFastCancelsRemaining(t *testing.T) {
+	installFakeDocker(t)
+
+	orig := model.Scenar
+	defer func() { model.Scenar = orig }()
+	model.Scenar = model.Scenario{
+		Event: []model.Event{
+			{
+				Type:          model.EventTypeShell,
+				Runner:        model.RunnerShell,
+				Host:          "r1",
+				FailFast:      true,
+				ShellCommands: []string{"exit 1", "echo should-not-run"},
+			},
+		},
+	}
+
+	lab := model.NewLabContext("testlab")
+	results, err := ExecShellCommand(context.Background(), 0, lab)
+	require.Error(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 1, results[0].ExitCode)
+}
+
+// fakeDockerExecAPI is a minimal in-memory stand-in for dockerExecAPI.
+type fakeDockerExecAPI struct {
+	stdout, stderr string
+	exitCode       int
+	err            error
+
+	calls []string // containers ExecInContainer was called against
+
+	// failFirst, if set, makes the first failFirst calls return exitCode 1
+	// and err regardless of the success fields above, so callers can exercise
+	// retry behavior without a real flaky command.
+	failFirst int
+}
+
+func (f *fakeDockerExecAPI) ExecInContainer(ctx context.Context, container string, cmd []string, opts runtime.ExecOptions) (string, string, int, error) {
+	f.calls = append(f.calls, container)
+	if len(f.calls) <= f.failFirst {
+		return "", "not ready yet", 1, errors.New("exit code 1")
+	}
+	return f.stdout, f.stderr, f.exitCode, f.err
+}
+
+func TestDockerExecRunner_Run(t *testing.T) {
+	fake := &fakeDockerExecAPI{stdout: "hi\n", exitCode: 0}
+	runner := &DockerExecRunner{Client: fake}
+
+	res := runner.Run(context.Background(), "r1", "clab-testlab-r1", "/bin/sh", "echo hi")
+
+	assert.Equal(t, []string{"clab-testlab-r1"}, fake.calls)
+	assert.Equal(t, "r1", res.Host)
+	assert.Equal(t, "echo hi", res.Command)
+	assert.Equal(t, "hi\n", res.Stdout)
+	assert.Equal(t, 0, res.ExitCode)
+	assert.NoError(t, res.Err)
+}
+
+func TestDockerExecRunner_Run_PropagatesError(t *testing.T) {
+	fake := &fakeDockerExecAPI{exitCode: 1, err: errors.New("exit code 1")}
+	runner := &DockerExecRunner{Client: fake}
+
+	res := runner.Run(context.Background(), "r1", "clab-testlab-r1", "/bin/sh", "exit 1")
+
+	assert.Equal(t, 1, res.ExitCode)
+	assert.Error(t, res.Err)
+}
+
+func TestRunCommandWithRetry_SucceedsAfterRetries(t *testing.T) {
+	fake := &fakeDockerExecAPI{failFirst: 2, stdout: "ready\n", exitCode: 0}
+	runner := &DockerExecRunner{Client: fake}
+
+	res, attempts := runCommandWithRetry(context.Background(), runner, 0, "r1", "clab-testlab-r1", "/bin/sh", "check", 2, 0, time.Millisecond)
+
+	assert.Equal(t, 3, attempts)
+	assert.NoError(t, res.Err)
+	assert.Equal(t, "ready\n", res.Stdout)
+}
+
+func TestRunCommandWithRetry_ExhaustsRetries(t *testing.T) {
+	fake := &fakeDockerExecAPI{failFirst: 100, exitCode: 1}
+	runner := &DockerExecRunner{Client: fake}
+
+	res, attempts := runCommandWithRetry(context.Background(), runner, 0, "r1", "clab-testlab-r1", "/bin/sh", "check", 2, 0, time.Millisecond)
+
+	assert.Equal(t, 3, attempts)
+	assert.Error(t, res.Err)
+	assert.Len(t, fake.calls, 3)
+}
+
+func TestExecShellCommand_RetriesAndWrapsFinalError(t *testing.T) {
+	installFakeDocker(t)
+
+	orig := model.Scenar
+	defer func() { model.Scenar = orig }()
+	model.Scenar = model.Scenario{
+		Event: []model.Event{
+			{
+				Type:          model.EventTypeShell,
+				Runner:        model.RunnerShell,
+				Host:          "r1",
+				Retries:       2,
+				RetryBackoff:  "1ms",
+				ShellCommands: []string{"exit 1"},
+			},
+		},
+	}
+
+	lab := model.NewLabContext("testlab")
+	results, err := ExecShellCommand(context.Background(), 0, lab)
+	require.Error(t, err)
+	require.Len(t, results, 1)
+
+	var cmdErr *ShellCommandError
+	require.ErrorAs(t, results[0].Err, &cmdErr)
+	assert.Equal(t, "r1", cmdErr.Host)
+	assert.Equal(t, "exit 1", cmdErr.Command)
+	assert.Equal(t, 3, cmdErr.Attempts)
+}
+
+func TestExecShellCommand_TimeoutCancelsSlowCommand(t *testing.T) {
+	installFakeDocker(t)
+
+	orig := model.Scenar
+	defer func() { model.Scenar = orig }()
+	model.Scenar = model.Scenario{
+		Event: []model.Event{
+			{
+				Type:          model.EventTypeShell,
+				Runner:        model.RunnerShell,
+				Host:          "r1",
+				Timeout:       "10ms",
+				ShellCommands: []string{"sleep 5"},
+			},
+		},
+	}
+
+	lab := model.NewLabContext("testlab")
+	results, err := ExecShellCommand(context.Background(), 0, lab)
+	require.Error(t, err)
+	require.Len(t, results, 1)
+	assert.Error(t, results[0].Err)
+}
@@ -0,0 +1,108 @@
+package events
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/3atlab/netroub/pkg/model"
+)
+
+// templatedSrcSuffix marks a ToContainer FileCopy.Src as a text/template
+// source to render per host, rather than a literal file to copy as-is.
+const templatedSrcSuffix = ".tmpl"
+
+// copyTemplateContext is the data available to a templated FileCopy.Src:
+// the target host's name and its position in the topology graph already
+// parsed into model.Data, plus the event's user-supplied vars.
+type copyTemplateContext struct {
+	Host        string
+	Node        model.Nodes
+	Connections []model.Connections
+	Vars        map[string]any
+}
+
+// isTemplatedSrc reports whether src should be rendered via text/template
+// before being copied.
+func isTemplatedSrc(src string) bool {
+	return strings.HasSuffix(src, templatedSrcSuffix)
+}
+
+// IsTemplatedCopySrc is isTemplatedSrc, exported for callers outside this
+// package (e.g. executor.ValidateScenarioFile) that need to tell a literal
+// ToContainer FileCopy.Src from a template one without importing the rest
+// of this package's copy machinery.
+func IsTemplatedCopySrc(src string) bool {
+	return isTemplatedSrc(src)
+}
+
+// renderedName strips src's templatedSrcSuffix, so a templated source named
+// "frr.conf.tmpl" renders to a file named "frr.conf".
+func renderedName(src string) string {
+	return strings.TrimSuffix(filepath.Base(src), templatedSrcSuffix)
+}
+
+// renderCopyTemplate renders the template at fc.Src against host's
+// model.Nodes/Connections entry and event's Vars/PerHostVars (which
+// override Vars for the same key), writing the result to a temp file. The
+// caller must invoke cleanup once done with the rendered file.
+func (e *EventExecutor) renderCopyTemplate(host string, fc model.FileCopy, event model.Event) (path string, cleanup func(), err error) {
+	tmplContent, err := os.ReadFile(fc.Src)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading template %s: %w", fc.Src, err)
+	}
+	tmpl, err := template.New(filepath.Base(fc.Src)).Parse(string(tmplContent))
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing template %s: %w", fc.Src, err)
+	}
+
+	vars := make(map[string]any, len(event.Vars))
+	for k, v := range event.Vars {
+		vars[k] = v
+	}
+	for k, v := range event.PerHostVars[host] {
+		vars[k] = v
+	}
+
+	node, _ := e.findNode(host)
+	data := copyTemplateContext{
+		Host:        host,
+		Node:        node,
+		Connections: e.Devices.Connections,
+		Vars:        vars,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", nil, fmt.Errorf("rendering template %s for %s: %w", fc.Src, host, err)
+	}
+
+	tmp, err := os.CreateTemp("", "netroub-tmpl-*-"+renderedName(fc.Src))
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", nil, err
+	}
+	tmp.Close()
+
+	return tmp.Name(), func() { os.Remove(tmp.Name()) }, nil
+}
+
+// findNode returns host's model.Nodes entry from e.Devices, if present.
+func (e *EventExecutor) findNode(host string) (model.Nodes, bool) {
+	if e.Devices == nil {
+		return model.Nodes{}, false
+	}
+	for _, n := range e.Devices.Nodes {
+		if n.Name == host {
+			return n, true
+		}
+	}
+	return model.Nodes{}, false
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventExecutor_Execute_Copy_TemplatedSrc(t *testing.T) {
+	dir := t.TempDir()
+	tmplPath := filepath.Join(dir, "frr.conf.tmpl")
+	tmplBody := "router bgp {{.Node.Params.As}}\nhostname {{.Host}}\nneighbor {{.Vars.neighbor}}\n"
+	require.NoError(t, os.WriteFile(tmplPath, []byte(tmplBody), 0644))
+
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type: model.EventTypeCopy,
+				Host: "r1",
+				ToContainer: []model.FileCopy{
+					{Src: tmplPath, Dst: "/etc/frr/"},
+				},
+				Vars: map[string]any{"neighbor": "10.0.0.2"},
+				PerHostVars: map[string]map[string]any{
+					"r1": {"neighbor": "10.0.0.9"},
+				},
+			},
+		},
+	}
+	devices := &model.Data{
+		Nodes: []model.Nodes{
+			{Name: "r1", Params: model.Params{As: "65001"}},
+		},
+	}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+	require.NoError(t, err)
+
+	require.Len(t, docker.copyToCalls, 1)
+	call := docker.copyToCalls[0]
+	assert.Equal(t, "/etc/frr/frr.conf", call.dst)
+
+	rendered, err := os.ReadFile(call.src)
+	require.NoError(t, err)
+	assert.Equal(t, "router bgp 65001\nhostname r1\nneighbor 10.0.0.9\n", string(rendered))
+
+	// renderCopyTemplate's temp file is removed once the copy completes.
+	_, statErr := os.Stat(call.src)
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+func TestEventExecutor_Execute_Copy_NonTemplatedSrcUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "config.conf")
+	require.NoError(t, os.WriteFile(srcPath, []byte("static content"), 0644))
+
+	runner := &mockRunner{}
+	docker := &fakeContainerRuntime{}
+	scenario := &model.Scenario{
+		Event: []model.Event{
+			{
+				Type: model.EventTypeCopy,
+				Host: "r1",
+				ToContainer: []model.FileCopy{
+					{Src: srcPath, Dst: "/etc/frr/config.conf"},
+				},
+			},
+		},
+	}
+	devices := &model.Data{}
+
+	executor := NewEventExecutor(scenario, devices, "test-lab", runner, docker)
+	err := executor.Execute(context.Background(), 0)
+	require.NoError(t, err)
+
+	require.Len(t, docker.copyToCalls, 1)
+	assert.Equal(t, srcPath, docker.copyToCalls[0].src)
+}
@@ -0,0 +1,158 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/3atlab/netroub/pkg/runtime"
+)
+
+// interactiveStarter is the subset of runtime.SDKDockerClient StartInteractive
+// depends on. It mirrors pidResolver in netem_netlink.go: kept as its own
+// narrow, unexported interface here rather than added to
+// runtime.ContainerRuntime, since Podman has no equivalent today and
+// shouldn't be forced to implement it.
+type interactiveStarter interface {
+	StartInteractive(ctx context.Context, container string, cmd []string) (io.ReadWriteCloser, error)
+}
+
+// VtyshSession is a persistent "vtysh" process inside one container, kept
+// running across events instead of forking a fresh vtysh per vtysh event
+// (see execVtyshChanges). Commands are submitted newline-delimited and the
+// session's combined output is read back up to a per-call sentinel echo,
+// since vtysh's own prompt isn't reliably pattern-matchable (its format
+// depends on context: normal mode, configure mode, sub-interface mode, ...).
+type VtyshSession struct {
+	conn io.ReadWriteCloser
+	r    *bufio.Reader
+
+	// mu serializes Run calls against this session, so two events targeting
+	// the same container can't interleave their commands and outputs.
+	mu sync.Mutex
+}
+
+func newVtyshSession(ctx context.Context, starter interactiveStarter, container string) (*VtyshSession, error) {
+	conn, err := starter.StartInteractive(ctx, container, []string{"vtysh"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start vtysh session in %s: %w", container, err)
+	}
+	return &VtyshSession{conn: conn, r: bufio.NewReader(conn)}, nil
+}
+
+// Run submits cmds to the session's vtysh one at a time (each as if typed at
+// the prompt), then reads output up to a unique sentinel line it appends
+// after the batch, and returns everything read before the sentinel.
+func (s *VtyshSession) Run(cmds []string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sentinel := "__netroub_vtysh_" + sentinelSuffix() + "__"
+
+	var out strings.Builder
+	for _, cmd := range cmds {
+		if _, err := io.WriteString(s.conn, cmd+"\n"); err != nil {
+			return "", fmt.Errorf("failed to write vtysh command %q: %w", cmd, err)
+		}
+	}
+	if _, err := io.WriteString(s.conn, "echo "+sentinel+"\n"); err != nil {
+		return "", fmt.Errorf("failed to write vtysh sentinel: %w", err)
+	}
+
+	for {
+		line, err := s.r.ReadString('\n')
+		if strings.Contains(line, sentinel) {
+			return out.String(), nil
+		}
+		out.WriteString(line)
+		if err != nil {
+			return out.String(), fmt.Errorf("vtysh session closed before sentinel: %w", err)
+		}
+	}
+}
+
+// Close terminates the underlying vtysh process and its connection.
+func (s *VtyshSession) Close() error {
+	return s.conn.Close()
+}
+
+// sentinelSuffix returns a 4-byte random hex string, used to make each Run
+// call's sentinel line unpredictable enough that it can't collide with
+// vtysh's own output. crypto/rand.Read never returns an error on the
+// platforms netroub targets (see logstore.randomSuffix).
+func sentinelSuffix() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// VtyshSessionPool keeps one VtyshSession per container, created lazily on
+// first use, so a scenario's repeated vtysh events reuse the same
+// long-lived vtysh process instead of forking a new one per event.
+type VtyshSessionPool struct {
+	starter interactiveStarter
+
+	// mu guards sessions only; a session's own mu serializes Run calls
+	// against that one container, so concurrent events on different
+	// containers don't block each other here.
+	mu       sync.Mutex
+	sessions map[string]*VtyshSession
+}
+
+// NewVtyshSessionPool returns a pool that starts vtysh sessions via starter.
+func NewVtyshSessionPool(starter interactiveStarter) *VtyshSessionPool {
+	return &VtyshSessionPool{starter: starter, sessions: make(map[string]*VtyshSession)}
+}
+
+// NewVtyshSessionPoolFor returns a VtyshSessionPool backed by containerRuntime,
+// or ok=false if containerRuntime doesn't support interactive exec sessions
+// (e.g. Podman today), in which case the caller should leave
+// EventExecutor.VtyshSessions unset and let execVtyshChanges use its
+// one-shot exec fallback instead.
+func NewVtyshSessionPoolFor(containerRuntime runtime.ContainerRuntime) (pool *VtyshSessionPool, ok bool) {
+	starter, ok := containerRuntime.(interactiveStarter)
+	if !ok {
+		return nil, false
+	}
+	return NewVtyshSessionPool(starter), true
+}
+
+// Run submits cmds to container's vtysh session, starting one first if this
+// is container's first vtysh event.
+func (p *VtyshSessionPool) Run(ctx context.Context, container string, cmds []string) (string, error) {
+	p.mu.Lock()
+	session, ok := p.sessions[container]
+	if !ok {
+		var err error
+		session, err = newVtyshSession(ctx, p.starter, container)
+		if err != nil {
+			p.mu.Unlock()
+			return "", err
+		}
+		p.sessions[container] = session
+	}
+	p.mu.Unlock()
+
+	return session.Run(cmds)
+}
+
+// Close tears down every session the pool has started, returning the first
+// error encountered (after attempting to close the rest).
+func (p *VtyshSessionPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var firstErr error
+	for container, session := range p.sessions {
+		if err := session.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to close vtysh session for %s: %w", container, err)
+		}
+	}
+	p.sessions = make(map[string]*VtyshSession)
+	return firstErr
+}
@@ -0,0 +1,159 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVtyshStarter hands out one fake vtysh connection per container, recording
+// every command written to it so the test can assert ordering afterwards.
+type fakeVtyshStarter struct {
+	mu    sync.Mutex
+	conns map[string]*vtyshLoop
+}
+
+// vtyshLoop pairs a fakeVtyshConn with the in-process goroutine that plays
+// the part of vtysh: reading lines written by VtyshSession.Run and echoing
+// the sentinel back so Run's read loop terminates.
+type vtyshLoop struct {
+	toVtysh   *io.PipeWriter
+	fromVtysh *io.PipeReader
+	mu        sync.Mutex
+	commands  []string
+}
+
+func (s *fakeVtyshStarter) StartInteractive(ctx context.Context, container string, cmd []string) (io.ReadWriteCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conns == nil {
+		s.conns = make(map[string]*vtyshLoop)
+	}
+
+	toVtyshR, toVtyshW := io.Pipe()
+	fromVtyshR, fromVtyshW := io.Pipe()
+	loop := &vtyshLoop{toVtysh: toVtyshW, fromVtysh: fromVtyshR}
+	s.conns[container] = loop
+
+	go func() {
+		scanner := bufio.NewScanner(toVtyshR)
+		for scanner.Scan() {
+			line := scanner.Text()
+			loop.mu.Lock()
+			loop.commands = append(loop.commands, line)
+			loop.mu.Unlock()
+			if strings.HasPrefix(line, "echo ") {
+				fmt.Fprintln(fromVtyshW, strings.TrimPrefix(line, "echo "))
+			}
+		}
+	}()
+
+	return &fakeConn{r: fromVtyshR, w: toVtyshW}, nil
+}
+
+// fakeConn adapts a pipe pair into the io.ReadWriteCloser StartInteractive
+// returns, matching runtime.execConn's shape.
+type fakeConn struct {
+	r *io.PipeReader
+	w *io.PipeWriter
+}
+
+func (c *fakeConn) Read(p []byte) (int, error)  { return c.r.Read(p) }
+func (c *fakeConn) Write(p []byte) (int, error) { return c.w.Write(p) }
+func (c *fakeConn) Close() error {
+	c.w.Close()
+	return c.r.Close()
+}
+
+func (s *fakeVtyshStarter) commandsFor(container string) []string {
+	s.mu.Lock()
+	loop := s.conns[container]
+	s.mu.Unlock()
+	if loop == nil {
+		return nil
+	}
+	loop.mu.Lock()
+	defer loop.mu.Unlock()
+	return append([]string(nil), loop.commands...)
+}
+
+// TestVtyshSessionPool_ConcurrentRunsDoNotInterleave fires many concurrent
+// Run calls against the same container and asserts every command batch's
+// 3 commands land together and in order, the way TestLoadScenarioAndDevices_
+// Parallel proves no cross-goroutine interleaving for scenario loading.
+func TestVtyshSessionPool_ConcurrentRunsDoNotInterleave(t *testing.T) {
+	starter := &fakeVtyshStarter{}
+	pool := NewVtyshSessionPool(starter)
+	defer pool.Close()
+
+	const goroutines = 20
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			cmds := []string{
+				fmt.Sprintf("configure terminal %d", i),
+				fmt.Sprintf("set hostname-%d", i),
+				"end",
+			}
+			_, err := pool.Run(context.Background(), "clab-lab-router1", cmds)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	commands := starter.commandsFor("clab-lab-router1")
+	// Every batch is 3 commands + 1 sentinel echo; confirm none of the
+	// "end" markers is immediately followed by another goroutine's
+	// "configure terminal" before its own "set hostname" ran, i.e. batches
+	// never interleave.
+	for i := 0; i+2 < len(commands); i++ {
+		if strings.HasPrefix(commands[i], "configure terminal") {
+			require.True(t, strings.HasPrefix(commands[i+1], "set hostname"), "batch interleaved at %d: %v", i, commands[i:i+3])
+			require.Equal(t, "end", commands[i+2])
+		}
+	}
+}
+
+// TestVtyshSessionPool_ReusesSessionPerContainer confirms a second Run
+// against a container already holding a session reuses it instead of
+// starting a fresh vtysh process.
+func TestVtyshSessionPool_ReusesSessionPerContainer(t *testing.T) {
+	starter := &fakeVtyshStarter{}
+	pool := NewVtyshSessionPool(starter)
+	defer pool.Close()
+
+	_, err := pool.Run(context.Background(), "clab-lab-router1", []string{"show version"})
+	require.NoError(t, err)
+	_, err = pool.Run(context.Background(), "clab-lab-router1", []string{"show version"})
+	require.NoError(t, err)
+
+	starter.mu.Lock()
+	n := len(starter.conns)
+	starter.mu.Unlock()
+	assert.Equal(t, 1, n)
+}
+
+func TestVtyshSessionPool_DifferentContainersGetDifferentSessions(t *testing.T) {
+	starter := &fakeVtyshStarter{}
+	pool := NewVtyshSessionPool(starter)
+	defer pool.Close()
+
+	_, err := pool.Run(context.Background(), "clab-lab-router1", []string{"show version"})
+	require.NoError(t, err)
+	_, err = pool.Run(context.Background(), "clab-lab-router2", []string{"show version"})
+	require.NoError(t, err)
+
+	starter.mu.Lock()
+	n := len(starter.conns)
+	starter.mu.Unlock()
+	assert.Equal(t, 2, n)
+}
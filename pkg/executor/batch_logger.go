@@ -2,57 +2,230 @@
 package executor
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sync"
 	"time"
+
+	"github.com/3atlab/netroub/pkg/executor/livelog"
 )
 
 // BatchLogFileName is the default log file name for batch execution.
 const BatchLogFileName = "netroub.log"
 
-// BatchLogger handles logging for batch/repeat execution.
-// It writes to a log file and optionally to stdout.
-type BatchLogger struct {
+// Format selects which Formatter a BatchLogger renders its Records with. It
+// is a defined string type rather than iota-int so an untyped string
+// literal like NewBatchLoggerWithFormat(path, "json") converts to it
+// without callers needing to spell out the constant name.
+type Format string
+
+const (
+	// TextFormat writes human-readable "timestamp [LEVEL] message" lines.
+	TextFormat Format = "text"
+	// JSONFormat writes one JSON object per line with stable fields (ts,
+	// level, msg, seq, plus whatever fields the logging call or With
+	// attached), suitable for aggregating logs across hundreds of parallel
+	// runs with jq/Loki/Elastic instead of a regex over text lines.
+	JSONFormat Format = "json"
+)
+
+// Record is a single structured log entry, handed to a Formatter to render.
+type Record struct {
+	Time    time.Time
+	Level   string
+	Message string
+	Fields  map[string]any
+}
+
+// Formatter renders a Record as a single log line, without a trailing
+// newline; logEntry appends that itself.
+type Formatter interface {
+	Format(rec Record) []byte
+}
+
+// textFormatter renders "timestamp [LEVEL] message", ignoring Fields, which
+// is BatchLogger's original (and default) output.
+type textFormatter struct{}
+
+func (textFormatter) Format(rec Record) []byte {
+	return []byte(fmt.Sprintf("%s [%s] %s", rec.Time.Format("2006-01-02 15:04:05"), rec.Level, rec.Message))
+}
+
+// jsonFormatter renders one JSON object per line, merging Fields alongside
+// the stable ts/level/msg keys.
+type jsonFormatter struct{}
+
+func (jsonFormatter) Format(rec Record) []byte {
+	out := make(map[string]any, len(rec.Fields)+3)
+	for k, v := range rec.Fields {
+		out[k] = v
+	}
+	out["ts"] = rec.Time.Format(time.RFC3339Nano)
+	out["level"] = rec.Level
+	out["msg"] = rec.Message
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		// One of Fields' values isn't JSON-marshalable; fall back to a
+		// record without them rather than dropping the line entirely.
+		data, _ = json.Marshal(map[string]any{"ts": out["ts"], "level": rec.Level, "msg": rec.Message})
+	}
+	return data
+}
+
+// formatterFor returns the built-in Formatter for format, defaulting to
+// textFormatter for anything but JSONFormat.
+func formatterFor(format Format) Formatter {
+	if format == JSONFormat {
+		return jsonFormatter{}
+	}
+	return textFormatter{}
+}
+
+// batchCore is the machinery a BatchLogger shares with every logger derived
+// from it via With: the log file, sequence counter, and formatter. Deriving
+// a logger only forks its Fields, not this.
+type batchCore struct {
 	file      *os.File
 	mu        sync.Mutex
 	startTime time.Time
+	formatter Formatter
+	seq       uint64
+
+	live *livelog.Registry // optional; set via SetLiveLog to enable RegisterTask/UnregisterTask
 }
 
-// NewBatchLogger creates a new BatchLogger that writes to the specified file.
-// If the file already exists, it will be truncated.
+// BatchLogger handles logging for batch/repeat execution. It writes to a
+// log file and optionally to stdout. Fields carries structured context
+// (e.g. run_id, scenario) that With attaches to every record this logger
+// writes; the root logger returned by NewBatchLogger has none.
+type BatchLogger struct {
+	core   *batchCore
+	fields map[string]any
+}
+
+// NewBatchLogger creates a new BatchLogger that writes text-format lines to
+// the specified file. If the file already exists, it will be truncated.
 func NewBatchLogger(path string) (*BatchLogger, error) {
+	return NewBatchLoggerWithFormat(path, TextFormat)
+}
+
+// NewBatchLoggerWithFormat creates a new BatchLogger that writes to the
+// specified file in the given Format. If the file already exists, it will
+// be truncated.
+func NewBatchLoggerWithFormat(path string, format Format) (*BatchLogger, error) {
 	file, err := os.Create(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create batch log file: %w", err)
 	}
 
 	return &BatchLogger{
-		file:      file,
-		startTime: time.Now(),
+		core: &batchCore{
+			file:      file,
+			startTime: time.Now(),
+			formatter: formatterFor(format),
+		},
 	}, nil
 }
 
 // Close closes the log file.
 func (l *BatchLogger) Close() error {
-	if l.file != nil {
-		return l.file.Close()
+	if l.core.file != nil {
+		return l.core.file.Close()
 	}
 	return nil
 }
 
-// Log writes a log message with timestamp.
-func (l *BatchLogger) Log(level, format string, args ...any) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
+// With returns a child logger that merges kvs (alternating key, value
+// pairs) into every record it logs, in addition to any this logger already
+// carries, e.g. logger.With("run_id", task.RunID, "attempt", n).Info("...").
+// It shares this logger's file/formatter/sequence counter, so interleaved
+// writes from a root logger and its derived children still land in one
+// correctly-ordered stream.
+func (l *BatchLogger) With(kvs ...any) *BatchLogger {
+	if len(kvs)%2 != 0 {
+		panic("executor: BatchLogger.With called with an odd number of arguments")
+	}
+
+	merged := make(map[string]any, len(l.fields)+len(kvs)/2)
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for i := 0; i < len(kvs); i += 2 {
+		key, ok := kvs[i].(string)
+		if !ok {
+			panic(fmt.Sprintf("executor: BatchLogger.With key %v is not a string", kvs[i]))
+		}
+		merged[key] = kvs[i+1]
+	}
+
+	return &BatchLogger{core: l.core, fields: merged}
+}
 
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	message := fmt.Sprintf(format, args...)
-	line := fmt.Sprintf("%s [%s] %s\n", timestamp, level, message)
+// SetLiveLog enables RegisterTask/UnregisterTask by attaching the
+// livelog.Registry a `batch --serve` HTTP server exposes GET
+// /runs/{run_id}/log through. Leaving it unset (the default) makes
+// RegisterTask a no-op, so callers don't need to check for one themselves.
+func (l *BatchLogger) SetLiveLog(live *livelog.Registry) {
+	l.core.live = live
+}
 
-	if l.file != nil {
-		l.file.WriteString(line)
+// RegisterTask starts live-tailing runID's log, returning an io.Writer the
+// caller should tee its task's log output (e.g. control.log) into
+// alongside the file itself, so GET /runs/{run_id}/log can follow along.
+// path is the task's own log file, included only to make the registration
+// log line actionable; RegisterTask does not open or read it itself. If no
+// livelog.Registry was set via SetLiveLog, returns io.Discard.
+func (l *BatchLogger) RegisterTask(runID, path string) io.Writer {
+	if l.core.live == nil {
+		return io.Discard
 	}
+	l.Info("Live log available for %s (%s)", runID, path)
+	return l.core.live.RegisterTask(runID)
+}
+
+// UnregisterTask stops live-tailing runID's log, closing every subscriber's
+// in-flight request. It is a no-op if no livelog.Registry was set via
+// SetLiveLog.
+func (l *BatchLogger) UnregisterTask(runID string) {
+	if l.core.live != nil {
+		l.core.live.UnregisterTask(runID)
+	}
+}
+
+// logEntry renders and writes a single Record, merging l.fields and any
+// extra fields passed in (extra wins on key collision), plus a
+// monotonically increasing seq, so every other logging method is a thin
+// wrapper over this.
+func (l *BatchLogger) logEntry(level, msg string, fields map[string]any) {
+	l.core.mu.Lock()
+	defer l.core.mu.Unlock()
+
+	l.core.seq++
+
+	merged := make(map[string]any, len(l.fields)+len(fields)+1)
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	merged["seq"] = l.core.seq
+
+	line := l.core.formatter.Format(Record{Time: time.Now(), Level: level, Message: msg, Fields: merged})
+	if l.core.file != nil {
+		l.core.file.Write(line)
+		l.core.file.WriteString("\n")
+	}
+}
+
+// Log writes a log message with timestamp.
+func (l *BatchLogger) Log(level, format string, args ...any) {
+	l.logEntry(level, fmt.Sprintf(format, args...), nil)
 }
 
 // Info logs an INFO level message.
@@ -70,6 +243,40 @@ func (l *BatchLogger) Warn(format string, args ...any) {
 	l.Log("WARN", format, args...)
 }
 
+// FieldLogger attaches additional structured context (e.g. event type,
+// host) to every message logged through it. The fields are merged into the
+// record in JSONFormat and ignored in TextFormat.
+//
+// Deprecated: prefer BatchLogger.With, which returns a *BatchLogger so
+// derived loggers can be passed around and further derived from like any
+// other logger instead of needing this separate type.
+type FieldLogger struct {
+	logger *BatchLogger
+	fields map[string]any
+}
+
+// WithFields returns a FieldLogger that attaches fields to every message it
+// logs, for callers like the ProgressTracker or event executors that want
+// to correlate log lines with an event type or host.
+func (l *BatchLogger) WithFields(fields map[string]any) *FieldLogger {
+	return &FieldLogger{logger: l, fields: fields}
+}
+
+// Info logs an INFO level message with the attached fields.
+func (f *FieldLogger) Info(format string, args ...any) {
+	f.logger.logEntry("INFO", fmt.Sprintf(format, args...), f.fields)
+}
+
+// Error logs an ERROR level message with the attached fields.
+func (f *FieldLogger) Error(format string, args ...any) {
+	f.logger.logEntry("ERROR", fmt.Sprintf(format, args...), f.fields)
+}
+
+// Warn logs a WARN level message with the attached fields.
+func (f *FieldLogger) Warn(format string, args ...any) {
+	f.logger.logEntry("WARN", fmt.Sprintf(format, args...), f.fields)
+}
+
 // LogStart logs the start of batch execution.
 func (l *BatchLogger) LogStart(command string, scenarios, totalRuns, parallel int, planFile string) {
 	l.Info("=== Batch Execution Started ===")
@@ -81,26 +288,63 @@ func (l *BatchLogger) LogStart(command string, scenarios, totalRuns, parallel in
 	l.Info("")
 }
 
-// LogTaskCompleted logs the completion of a task.
-func (l *BatchLogger) LogTaskCompleted(task *Task, duration time.Duration, err error, logDir string) {
-	if err != nil {
-		l.Error("[%s] Failed: %v (%.1fs)", task.RunID, err, duration.Seconds())
-		if logDir != "" {
-			l.Error("[%s] Log directory: %s", task.RunID, logDir)
-		}
-	} else {
-		l.Info("[%s] Completed successfully (%.1fs)", task.RunID, duration.Seconds())
+// LogTaskCompleted logs the completion of a task, with run_id, scenario,
+// duration_ms, attempts, err and log_dir populated as structured fields
+// rather than formatted into the message. If err wraps context.Canceled (a
+// ctx canceled out from under the task, e.g. by the CLI's SIGINT handler,
+// rather than the scenario itself failing), the "cancelled" field is set to
+// true so consumers of the JSON log can tell a user-cancel apart from a
+// scenario failure.
+func (l *BatchLogger) LogTaskCompleted(task *Task, duration time.Duration, err error, logDir string, attempts int) {
+	logger := l.With(
+		"run_id", task.RunID,
+		"scenario", task.ScenarioPath,
+		"duration_ms", duration.Milliseconds(),
+		"attempts", attempts,
+	)
+	if logDir != "" {
+		logger = logger.With("log_dir", logDir)
+	}
+
+	if err == nil {
+		logger.Info("[%s] Completed successfully (%.1fs)", task.RunID, duration.Seconds())
+		return
+	}
+
+	logger = logger.With("err", err.Error())
+	if errors.Is(err, context.Canceled) {
+		logger = logger.With("cancelled", true)
+	}
+	logger.Error("[%s] Failed: %v (%.1fs)", task.RunID, err, duration.Seconds())
+	if logDir != "" {
+		logger.Error("[%s] Log directory: %s", task.RunID, logDir)
 	}
 }
 
-// LogSummary logs the execution summary.
+// LogTaskRetrying logs a failed attempt that will be retried, distinguishing
+// this "Retrying" outcome from the task's eventual Succeeded/Failed record
+// from LogTaskCompleted.
+func (l *BatchLogger) LogTaskRetrying(task *Task, attempt, maxAttempts int, err error, backoff time.Duration) {
+	logger := l.With(
+		"run_id", task.RunID,
+		"scenario", task.ScenarioPath,
+		"attempt", attempt,
+		"err", err.Error(),
+	)
+	logger.Warn("[%s] Retrying (attempt %d/%d) after %s: %v", task.RunID, attempt, maxAttempts, backoff.Round(time.Millisecond), err)
+}
+
+// LogSummary logs the execution summary. Retried counts results that took
+// more than one attempt, whether they ultimately succeeded or failed, so a
+// batch where every failure was retried at least once reads differently
+// from one that failed outright.
 func (l *BatchLogger) LogSummary(results []*Result) {
-	total, succeeded, failed, totalDuration := Summary(results)
-	elapsed := time.Since(l.startTime)
+	total, succeeded, failed, retried, totalDuration := Summary(results)
+	elapsed := time.Since(l.core.startTime)
 
 	l.Info("")
 	l.Info("=== Execution Summary ===")
-	l.Info("Total: %d, Succeeded: %d, Failed: %d", total, succeeded, failed)
+	l.Info("Total: %d, Succeeded: %d, Failed: %d, Retried: %d", total, succeeded, failed, retried)
 	l.Info("Total task duration: %s", totalDuration.Round(time.Second))
 	l.Info("Wall clock time: %s", elapsed.Round(time.Second))
 
@@ -109,9 +353,13 @@ func (l *BatchLogger) LogSummary(results []*Result) {
 		l.Info("Failed tasks:")
 		for _, r := range results {
 			if r.Error != nil {
-				l.Error("  - %s: %v", r.Task.RunID, r.Error)
+				logger := l.With("run_id", r.Task.RunID, "err", r.Error.Error())
+				if r.LogDir != "" {
+					logger = logger.With("log_dir", r.LogDir)
+				}
+				logger.Error("  - %s: %v", r.Task.RunID, r.Error)
 				if r.LogDir != "" {
-					l.Error("    Log: %s/control.log", r.LogDir)
+					logger.Error("    Log: %s/control.log", r.LogDir)
 				}
 			}
 		}
@@ -122,8 +370,8 @@ func (l *BatchLogger) LogSummary(results []*Result) {
 
 // GetLogPath returns the log file path.
 func (l *BatchLogger) GetLogPath() string {
-	if l.file != nil {
-		return l.file.Name()
+	if l.core.file != nil {
+		return l.core.file.Name()
 	}
 	return ""
 }
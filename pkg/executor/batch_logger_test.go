@@ -1,6 +1,10 @@
 package executor
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,6 +13,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/3atlab/netroub/pkg/executor/livelog"
 )
 
 func TestNewBatchLogger(t *testing.T) {
@@ -77,11 +83,11 @@ func TestBatchLogger_LogTaskCompleted(t *testing.T) {
 	task := &Task{RunID: "test_001", ScenarioPath: "test.json"}
 
 	// Test successful completion
-	logger.LogTaskCompleted(task, 30*time.Second, nil, "")
+	logger.LogTaskCompleted(task, 30*time.Second, nil, "", 1)
 
 	// Test failed completion
 	task2 := &Task{RunID: "test_002", ScenarioPath: "test.json"}
-	logger.LogTaskCompleted(task2, 45*time.Second, assert.AnError, "/path/to/log")
+	logger.LogTaskCompleted(task2, 45*time.Second, assert.AnError, "/path/to/log", 2)
 
 	logger.Close()
 
@@ -94,6 +100,35 @@ func TestBatchLogger_LogTaskCompleted(t *testing.T) {
 	assert.Contains(t, logStr, "/path/to/log")
 }
 
+func TestBatchLogger_LogTaskCompleted_CancelledField(t *testing.T) {
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "test.log")
+
+	logger, err := NewBatchLoggerWithFormat(logPath, JSONFormat)
+	require.NoError(t, err)
+
+	cancelled := &Task{RunID: "test_001", ScenarioPath: "test.json"}
+	logger.LogTaskCompleted(cancelled, time.Second, fmt.Errorf("aborted: %w", context.Canceled), "", 1)
+
+	failed := &Task{RunID: "test_002", ScenarioPath: "test.json"}
+	logger.LogTaskCompleted(failed, time.Second, assert.AnError, "", 1)
+
+	logger.Close()
+
+	content, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+
+	var cancelledRec, failedRec map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &cancelledRec))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &failedRec))
+
+	assert.Equal(t, true, cancelledRec["cancelled"])
+	assert.Nil(t, failedRec["cancelled"])
+}
+
 func TestBatchLogger_LogSummary(t *testing.T) {
 	tmpDir := t.TempDir()
 	logPath := filepath.Join(tmpDir, "test.log")
@@ -104,7 +139,7 @@ func TestBatchLogger_LogSummary(t *testing.T) {
 	results := []*Result{
 		{Task: &Task{RunID: "test_001"}, Duration: 30 * time.Second, Error: nil},
 		{Task: &Task{RunID: "test_002"}, Duration: 45 * time.Second, Error: nil},
-		{Task: &Task{RunID: "test_003"}, Duration: 20 * time.Second, Error: assert.AnError, LogDir: "/path/to/log"},
+		{Task: &Task{RunID: "test_003"}, Duration: 20 * time.Second, Error: assert.AnError, LogDir: "/path/to/log", Attempts: 2},
 	}
 
 	logger.LogSummary(results)
@@ -118,6 +153,7 @@ func TestBatchLogger_LogSummary(t *testing.T) {
 	assert.Contains(t, logStr, "Total: 3")
 	assert.Contains(t, logStr, "Succeeded: 2")
 	assert.Contains(t, logStr, "Failed: 1")
+	assert.Contains(t, logStr, "Retried: 1")
 	assert.Contains(t, logStr, "Failed tasks:")
 	assert.Contains(t, logStr, "test_003")
 }
@@ -142,3 +178,109 @@ func TestBatchLogger_Timestamp(t *testing.T) {
 	// First line should start with a timestamp
 	assert.Regexp(t, `^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`, lines[0])
 }
+
+func TestBatchLogger_JSONFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "test.log")
+
+	logger, err := NewBatchLoggerWithFormat(logPath, JSONFormat)
+	require.NoError(t, err)
+
+	task := &Task{RunID: "test_001", ScenarioPath: "test.json"}
+	logger.LogTaskCompleted(task, 30*time.Second, nil, "/path/to/log", 1)
+	logger.Close()
+
+	content, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 1)
+
+	var rec map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &rec))
+
+	assert.Equal(t, "test_001", rec["run_id"])
+	assert.Equal(t, "test.json", rec["scenario"])
+	assert.Equal(t, float64(30000), rec["duration_ms"])
+	assert.Equal(t, float64(1), rec["attempts"])
+	assert.Equal(t, "/path/to/log", rec["log_dir"])
+	assert.Equal(t, "INFO", rec["level"])
+	assert.Equal(t, float64(1), rec["seq"])
+	assert.NotEmpty(t, rec["ts"])
+	assert.Nil(t, rec["err"])
+}
+
+func TestBatchLogger_JSONFormat_SeqIncrements(t *testing.T) {
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "test.log")
+
+	logger, err := NewBatchLoggerWithFormat(logPath, JSONFormat)
+	require.NoError(t, err)
+
+	logger.Info("first")
+	logger.Info("second")
+	logger.Close()
+
+	content, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+
+	var first, second map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+
+	assert.Equal(t, float64(1), first["seq"])
+	assert.Equal(t, float64(2), second["seq"])
+}
+
+func TestBatchLogger_RegisterTask_NoLiveLogReturnsDiscard(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger, err := NewBatchLogger(filepath.Join(tmpDir, "test.log"))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	assert.Equal(t, io.Discard, logger.RegisterTask("run-1", "/path/to/control.log"))
+	logger.UnregisterTask("run-1") // no-op without a livelog.Registry; must not panic
+}
+
+func TestBatchLogger_RegisterTask_WithLiveLog(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger, err := NewBatchLogger(filepath.Join(tmpDir, "test.log"))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	reg := livelog.NewRegistry()
+	logger.SetLiveLog(reg)
+
+	w := logger.RegisterTask("run-1", "/path/to/control.log")
+	n, err := w.Write([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, len("hello"), n)
+
+	logger.UnregisterTask("run-1")
+}
+
+func TestBatchLogger_WithFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "test.log")
+
+	logger, err := NewBatchLoggerWithFormat(logPath, JSONFormat)
+	require.NoError(t, err)
+
+	fl := logger.WithFields(map[string]any{"event_type": "pumba", "host": "r1"})
+	fl.Warn("netem applied")
+	logger.Close()
+
+	content, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+
+	var rec map[string]any
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(string(content))), &rec))
+
+	assert.Equal(t, "pumba", rec["event_type"])
+	assert.Equal(t, "r1", rec["host"])
+	assert.Equal(t, "WARN", rec["level"])
+	assert.Equal(t, "netem applied", rec["msg"])
+}
@@ -0,0 +1,312 @@
+package executor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	goruntime "runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/3atlab/netroub/pkg/events"
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/network"
+	"github.com/3atlab/netroub/pkg/runtime"
+)
+
+// BenchOp identifies a topology operation `netroub bench` times.
+type BenchOp string
+
+const (
+	BenchOpDeploy  BenchOp = "deploy"
+	BenchOpDestroy BenchOp = "destroy"
+	BenchOpEvent   BenchOp = "event"
+)
+
+// BenchConfig configures a `netroub bench` run: which scenario to deploy
+// against, which ops to time, and how many iterations/warmup runs each op
+// gets. Each iteration deploys and destroys its own lab (named
+// "bench-<n>"), so iterations never collide with each other or with a
+// concurrently-running scenario.
+type BenchConfig struct {
+	ScenarioPath   string
+	YAML           bool
+	Ops            []BenchOp
+	Iterations     int
+	Warmup         int
+	Runtime        RuntimeKind
+	DockerEndpoint runtime.DockerEndpoint
+}
+
+// BenchResult is one BenchOp's timing/memory samples over a BenchConfig
+// run. String formats it in Go testing.B's "BenchmarkX-N  iters  ns/op"
+// shape so benchstat can compare netroub bench runs the same way it
+// compares `go test -bench` output.
+type BenchResult struct {
+	Op         BenchOp
+	GOMAXPROCS int
+	Iterations int
+	NsPerOp    int64
+	RSSPerOp   int64 // peak RSS (bytes) of "containerlab" child processes observed during the op, averaged over Iterations
+	Containers int   // container count observed after the last deploy of this op
+}
+
+// String renders r in Go benchmark format, e.g.
+// "BenchmarkDeploy-8   20   152340123 ns/op   41943040 B/op(rss)   4 containers".
+func (r BenchResult) String() string {
+	name := strings.ToUpper(string(r.Op[:1])) + string(r.Op[1:])
+	return fmt.Sprintf("Benchmark%s-%d\t%d\t%d ns/op\t%d B/op(rss)\t%d containers",
+		name, r.GOMAXPROCS, r.Iterations, r.NsPerOp, r.RSSPerOp, r.Containers)
+}
+
+// RunBench loads cfg.ScenarioPath once, then times each of cfg.Ops over
+// cfg.Warmup discarded iterations followed by cfg.Iterations measured ones,
+// deploying/destroying a fresh "bench-<n>" lab per iteration so consecutive
+// iterations don't collide on the same containers. ctx cancellation aborts
+// the in-flight Deploy/Destroy/event call the same way it would during a
+// normal scenario run.
+func RunBench(ctx context.Context, cfg BenchConfig) ([]BenchResult, error) {
+	scenario, devices, err := loadBenchScenario(cfg.ScenarioPath, cfg.YAML)
+	if err != nil {
+		return nil, fmt.Errorf("bench: failed to load scenario: %w", err)
+	}
+
+	rt := cfg.Runtime
+	if rt == "" {
+		rt = DefaultRuntimeKind
+	}
+
+	results := make([]BenchResult, 0, len(cfg.Ops))
+	for _, op := range cfg.Ops {
+		result, err := benchOp(ctx, op, scenario, devices, cfg, rt)
+		if err != nil {
+			return results, fmt.Errorf("bench: op %s: %w", op, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// benchOp times a single BenchOp over cfg.Warmup+cfg.Iterations fresh labs.
+// Every iteration deploys a lab, runs op against it (timed, for
+// BenchOpDeploy the deploy itself; otherwise an untimed setup deploy), and
+// tears the lab back down before the next iteration starts.
+func benchOp(ctx context.Context, op BenchOp, scenario *model.Scenario, devices *model.Data, cfg BenchConfig, rt RuntimeKind) (BenchResult, error) {
+	cmdRunner := runtime.NewExecRunner()
+
+	listRuntime, err := NewRuntime(rt)
+	if err != nil {
+		return BenchResult{}, err
+	}
+
+	var totalDur time.Duration
+	var totalRSS int64
+	var lastContainers int
+	runs := cfg.Warmup + cfg.Iterations
+
+	for i := 0; i < runs; i++ {
+		labName := fmt.Sprintf("bench-%d", i)
+		containerRuntime, err := runtime.NewContainerRuntimeWithEndpoint(runtime.Engine(scenario.ContainerEngine), cfg.DockerEndpoint)
+		if err != nil {
+			return BenchResult{}, err
+		}
+		networkController := network.NewNetworkController(scenario, devices, labName, cmdRunner, containerRuntime)
+
+		var dur time.Duration
+		var rss int64
+		var containers int
+		switch op {
+		case BenchOpDeploy:
+			stop := sampleRSS(&rss)
+			start := time.Now()
+			err = networkController.Deploy(ctx)
+			dur = time.Since(start)
+			stop()
+			if err == nil {
+				containers = countContainers(ctx, listRuntime, labName)
+				err = networkController.Destroy(ctx)
+			}
+		case BenchOpDestroy:
+			if err = networkController.Deploy(ctx); err == nil {
+				containers = countContainers(ctx, listRuntime, labName)
+				stop := sampleRSS(&rss)
+				start := time.Now()
+				err = networkController.Destroy(ctx)
+				dur = time.Since(start)
+				stop()
+			}
+		case BenchOpEvent:
+			if err = networkController.Deploy(ctx); err == nil {
+				containers = countContainers(ctx, listRuntime, labName)
+				// Time the dummy event's dispatch overhead (Execute's
+				// switch plus its telemetry publish), not the scenario's
+				// actual duration: the dummy sleeps for Duration, so a
+				// throwaway zero-duration scenario is used here instead of
+				// the real one.
+				event := model.Event{BeginTime: "0s", Type: model.EventTypeDummy}
+				eventExecutor := events.NewEventExecutor(&model.Scenario{Duration: "0s", Event: []model.Event{event}}, devices, labName, cmdRunner, containerRuntime)
+				start := time.Now()
+				err = eventExecutor.Execute(ctx, 0)
+				dur = time.Since(start)
+				if destroyErr := networkController.Destroy(ctx); err == nil {
+					err = destroyErr
+				}
+			}
+		default:
+			return BenchResult{}, fmt.Errorf("unknown bench op %q", op)
+		}
+		if err != nil {
+			return BenchResult{}, fmt.Errorf("%s lab %s: %w", op, labName, err)
+		}
+
+		if i >= cfg.Warmup {
+			totalDur += dur
+			totalRSS += rss
+			lastContainers = containers
+		}
+	}
+
+	iterations := cfg.Iterations
+	if iterations < 1 {
+		iterations = 1
+	}
+	return BenchResult{
+		Op:         op,
+		GOMAXPROCS: goruntime.GOMAXPROCS(0),
+		Iterations: iterations,
+		NsPerOp:    totalDur.Nanoseconds() / int64(iterations),
+		RSSPerOp:   totalRSS / int64(iterations),
+		Containers: lastContainers,
+	}, nil
+}
+
+// countContainers returns the number of containers labName's deploy
+// produced, or 0 if they can't be listed.
+func countContainers(ctx context.Context, rt Runtime, labName string) int {
+	containers, err := rt.ListContainers(ctx, labName)
+	if err != nil {
+		return 0
+	}
+	return len(containers)
+}
+
+// loadBenchScenario parses path (JSON unless yaml is set) the same way
+// ScenarioRunner.loadScenarioAndDevices does, but without the --var/run-ID
+// substitution a Task carries, since a bench run has no Task behind it.
+func loadBenchScenario(path string, yaml bool) (*model.Scenario, *model.Data, error) {
+	var scenario *model.Scenario
+	var err error
+	if yaml {
+		scenario, err = model.ParseScenarioYAML(path, nil)
+	} else {
+		scenario, err = model.ParseScenarioJSON(path, nil)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	devices := &model.Data{}
+	if scenario.Data != "" {
+		devices, err = model.ParseDataJSON(scenario.Data)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return scenario, devices, nil
+}
+
+// rssPollInterval is how often sampleRSS rescans /proc while an op is
+// in flight.
+const rssPollInterval = 50 * time.Millisecond
+
+// sampleRSS starts a background poller that rescans /proc for processes
+// named "containerlab" every rssPollInterval, storing the highest total
+// VmRSS observed into *peak (in bytes). Call the returned stop func once
+// the timed operation finishes; it blocks until the poller has exited.
+func sampleRSS(peak *int64) func() {
+	stopCh := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(rssPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				if rss := totalContainerlabRSS(); rss > *peak {
+					*peak = rss
+				}
+			}
+		}
+	}()
+	return func() {
+		close(stopCh)
+		wg.Wait()
+	}
+}
+
+// totalContainerlabRSS sums VmRSS (from /proc/<pid>/status) across every
+// running "containerlab" process, returning bytes. Best-effort: a pid that
+// disappears mid-scan (process exited) is skipped rather than failing the
+// whole sample.
+func totalContainerlabRSS() int64 {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0
+	}
+
+	var total int64
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		comm, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+		if err != nil || !strings.Contains(string(comm), "containerlab") {
+			continue
+		}
+		total += rssOf(pid)
+	}
+	return total
+}
+
+// rssOf reads VmRSS (in kB, converted to bytes) for pid from
+// /proc/<pid>/status, or 0 if it can't be read.
+func rssOf(pid int) int64 {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	return parseVmRSS(f)
+}
+
+// parseVmRSS scans r (a /proc/<pid>/status file's contents) for its VmRSS
+// line, returning the value in bytes, or 0 if the line is missing or
+// malformed.
+func parseVmRSS(r io.Reader) int64 {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "VmRSS:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return 0
+		}
+		kb, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0
+		}
+		return kb * 1024
+	}
+	return 0
+}
@@ -0,0 +1,31 @@
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBenchResult_String(t *testing.T) {
+	r := BenchResult{Op: BenchOpDeploy, GOMAXPROCS: 8, Iterations: 20, NsPerOp: 152340123, RSSPerOp: 41943040, Containers: 4}
+	assert.Equal(t, "BenchmarkDeploy-8\t20\t152340123 ns/op\t41943040 B/op(rss)\t4 containers", r.String())
+}
+
+func TestParseVmRSS(t *testing.T) {
+	status := "Name:\tcontainerlab\nVmRSS:\t   40960 kB\nVmSize:\t 102400 kB\n"
+	assert.Equal(t, int64(40960*1024), parseVmRSS(strings.NewReader(status)))
+}
+
+func TestParseVmRSS_MissingLineReturnsZero(t *testing.T) {
+	assert.Equal(t, int64(0), parseVmRSS(strings.NewReader("Name:\tsh\n")))
+}
+
+func TestTotalContainerlabRSS_NoMatchingProcessesDoesNotPanic(t *testing.T) {
+	// totalContainerlabRSS scans the real /proc; on a CI/sandbox host it's
+	// extremely unlikely a process named exactly "containerlab" is
+	// running, so this just exercises the scan itself.
+	assert.NotPanics(t, func() {
+		totalContainerlabRSS()
+	})
+}
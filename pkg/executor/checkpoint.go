@@ -0,0 +1,238 @@
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// CheckpointStatusSucceeded and CheckpointStatusFailed are the Status values
+// recorded in a CheckpointEntry.
+const (
+	CheckpointStatusSucceeded = "succeeded"
+	CheckpointStatusFailed    = "failed"
+	// CheckpointStatusStarted is recorded by RecordStarted just before a
+	// task runs. It's never treated as "completed" by FilterTasks/
+	// Completed's callers: a RunID whose latest entry is still "started"
+	// (the process crashed mid-task, so no succeeded/failed entry ever
+	// followed it) is indistinguishable from a never-attempted task, and
+	// gets re-run the same way.
+	CheckpointStatusStarted = "started"
+)
+
+// CheckpointEntry records one task's outcome in a checkpoint file.
+type CheckpointEntry struct {
+	RunID    string    `json:"runId"`
+	Status   string    `json:"status"`
+	Error    string    `json:"error,omitempty"`
+	ExitedAt time.Time `json:"exitedAt"`
+}
+
+// ResumeMode selects how FilterTasks uses a Checkpoint's recorded outcomes
+// to narrow down the set of tasks an Executor should run.
+type ResumeMode int
+
+const (
+	// ResumeNone runs every task, ignoring any checkpoint.
+	ResumeNone ResumeMode = iota
+	// ResumeSkipSucceeded (`netroub plan resume --retry-failed`) skips
+	// RunIDs already recorded as succeeded, re-running everything else (failures and never-attempted
+	// tasks alike) so an interrupted plan can pick up where it left off.
+	ResumeSkipSucceeded
+	// ResumeRestartFailed runs only RunIDs recorded as
+	// failed, leaving succeeded and never-attempted tasks alone.
+	ResumeRestartFailed
+	// ResumeSkipCompleted (the default for `netroub plan resume`) skips
+	// RunIDs already recorded as succeeded OR failed, running only tasks
+	// that were never attempted (or that crashed mid-run, leaving only a
+	// CheckpointStatusStarted entry behind). Pass --retry-failed to get
+	// ResumeSkipSucceeded's behavior instead, which also re-runs failures.
+	ResumeSkipCompleted
+)
+
+// Checkpoint records, to a JSONL file on disk, which RunIDs a Plan's tasks
+// have already completed and whether they succeeded, so a long-running
+// Execute can be interrupted (SIGINT, crash, infra flake) and resumed
+// without repeating already-successful work.
+//
+// The file is rewritten in full on every Record: entries are marshaled one
+// per line and written to path+".tmp", fsynced, then renamed over path.
+// Because rename is atomic, a reader (or a crash mid-write) never observes a
+// partially-written checkpoint, and the file stays safe to read while a
+// writer is appending to it.
+type Checkpoint struct {
+	mu      sync.Mutex
+	path    string
+	entries []CheckpointEntry
+}
+
+// OpenCheckpoint opens the checkpoint file at path, loading any entries
+// already recorded there. A missing file is treated as an empty checkpoint,
+// since the first run of a plan has none yet.
+func OpenCheckpoint(path string) (*Checkpoint, error) {
+	entries, err := LoadCheckpoint(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Checkpoint{path: path, entries: entries}, nil
+}
+
+// LoadCheckpoint reads every entry recorded in the checkpoint file at path,
+// in the order they were recorded. A missing file returns no entries and no
+// error. A trailing line left half-written by a crash (rather than by the
+// write-tmp-fsync-rename cycle Record uses, e.g. from an older, less careful
+// writer) is skipped rather than failing the whole load.
+func LoadCheckpoint(path string) ([]CheckpointEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+
+	var entries []CheckpointEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry CheckpointEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+	return entries, nil
+}
+
+// Record appends an entry for task's outcome (err nil means success) and
+// atomically rewrites the checkpoint file on disk.
+func (c *Checkpoint) Record(task *Task, err error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := CheckpointEntry{
+		RunID:    task.RunID,
+		Status:   CheckpointStatusSucceeded,
+		ExitedAt: time.Now(),
+	}
+	if err != nil {
+		entry.Status = CheckpointStatusFailed
+		entry.Error = err.Error()
+	}
+	c.entries = append(c.entries, entry)
+
+	return c.writeLocked()
+}
+
+// RecordStarted appends a CheckpointStatusStarted entry for task, called
+// just before it runs. Its only purpose is to leave a trace of in-flight
+// work if the process crashes before a succeeded/failed entry follows it;
+// FilterTasks never treats "started" alone as a reason to skip a task.
+func (c *Checkpoint) RecordStarted(task *Task) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = append(c.entries, CheckpointEntry{
+		RunID:    task.RunID,
+		Status:   CheckpointStatusStarted,
+		ExitedAt: time.Now(),
+	})
+	return c.writeLocked()
+}
+
+// writeLocked rewrites c.path in full: every recorded entry, one JSON object
+// per line, written to path+".tmp", fsynced, then renamed over path.
+func (c *Checkpoint) writeLocked() error {
+	var buf bytes.Buffer
+	for _, entry := range c.entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("marshaling checkpoint entry for %s: %w", entry.RunID, err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	tmpPath := c.path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint tmp file: %w", err)
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write checkpoint tmp file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to fsync checkpoint tmp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close checkpoint tmp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, c.path); err != nil {
+		return fmt.Errorf("failed to rename checkpoint tmp file into place: %w", err)
+	}
+	return nil
+}
+
+// Completed returns the most recently recorded status for every RunID in
+// the checkpoint. A RunID recorded more than once (e.g. a task that failed
+// and was later re-queued and succeeded) keeps only its latest status.
+func (c *Checkpoint) Completed() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return latestStatuses(c.entries)
+}
+
+// latestStatuses reduces entries down to each RunID's most recently
+// recorded status.
+func latestStatuses(entries []CheckpointEntry) map[string]string {
+	statuses := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		statuses[entry.RunID] = entry.Status
+	}
+	return statuses
+}
+
+// FilterTasks narrows tasks down to those mode says should still run, given
+// what checkpoint has already recorded. A nil checkpoint or ResumeNone
+// returns tasks unchanged.
+func FilterTasks(tasks []*Task, checkpoint *Checkpoint, mode ResumeMode) []*Task {
+	if checkpoint == nil || mode == ResumeNone {
+		return tasks
+	}
+	completed := checkpoint.Completed()
+
+	var filtered []*Task
+	for _, task := range tasks {
+		status, recorded := completed[task.RunID]
+		switch mode {
+		case ResumeSkipSucceeded:
+			if recorded && status == CheckpointStatusSucceeded {
+				continue
+			}
+			filtered = append(filtered, task)
+		case ResumeRestartFailed:
+			if recorded && status == CheckpointStatusFailed {
+				filtered = append(filtered, task)
+			}
+		case ResumeSkipCompleted:
+			if recorded && (status == CheckpointStatusSucceeded || status == CheckpointStatusFailed) {
+				continue
+			}
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,169 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint_RecordAndLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "checkpoint.jsonl")
+
+	checkpoint, err := OpenCheckpoint(path)
+	require.NoError(t, err)
+
+	require.NoError(t, checkpoint.Record(&Task{RunID: "A1_001"}, nil))
+	require.NoError(t, checkpoint.Record(&Task{RunID: "A1_002"}, errors.New("boom")))
+
+	completed := checkpoint.Completed()
+	assert.Equal(t, CheckpointStatusSucceeded, completed["A1_001"])
+	assert.Equal(t, CheckpointStatusFailed, completed["A1_002"])
+
+	// .tmp never lingers once Record has returned.
+	_, statErr := os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(statErr))
+
+	reloaded, err := OpenCheckpoint(path)
+	require.NoError(t, err)
+	assert.Equal(t, completed, reloaded.Completed())
+}
+
+func TestCheckpoint_LatestStatusWins(t *testing.T) {
+	tmpDir := t.TempDir()
+	checkpoint, err := OpenCheckpoint(filepath.Join(tmpDir, "checkpoint.jsonl"))
+	require.NoError(t, err)
+
+	require.NoError(t, checkpoint.Record(&Task{RunID: "A1_001"}, errors.New("boom")))
+	require.NoError(t, checkpoint.Record(&Task{RunID: "A1_001"}, nil))
+
+	assert.Equal(t, CheckpointStatusSucceeded, checkpoint.Completed()["A1_001"])
+}
+
+func TestLoadCheckpoint_MissingFile(t *testing.T) {
+	entries, err := LoadCheckpoint(filepath.Join(t.TempDir(), "missing.jsonl"))
+	require.NoError(t, err)
+	assert.Nil(t, entries)
+}
+
+func TestFilterTasks(t *testing.T) {
+	tasks := []*Task{
+		{RunID: "A1_001"},
+		{RunID: "A1_002"},
+		{RunID: "A1_003"},
+	}
+
+	tmpDir := t.TempDir()
+	checkpoint, err := OpenCheckpoint(filepath.Join(tmpDir, "checkpoint.jsonl"))
+	require.NoError(t, err)
+	require.NoError(t, checkpoint.Record(tasks[0], nil))
+	require.NoError(t, checkpoint.Record(tasks[1], errors.New("boom")))
+	// tasks[2] was never attempted.
+
+	resumed := FilterTasks(tasks, checkpoint, ResumeSkipSucceeded)
+	require.Len(t, resumed, 2)
+	assert.Equal(t, "A1_002", resumed[0].RunID)
+	assert.Equal(t, "A1_003", resumed[1].RunID)
+
+	restarted := FilterTasks(tasks, checkpoint, ResumeRestartFailed)
+	require.Len(t, restarted, 1)
+	assert.Equal(t, "A1_002", restarted[0].RunID)
+
+	assert.Equal(t, tasks, FilterTasks(tasks, checkpoint, ResumeNone))
+	assert.Equal(t, tasks, FilterTasks(tasks, nil, ResumeSkipSucceeded))
+
+	onlyPending := FilterTasks(tasks, checkpoint, ResumeSkipCompleted)
+	require.Len(t, onlyPending, 1)
+	assert.Equal(t, "A1_003", onlyPending[0].RunID)
+}
+
+func TestFilterTasks_ResumeSkipCompleted_StartedOnlyStillRuns(t *testing.T) {
+	tasks := []*Task{{RunID: "A1_001"}}
+
+	tmpDir := t.TempDir()
+	checkpoint, err := OpenCheckpoint(filepath.Join(tmpDir, "checkpoint.jsonl"))
+	require.NoError(t, err)
+	require.NoError(t, checkpoint.RecordStarted(tasks[0]))
+
+	resumed := FilterTasks(tasks, checkpoint, ResumeSkipCompleted)
+	require.Len(t, resumed, 1, "a task that only got as far as \"started\" before a crash must still be re-run")
+}
+
+func TestGenerateTasksFromPlanWithResume(t *testing.T) {
+	tmpDir := t.TempDir()
+	scenarioPath := filepath.Join(tmpDir, "baseline.json")
+	require.NoError(t, os.WriteFile(scenarioPath, []byte(`{"event":[]}`), 0644))
+
+	checkpointPath := filepath.Join(tmpDir, "checkpoint.jsonl")
+	plan := &Plan{
+		Scenarios:      []ScenarioEntry{{Pattern: "baseline.json", Repeat: 3, Runtime: DefaultRuntimeKind}},
+		CheckpointPath: checkpointPath,
+	}
+
+	checkpoint, err := OpenCheckpoint(checkpointPath)
+	require.NoError(t, err)
+	require.NoError(t, checkpoint.Record(&Task{RunID: "baseline_001"}, nil))
+
+	tasks, opened, err := GenerateTasksFromPlanWithResume(plan, tmpDir, ResumeSkipSucceeded)
+	require.NoError(t, err)
+	require.NotNil(t, opened)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "baseline_002", tasks[0].RunID)
+	assert.Equal(t, "baseline_003", tasks[1].RunID)
+}
+
+func TestPlanStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+	scenarioPath := filepath.Join(tmpDir, "baseline.json")
+	require.NoError(t, os.WriteFile(scenarioPath, []byte(`{"event":[]}`), 0644))
+
+	plan := &Plan{Scenarios: []ScenarioEntry{{Pattern: "baseline.json", Repeat: 3, Runtime: DefaultRuntimeKind}}}
+
+	checkpointPath := filepath.Join(tmpDir, "checkpoint.jsonl")
+	checkpoint, err := OpenCheckpoint(checkpointPath)
+	require.NoError(t, err)
+	require.NoError(t, checkpoint.Record(&Task{RunID: "baseline_001"}, nil))
+	require.NoError(t, checkpoint.Record(&Task{RunID: "baseline_002"}, errors.New("boom")))
+
+	statuses, err := PlanStatus(plan, tmpDir, checkpointPath)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "baseline.json", statuses[0].Pattern)
+	assert.Equal(t, 3, statuses[0].Total)
+	assert.Equal(t, 1, statuses[0].Completed)
+	assert.Equal(t, 1, statuses[0].Failed)
+	assert.Equal(t, 1, statuses[0].Pending)
+}
+
+func TestExecutor_SetCheckpoint_RecordsOutcomes(t *testing.T) {
+	runner := &stubRunner{failFor: map[string]bool{"A1_002": true}}
+	exec := NewExecutor(1, runner)
+
+	tmpDir := t.TempDir()
+	checkpoint, err := OpenCheckpoint(filepath.Join(tmpDir, "checkpoint.jsonl"))
+	require.NoError(t, err)
+	exec.SetCheckpoint(checkpoint)
+
+	tasks := []*Task{{RunID: "A1_001"}, {RunID: "A1_002"}}
+	exec.Execute(context.Background(), tasks)
+
+	completed := checkpoint.Completed()
+	assert.Equal(t, CheckpointStatusSucceeded, completed["A1_001"])
+	assert.Equal(t, CheckpointStatusFailed, completed["A1_002"])
+}
+
+type stubRunner struct {
+	failFor map[string]bool
+}
+
+func (r *stubRunner) Run(ctx context.Context, task *Task) error {
+	if r.failFor[task.RunID] {
+		return errors.New("boom")
+	}
+	return nil
+}
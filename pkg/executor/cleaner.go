@@ -2,15 +2,46 @@
 package executor
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/3atlab/netroub/pkg/model"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
 	"github.com/sirupsen/logrus"
 )
 
+// cleanupWorkers bounds the number of concurrent container/network removals
+// issued against the Docker daemon.
+const cleanupWorkers = 8
+
+// dockerAPIClient is the subset of the Docker Engine SDK used by the cleanup
+// helpers below. It exists so tests can supply a fake implementation without
+// requiring a real daemon.
+type dockerAPIClient interface {
+	ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error)
+	ContainerRemove(ctx context.Context, containerID string, options types.ContainerRemoveOptions) error
+	NetworkList(ctx context.Context, options types.NetworkListOptions) ([]types.NetworkResource, error)
+	NetworkRemove(ctx context.Context, networkID string) error
+}
+
+// newDockerClient constructs a Docker client from the environment
+// (DOCKER_HOST, DOCKER_TLS_VERIFY, DOCKER_CERT_PATH). It is a var so tests
+// can substitute a fake dockerAPIClient.
+var newDockerClient = func() (dockerAPIClient, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	return cli, nil
+}
+
 // GenerateLabNames generates all lab names for a plan.
 // Returns a list of lab name patterns (e.g., "baseline_001", "baseline_002", ...).
 func GenerateLabNamesFromPlan(plan *Plan, baseDir string) ([]string, error) {
@@ -63,78 +94,148 @@ func CleanContainers(labNames []string, dryRun bool) (int, error) {
 		return 0, nil
 	}
 
-	// Get all clab- containers at once (efficient single docker call)
-	output, err := exec.Command("sudo", "docker", "ps", "-a", "--filter", "name=clab-", "--format", "{{.ID}}\t{{.Names}}").Output()
+	cli, err := newDockerClient()
 	if err != nil {
-		return 0, fmt.Errorf("failed to list containers: %w", err)
+		return 0, err
 	}
 
-	if len(output) == 0 {
-		logrus.Info("No containers found to clean")
-		return 0, nil
+	ctx := context.Background()
+
+	// Container names are like "clab-{labName}-{nodeName}"; OR all lab name
+	// prefixes together in a single filtered list call.
+	f := filters.NewArgs()
+	for _, labName := range labNames {
+		f.Add("name", fmt.Sprintf("clab-%s-", labName))
 	}
+	f.Add("label", "containerlab=")
 
-	// Build a set of lab names for fast lookup
-	labNameSet := make(map[string]bool)
-	for _, name := range labNames {
-		labNameSet[name] = true
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: f})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list containers: %w", err)
 	}
 
-	// Filter containers that match our lab names
-	// Container names are like "clab-{labName}-{nodeName}"
-	var matchingContainers []string
-	var matchingNames []string
+	if len(containers) == 0 {
+		logrus.Info("No matching containers found to clean")
+		return 0, nil
+	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-		parts := strings.SplitN(line, "\t", 2)
-		if len(parts) != 2 {
-			continue
+	if dryRun {
+		fmt.Printf("Found %d containers to remove:\n", len(containers))
+		for _, c := range containers {
+			fmt.Printf("  %s\n", containerDisplayName(c))
 		}
-		containerID := parts[0]
-		containerName := parts[1]
+		return len(containers), nil
+	}
 
-		// Extract lab name from container name: clab-{labName}-{nodeName}
-		if !strings.HasPrefix(containerName, "clab-") {
-			continue
-		}
+	removed, err := removeContainersParallel(ctx, cli, containers)
+	if err != nil {
+		return removed, err
+	}
+
+	logrus.Infof("Removed %d containers", removed)
+	return removed, nil
+}
 
-		// Find the lab name by matching against our set
-		for labName := range labNameSet {
-			prefix := fmt.Sprintf("clab-%s-", labName)
-			if strings.HasPrefix(containerName, prefix) {
-				matchingContainers = append(matchingContainers, containerID)
-				matchingNames = append(matchingNames, containerName)
-				break
+// removeContainersParallel force-removes containers using a bounded worker
+// pool. A container that is already gone (errdefs.IsNotFound) is not treated
+// as a failure.
+func removeContainersParallel(ctx context.Context, cli dockerAPIClient, containers []types.Container) (int, error) {
+	sem := make(chan struct{}, cleanupWorkers)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	removed := 0
+	var firstErr error
+
+	for _, c := range containers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(container types.Container) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := cli.ContainerRemove(ctx, container.ID, types.ContainerRemoveOptions{Force: true})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil && !errdefs.IsNotFound(err) {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to remove container %s: %w", containerDisplayName(container), err)
+				}
+				return
 			}
-		}
+			removed++
+		}(c)
 	}
 
-	if len(matchingContainers) == 0 {
-		logrus.Info("No matching containers found to clean")
-		return 0, nil
+	wg.Wait()
+	return removed, firstErr
+}
+
+// CleanupLabsWithPrefix tears down every containerlab-managed Docker
+// container and network whose name starts with "clab-"+prefix, for
+// `netroub cleanup <prefix>` to destroy labs a crashed or interrupted run
+// left behind. Unlike CleanContainers/CleanDockerNetworks (which take an
+// exact, already-known set of lab names), this matches by prefix since the
+// caller generally doesn't know which lab name suffixes (repetition
+// counters, matrix params, run IDs, ...) are still running.
+func CleanupLabsWithPrefix(prefix string, dryRun bool) (containersRemoved, networksRemoved int, err error) {
+	cli, err := newDockerClient()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ctx := context.Background()
+
+	cf := filters.NewArgs()
+	cf.Add("name", "clab-"+prefix)
+	cf.Add("label", "containerlab=")
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: cf})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	nf := filters.NewArgs()
+	nf.Add("name", "clab-"+prefix)
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: nf})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list networks: %w", err)
 	}
 
 	if dryRun {
-		fmt.Printf("Found %d containers to remove:\n", len(matchingContainers))
-		for _, name := range matchingNames {
-			fmt.Printf("  %s\n", name)
+		fmt.Printf("Found %d containers and %d networks to remove:\n", len(containers), len(networks))
+		for _, c := range containers {
+			fmt.Printf("  container %s\n", containerDisplayName(c))
 		}
-		return len(matchingContainers), nil
+		for _, n := range networks {
+			fmt.Printf("  network %s\n", n.Name)
+		}
+		return len(containers), len(networks), nil
 	}
 
-	// Remove containers
-	args := append([]string{"docker", "rm", "-f"}, matchingContainers...)
-	cmd := exec.Command("sudo", args...)
-	cmdOutput, err := cmd.CombinedOutput()
+	containersRemoved, err = removeContainersParallel(ctx, cli, containers)
 	if err != nil {
-		return 0, fmt.Errorf("failed to remove containers: %w, output: %s", err, cmdOutput)
+		return containersRemoved, 0, err
 	}
 
-	return len(matchingContainers), nil
+	for _, n := range networks {
+		if rmErr := cli.NetworkRemove(ctx, n.ID); rmErr != nil && !errdefs.IsNotFound(rmErr) {
+			logrus.Warnf("Failed to remove network %s: %v", n.Name, rmErr)
+			continue
+		}
+		networksRemoved++
+	}
+
+	logrus.Infof("Removed %d containers, %d networks matching prefix %q", containersRemoved, networksRemoved, prefix)
+	return containersRemoved, networksRemoved, nil
+}
+
+// containerDisplayName returns the first name of a container without its
+// leading slash, falling back to its ID.
+func containerDisplayName(c types.Container) string {
+	if len(c.Names) > 0 {
+		return strings.TrimPrefix(c.Names[0], "/")
+	}
+	return c.ID
 }
 
 // CleanLabDirectories removes containerlab lab directories matching the given lab names.
@@ -145,9 +246,8 @@ func CleanLabDirectories(topoDir string, labNames []string, dryRun bool) (int, e
 	for _, labName := range labNames {
 		labDir := fmt.Sprintf("%s/clab-%s", topoDir, labName)
 
-		// Check if directory exists
-		cmd := exec.Command("test", "-d", labDir)
-		if err := cmd.Run(); err != nil {
+		info, err := os.Stat(labDir)
+		if err != nil || !info.IsDir() {
 			// Directory doesn't exist, skip
 			continue
 		}
@@ -158,9 +258,7 @@ func CleanLabDirectories(topoDir string, labNames []string, dryRun bool) (int, e
 			continue
 		}
 
-		// Remove directory
-		cmd = exec.Command("sudo", "rm", "-rf", labDir)
-		if err := cmd.Run(); err != nil {
+		if err := os.RemoveAll(labDir); err != nil {
 			logrus.Warnf("Failed to remove directory %s: %v", labDir, err)
 			continue
 		}
@@ -183,55 +281,52 @@ func CleanDockerNetworks(labNames []string, dryRun bool) (int, error) {
 		return 0, nil
 	}
 
-	// Get all clab- networks
-	output, err := exec.Command("sudo", "docker", "network", "ls", "--filter", "name=clab-", "--format", "{{.Name}}").Output()
+	cli, err := newDockerClient()
 	if err != nil {
-		return 0, fmt.Errorf("failed to list networks: %w", err)
+		return 0, err
 	}
 
-	if len(output) == 0 {
-		return 0, nil
-	}
+	ctx := context.Background()
 
-	// Build a set of expected network names
-	networkNameSet := make(map[string]bool)
+	networkNameSet := make(map[string]bool, len(labNames))
+	f := filters.NewArgs()
 	for _, labName := range labNames {
-		networkNameSet["clab-"+labName] = true
+		name := "clab-" + labName
+		networkNameSet[name] = true
+		f.Add("name", name)
 	}
 
-	// Find matching networks
-	var matchingNetworks []string
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	for _, networkName := range lines {
-		if networkName == "" {
-			continue
-		}
-		if networkNameSet[networkName] {
-			matchingNetworks = append(matchingNetworks, networkName)
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: f})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	// The name filter matches substrings, so narrow down to exact matches.
+	var matching []types.NetworkResource
+	for _, n := range networks {
+		if networkNameSet[n.Name] {
+			matching = append(matching, n)
 		}
 	}
 
-	if len(matchingNetworks) == 0 {
+	if len(matching) == 0 {
 		return 0, nil
 	}
 
 	if dryRun {
-		fmt.Printf("Found %d Docker networks to remove:\n", len(matchingNetworks))
-		for _, name := range matchingNetworks {
-			fmt.Printf("  %s\n", name)
+		fmt.Printf("Found %d Docker networks to remove:\n", len(matching))
+		for _, n := range matching {
+			fmt.Printf("  %s\n", n.Name)
 		}
-		return len(matchingNetworks), nil
+		return len(matching), nil
 	}
 
-	// Remove networks
 	removed := 0
-	for _, networkName := range matchingNetworks {
-		cmd := exec.Command("sudo", "docker", "network", "rm", networkName)
-		if err := cmd.Run(); err != nil {
-			logrus.Warnf("Failed to remove network %s: %v", networkName, err)
+	for _, n := range matching {
+		if err := cli.NetworkRemove(ctx, n.ID); err != nil && !errdefs.IsNotFound(err) {
+			logrus.Warnf("Failed to remove network %s: %v", n.Name, err)
 			continue
 		}
-		logrus.Debugf("Removed network: %s", networkName)
 		removed++
 	}
 
@@ -0,0 +1,179 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDockerClient is a minimal in-memory stand-in for dockerAPIClient.
+type fakeDockerClient struct {
+	containers []types.Container
+	networks   []types.NetworkResource
+
+	removedContainers []string
+	removedNetworks   []string
+	removeErr         error
+}
+
+func (f *fakeDockerClient) ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
+	return f.containers, nil
+}
+
+func (f *fakeDockerClient) ContainerRemove(ctx context.Context, containerID string, options types.ContainerRemoveOptions) error {
+	if f.removeErr != nil {
+		return f.removeErr
+	}
+	f.removedContainers = append(f.removedContainers, containerID)
+	return nil
+}
+
+func (f *fakeDockerClient) NetworkList(ctx context.Context, options types.NetworkListOptions) ([]types.NetworkResource, error) {
+	return f.networks, nil
+}
+
+func (f *fakeDockerClient) NetworkRemove(ctx context.Context, networkID string) error {
+	if f.removeErr != nil {
+		return f.removeErr
+	}
+	f.removedNetworks = append(f.removedNetworks, networkID)
+	return nil
+}
+
+func withFakeDockerClient(t *testing.T, fake *fakeDockerClient) {
+	t.Helper()
+	original := newDockerClient
+	newDockerClient = func() (dockerAPIClient, error) { return fake, nil }
+	t.Cleanup(func() { newDockerClient = original })
+}
+
+func TestCleanContainers_RemovesMatching(t *testing.T) {
+	fake := &fakeDockerClient{
+		containers: []types.Container{
+			{ID: "c1", Names: []string{"/clab-baseline_001-r1"}},
+			{ID: "c2", Names: []string{"/clab-baseline_001-r2"}},
+		},
+	}
+	withFakeDockerClient(t, fake)
+
+	removed, err := CleanContainers([]string{"baseline_001"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assert.ElementsMatch(t, []string{"c1", "c2"}, fake.removedContainers)
+}
+
+func TestCleanContainers_DryRun(t *testing.T) {
+	fake := &fakeDockerClient{
+		containers: []types.Container{
+			{ID: "c1", Names: []string{"/clab-baseline_001-r1"}},
+		},
+	}
+	withFakeDockerClient(t, fake)
+
+	removed, err := CleanContainers([]string{"baseline_001"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Empty(t, fake.removedContainers)
+}
+
+func TestCleanContainers_NoLabNames(t *testing.T) {
+	removed, err := CleanContainers(nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, removed)
+}
+
+func TestCleanContainers_NotFoundIsNotAnError(t *testing.T) {
+	fake := &fakeDockerClient{
+		containers: []types.Container{
+			{ID: "c1", Names: []string{"/clab-baseline_001-r1"}},
+		},
+		removeErr: errdefs.NotFound(errors.New("no such container")),
+	}
+	withFakeDockerClient(t, fake)
+
+	removed, err := CleanContainers([]string{"baseline_001"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+}
+
+func TestCleanDockerNetworks_RemovesExactMatch(t *testing.T) {
+	fake := &fakeDockerClient{
+		networks: []types.NetworkResource{
+			{ID: "n1", Name: "clab-baseline_001"},
+			{ID: "n2", Name: "clab-baseline_001-extra"}, // should not match exactly
+		},
+	}
+	withFakeDockerClient(t, fake)
+
+	removed, err := CleanDockerNetworks([]string{"baseline_001"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, []string{"n1"}, fake.removedNetworks)
+}
+
+func TestCleanupLabsWithPrefix_RemovesContainersAndNetworks(t *testing.T) {
+	fake := &fakeDockerClient{
+		containers: []types.Container{
+			{ID: "c1", Names: []string{"/clab-baseline_001-r1"}},
+			{ID: "c2", Names: []string{"/clab-baseline_002-r1"}},
+		},
+		networks: []types.NetworkResource{
+			{ID: "n1", Name: "clab-baseline_001"},
+			{ID: "n2", Name: "clab-baseline_002"},
+		},
+	}
+	withFakeDockerClient(t, fake)
+
+	containersRemoved, networksRemoved, err := CleanupLabsWithPrefix("baseline_", false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, containersRemoved)
+	assert.Equal(t, 2, networksRemoved)
+	assert.ElementsMatch(t, []string{"c1", "c2"}, fake.removedContainers)
+	assert.ElementsMatch(t, []string{"n1", "n2"}, fake.removedNetworks)
+}
+
+func TestCleanupLabsWithPrefix_DryRunRemovesNothing(t *testing.T) {
+	fake := &fakeDockerClient{
+		containers: []types.Container{{ID: "c1", Names: []string{"/clab-baseline_001-r1"}}},
+		networks:   []types.NetworkResource{{ID: "n1", Name: "clab-baseline_001"}},
+	}
+	withFakeDockerClient(t, fake)
+
+	containersRemoved, networksRemoved, err := CleanupLabsWithPrefix("baseline_", true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, containersRemoved)
+	assert.Equal(t, 1, networksRemoved)
+	assert.Empty(t, fake.removedContainers)
+	assert.Empty(t, fake.removedNetworks)
+}
+
+func TestCleanLabDirectories_DryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(tmpDir+"/clab-baseline_001", 0755))
+
+	removed, err := CleanLabDirectories(tmpDir, []string{"baseline_001", "missing"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	// Dry run must not remove anything.
+	_, statErr := os.Stat(tmpDir + "/clab-baseline_001")
+	assert.NoError(t, statErr)
+}
+
+func TestCleanLabDirectories_Removes(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(tmpDir+"/clab-baseline_001", 0755))
+
+	removed, err := CleanLabDirectories(tmpDir, []string{"baseline_001"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	_, statErr := os.Stat(tmpDir + "/clab-baseline_001")
+	assert.Error(t, statErr)
+}
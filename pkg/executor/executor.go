@@ -2,10 +2,13 @@
 package executor
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/3atlab/netroub/pkg/telemetry"
 	"github.com/sirupsen/logrus"
 )
 
@@ -13,7 +16,15 @@ import (
 type Task struct {
 	ScenarioPath string
 	RunID        string // Unique ID for this run (e.g., "A1_delay_pause_001")
+	Index        int    // 1-based repetition index within its scenario, exposed as NETROUB_RUN_INDEX
 	YAML         bool
+	Runtime      RuntimeKind    // Container runtime backend to deploy/destroy this task's topology
+	Retry        *RetryPolicy   // Retry/backoff behavior on failure; nil means no retries
+	Params       map[string]any // Effective matrix parameter assignment, if generated from a ScenarioEntry.Matrix; nil otherwise
+	// Host names an entry in Plan.Hosts this task's containerlab/docker
+	// commands should run through. Empty means run on this machine. See
+	// Executor.SetHostLimits and ScenarioRunner.Hosts.
+	Host string
 }
 
 // Result represents the result of a task execution.
@@ -23,6 +34,15 @@ type Result struct {
 	Duration  time.Duration
 	StartTime time.Time // Trial start time
 	LogDir    string    // Log directory path for this trial
+	Attempts  int       // Number of times the task was run, including retries
+
+	// PriorAttempts holds a Result for each retried attempt that preceded
+	// this (authoritative) one, oldest first. Each entry's Task is a copy
+	// of the original with RunID suffixed "_retry<N>", matching the RunID
+	// the runner actually saw for that attempt. nil if the task succeeded
+	// on its first try. Summary/PrintSummary/the Reporters only ever look
+	// at the top-level []*Result, so these never double-count.
+	PriorAttempts []*Result
 }
 
 // Executor manages parallel execution of scenario tasks.
@@ -30,15 +50,24 @@ type Executor struct {
 	parallel    int
 	runner      TaskRunner
 	batchLogger *BatchLogger
+	checkpoint  *Checkpoint
+	telemetry   telemetry.Sink
+	failFast    bool
+	hostLimits  map[string]chan struct{}
 }
 
-// TaskRunner is the interface for executing a single task.
+// TaskRunner is the interface for executing a single task. ctx cancellation
+// (e.g. Ctrl-C at the CLI) should abort the task in progress rather than
+// leaving it to run to completion.
 // This allows for testing with mock implementations.
 type TaskRunner interface {
-	Run(task *Task) error
+	Run(ctx context.Context, task *Task) error
 }
 
-// TaskRunnerResult contains the result of a task execution.
+// TaskRunnerResult contains the result of a task execution. If ctx was
+// canceled before the task finished, Error wraps ctx.Err() (errors.Is(Error,
+// context.Canceled) holds), so callers like BatchLogger can tell a
+// user-cancel apart from a scenario failure.
 type TaskRunnerResult struct {
 	LogDir string
 	Error  error
@@ -47,7 +76,7 @@ type TaskRunnerResult struct {
 // TaskRunnerWithResult extends TaskRunner to return detailed results.
 type TaskRunnerWithResult interface {
 	TaskRunner
-	RunWithResult(task *Task, startTime time.Time) TaskRunnerResult
+	RunWithResult(ctx context.Context, task *Task, startTime time.Time) TaskRunnerResult
 }
 
 // NewExecutor creates a new Executor with the specified parallelism.
@@ -66,18 +95,76 @@ func (e *Executor) SetBatchLogger(logger *BatchLogger) {
 	e.batchLogger = logger
 }
 
-// Execute runs all tasks with the configured parallelism.
+// SetCheckpoint sets the checkpoint this executor records each task's final
+// outcome to, so the run can be resumed later via FilterTasks.
+func (e *Executor) SetCheckpoint(checkpoint *Checkpoint) {
+	e.checkpoint = checkpoint
+}
+
+// SetFailFast enables "stop on first failure" behavior, mirroring `go test
+// -failfast`: once any task's authoritative outcome (after exhausting its
+// retries, if any) is a failure, tasks still queued are skipped and
+// in-flight tasks have their context canceled, rather than being left to
+// run to completion. Default false runs every task regardless of earlier
+// failures.
+func (e *Executor) SetFailFast(failFast bool) {
+	e.failFast = failFast
+}
+
+// SetHostLimits caps how many tasks run at once per host name (see
+// Task.Host), independent of the overall e.parallel worker count: a task
+// whose Host has a configured limit acquires one of that host's slots
+// before running and releases it when done, so one beefy host can run 8
+// tasks in parallel while a smaller one runs 2, even though both draw from
+// the same worker pool. A host with no entry here (or limit <= 0) is
+// unbounded. Must be called before ExecuteWithProgress.
+func (e *Executor) SetHostLimits(limits map[string]int) {
+	hostLimits := make(map[string]chan struct{}, len(limits))
+	for host, limit := range limits {
+		if limit <= 0 {
+			continue
+		}
+		hostLimits[host] = make(chan struct{}, limit)
+	}
+	e.hostLimits = hostLimits
+}
+
+// SetTelemetry sets the sink this executor publishes task_started/
+// task_finished records to, so an external dashboard can follow a run's
+// progress as it happens. Unset, telemetry is a no-op.
+func (e *Executor) SetTelemetry(sink telemetry.Sink) {
+	e.telemetry = sink
+}
+
+// sink returns e.telemetry, or telemetry.NoopSink{} if none is configured.
+func (e *Executor) sink() telemetry.Sink {
+	if e.telemetry == nil {
+		return telemetry.NoopSink{}
+	}
+	return e.telemetry
+}
+
+// Execute runs all tasks with the configured parallelism. Canceling ctx
+// (e.g. from a CLI SIGINT handler) stops workers from starting further
+// tasks and propagates into each in-flight task's Run/RunWithResult call.
 // Returns a slice of results for all tasks.
-func (e *Executor) Execute(tasks []*Task) []*Result {
-	return e.ExecuteWithProgress(tasks, false)
+func (e *Executor) Execute(ctx context.Context, tasks []*Task) []*Result {
+	return e.ExecuteWithProgress(ctx, tasks, false)
 }
 
 // ExecuteWithProgress runs all tasks with optional progress display.
-func (e *Executor) ExecuteWithProgress(tasks []*Task, showProgress bool) []*Result {
+func (e *Executor) ExecuteWithProgress(ctx context.Context, tasks []*Task, showProgress bool) []*Result {
 	results := make([]*Result, len(tasks))
 	taskChan := make(chan int, len(tasks))
 	var wg sync.WaitGroup
 
+	// runCtx is ctx, plus e.failFast's own cancellation: canceling it (on
+	// the first failed task, if enabled) stops queued tasks from starting
+	// and propagates into in-flight Run/RunWithResult calls, same as ctx
+	// cancellation from a CLI SIGINT handler.
+	runCtx, cancelRun := context.WithCancelCause(ctx)
+	defer cancelRun(nil)
+
 	// Check if runner supports extended interface
 	runnerWithResult, hasExtended := e.runner.(TaskRunnerWithResult)
 
@@ -106,59 +193,173 @@ func (e *Executor) ExecuteWithProgress(tasks []*Task, showProgress bool) []*Resu
 		go func(workerID int) {
 			defer wg.Done()
 			for i := range taskChan {
+				if runCtx.Err() != nil {
+					results[i] = &Result{Task: tasks[i], Error: context.Cause(runCtx)}
+					continue
+				}
 				task := tasks[i]
-				startTime := time.Now()
-
-				if !showProgress {
-					logrus.Infof("[Worker %d] Starting task %s", workerID, task.RunID)
+				if slot, ok := e.hostLimits[task.Host]; ok {
+					slot <- struct{}{}
+				}
+				results[i] = e.runWithRetry(runCtx, task, workerID, showProgress, runnerWithResult, hasExtended, progress)
+				if slot, ok := e.hostLimits[task.Host]; ok {
+					<-slot
 				}
+				if e.failFast && results[i].Error != nil {
+					cancelRun(fmt.Errorf("fail-fast: task %s failed: %w", task.RunID, results[i].Error))
+				}
+			}
+		}(w)
+	}
 
-				var err error
-				var logDir string
+	wg.Wait()
+	return results
+}
 
-				if hasExtended {
-					result := runnerWithResult.RunWithResult(task, startTime)
-					err = result.Error
-					logDir = result.LogDir
-				} else {
-					err = e.runner.Run(task)
-				}
+// runWithRetry runs task to completion, retrying on a retryable error per
+// task.Retry until it succeeds or exhausts MaxAttempts, sleeping for the
+// policy's backoff between attempts. A canceled ctx is itself terminal
+// (defaultRetryable treats context.Canceled as non-retryable), so a
+// cancellation during one attempt ends the task rather than retrying it.
+func (e *Executor) runWithRetry(ctx context.Context, task *Task, workerID int, showProgress bool, runnerWithResult TaskRunnerWithResult, hasExtended bool, progress *ProgressTracker) *Result {
+	maxAttempts := task.Retry.maxAttempts()
+	startTime := time.Now()
+
+	if e.checkpoint != nil {
+		if err := e.checkpoint.RecordStarted(task); err != nil {
+			logrus.Warnf("[Worker %d] Task %s: failed to record checkpoint: %v", workerID, task.RunID, err)
+		}
+	}
 
-				duration := time.Since(startTime)
+	if err := e.sink().Publish(ctx, telemetry.Record{
+		Type:         telemetry.RecordTaskStarted,
+		Time:         startTime,
+		RunID:        task.RunID,
+		ScenarioPath: task.ScenarioPath,
+	}); err != nil {
+		logrus.Debugf("[Worker %d] telemetry publish failed: %v", workerID, err)
+	}
 
-				results[i] = &Result{
-					Task:      task,
-					Error:     err,
-					Duration:  duration,
-					StartTime: startTime,
-					LogDir:    logDir,
-				}
+	var err error
+	var logDir string
+	var duration time.Duration
+	attemptsUsed := 0
+	var priorAttempts []*Result
 
-				// Update progress tracker
-				progress.TaskCompleted(task, err)
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		attemptsUsed = attempt
+		if !showProgress {
+			logrus.Infof("[Worker %d] Starting task %s (attempt %d/%d)", workerID, task.RunID, attempt, maxAttempts)
+		}
 
-				// Log to batch logger
-				if e.batchLogger != nil {
-					e.batchLogger.LogTaskCompleted(task, duration, err, logDir)
-				}
+		// attemptTask is task itself on the first try; retries get a copy
+		// with RunID suffixed "_retry<N>" so each attempt deploys its own
+		// lab/log directory instead of clobbering the previous attempt's.
+		attemptTask := task
+		if attempt > 1 {
+			taskCopy := *task
+			taskCopy.RunID = fmt.Sprintf("%s_retry%d", task.RunID, attempt-1)
+			attemptTask = &taskCopy
+		}
 
-				if !showProgress {
-					if err != nil {
-						logrus.Warnf("[Worker %d] Task %s failed: %v (%.1fs)", workerID, task.RunID, err, duration.Seconds())
-					} else {
-						logrus.Infof("[Worker %d] Task %s completed (%.1fs)", workerID, task.RunID, duration.Seconds())
-					}
-				}
-			}
-		}(w)
+		attemptStart := time.Now()
+		if hasExtended {
+			result := runnerWithResult.RunWithResult(ctx, attemptTask, attemptStart)
+			err = result.Error
+			logDir = result.LogDir
+		} else {
+			err = e.runner.Run(ctx, attemptTask)
+		}
+		attemptDuration := time.Since(attemptStart)
+		duration = time.Since(startTime)
+
+		progress.TaskAttempted(task, attempt, err)
+
+		if err == nil || attempt >= maxAttempts || !task.Retry.shouldRetry(err) {
+			break
+		}
+
+		// This attempt failed but will be retried: record it as its own
+		// Result (Summary/Reporters never see these, only the authoritative
+		// Result this func eventually returns).
+		priorAttempts = append(priorAttempts, &Result{
+			Task:      attemptTask,
+			Error:     err,
+			Duration:  attemptDuration,
+			StartTime: attemptStart,
+			LogDir:    logDir,
+			Attempts:  1,
+		})
+
+		backoff := task.Retry.backoff(attempt)
+		if e.batchLogger != nil {
+			e.batchLogger.LogTaskRetrying(task, attempt, maxAttempts, err, backoff)
+		}
+		if !showProgress {
+			logrus.Warnf("[Worker %d] Task %s failed (attempt %d/%d), retrying in %s: %v", workerID, task.RunID, attempt, maxAttempts, backoff, err)
+		}
+		if backoff > 0 {
+			time.Sleep(backoff)
+		}
 	}
 
-	wg.Wait()
-	return results
+	// Update progress tracker with the final outcome
+	progress.TaskCompletedWithDuration(task, err, duration)
+
+	finishedRec := telemetry.Record{
+		Type:         telemetry.RecordTaskFinished,
+		Time:         time.Now(),
+		RunID:        task.RunID,
+		ScenarioPath: task.ScenarioPath,
+		Duration:     duration,
+	}
+	if err != nil {
+		finishedRec.Error = err.Error()
+	}
+	if pubErr := e.sink().Publish(ctx, finishedRec); pubErr != nil {
+		logrus.Debugf("[Worker %d] telemetry publish failed: %v", workerID, pubErr)
+	}
+
+	// Log to batch logger
+	if e.batchLogger != nil {
+		e.batchLogger.LogTaskCompleted(task, duration, err, logDir, attemptsUsed)
+	}
+
+	if e.checkpoint != nil {
+		if checkpointErr := e.checkpoint.Record(task, err); checkpointErr != nil {
+			logrus.Warnf("[Worker %d] Task %s: failed to record checkpoint: %v", workerID, task.RunID, checkpointErr)
+		}
+	}
+
+	if !showProgress {
+		if err != nil {
+			logrus.Warnf("[Worker %d] Task %s failed: %v (%.1fs)", workerID, task.RunID, err, duration.Seconds())
+		} else {
+			logrus.Infof("[Worker %d] Task %s completed (%.1fs)", workerID, task.RunID, duration.Seconds())
+		}
+	}
+
+	return &Result{
+		Task:          task,
+		Error:         err,
+		Duration:      duration,
+		StartTime:     startTime,
+		LogDir:        logDir,
+		Attempts:      attemptsUsed,
+		PriorAttempts: priorAttempts,
+	}
 }
 
 // GenerateTasks creates tasks for repeated execution of a scenario.
+// Tasks default to the containerlab/Docker runtime backend; use
+// GenerateTasksWithRuntime to target a different backend (e.g. containerd).
 func GenerateTasks(scenarioPath string, count int, yaml bool) []*Task {
+	return GenerateTasksWithRuntime(scenarioPath, count, yaml, RuntimeContainerlab)
+}
+
+// GenerateTasksWithRuntime creates tasks for repeated execution of a scenario
+// against the given runtime backend.
+func GenerateTasksWithRuntime(scenarioPath string, count int, yaml bool, rt RuntimeKind) []*Task {
 	tasks := make([]*Task, count)
 
 	// Extract scenario name from path
@@ -168,7 +369,9 @@ func GenerateTasks(scenarioPath string, count int, yaml bool) []*Task {
 		tasks[i] = &Task{
 			ScenarioPath: scenarioPath,
 			RunID:        fmt.Sprintf("%s_%03d", scenarioName, i+1),
+			Index:        i + 1,
 			YAML:         yaml,
+			Runtime:      rt,
 		}
 	}
 
@@ -203,8 +406,11 @@ func extractScenarioName(path string) string {
 	return name
 }
 
-// Summary returns a summary of execution results.
-func Summary(results []*Result) (total, succeeded, failed int, totalDuration time.Duration) {
+// Summary returns a summary of execution results. retried counts results
+// that took more than one attempt, whether they ultimately succeeded or
+// failed, so callers can distinguish "failed outright" from "failed despite
+// retrying".
+func Summary(results []*Result) (total, succeeded, failed, retried int, totalDuration time.Duration) {
 	total = len(results)
 	for _, r := range results {
 		if r.Error != nil {
@@ -212,6 +418,9 @@ func Summary(results []*Result) (total, succeeded, failed int, totalDuration tim
 		} else {
 			succeeded++
 		}
+		if r.Attempts > 1 {
+			retried++
+		}
 		totalDuration += r.Duration
 	}
 	return
@@ -219,23 +428,31 @@ func Summary(results []*Result) (total, succeeded, failed int, totalDuration tim
 
 // PrintSummary prints a summary of execution results.
 func PrintSummary(results []*Result) {
-	total, succeeded, failed, totalDuration := Summary(results)
+	fmt.Print(summaryText(results))
+}
+
+// summaryText renders the same report PrintSummary prints, as a string, so
+// TextReporter can produce it without going through stdout directly.
+func summaryText(results []*Result) string {
+	total, succeeded, failed, retried, totalDuration := Summary(results)
 
-	fmt.Println()
-	fmt.Println("========== Execution Summary ==========")
-	fmt.Printf("Total: %d, Succeeded: %d, Failed: %d\n", total, succeeded, failed)
-	fmt.Printf("Total Duration: %s\n", totalDuration.Round(time.Second))
+	var b strings.Builder
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "========== Execution Summary ==========")
+	fmt.Fprintf(&b, "Total: %d, Succeeded: %d, Failed: %d, Retried: %d\n", total, succeeded, failed, retried)
+	fmt.Fprintf(&b, "Total Duration: %s\n", totalDuration.Round(time.Second))
 
 	if failed > 0 {
-		fmt.Println("\nFailed tasks:")
+		fmt.Fprintln(&b, "\nFailed tasks:")
 		for _, r := range results {
 			if r.Error != nil {
-				fmt.Printf("  - %s: %v\n", r.Task.RunID, r.Error)
+				fmt.Fprintf(&b, "  - %s: %v\n", r.Task.RunID, r.Error)
 				if r.LogDir != "" {
-					fmt.Printf("    Log: %s/control.log\n", r.LogDir)
+					fmt.Fprintf(&b, "    Log: %s/control.log\n", r.LogDir)
 				}
 			}
 		}
 	}
-	fmt.Println("========================================")
+	fmt.Fprintln(&b, "========================================")
+	return b.String()
 }
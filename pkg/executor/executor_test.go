@@ -1,6 +1,7 @@
 package executor
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"sync/atomic"
@@ -19,7 +20,7 @@ type mockTaskRunner struct {
 	mu        sync.Mutex
 }
 
-func (m *mockTaskRunner) Run(task *Task) error {
+func (m *mockTaskRunner) Run(ctx context.Context, task *Task) error {
 	atomic.AddInt32(&m.runCount, 1)
 	m.mu.Lock()
 	m.runCalled = append(m.runCalled, task.RunID)
@@ -107,7 +108,7 @@ func TestExecutor_Execute_Sequential(t *testing.T) {
 	tasks := GenerateTasks("test.json", 5, false)
 
 	exec := NewExecutor(1, mock) // Sequential (parallel=1)
-	results := exec.Execute(tasks)
+	results := exec.Execute(context.Background(), tasks)
 
 	assert.Equal(t, 5, len(results))
 	assert.Equal(t, int32(5), mock.runCount)
@@ -124,7 +125,7 @@ func TestExecutor_Execute_Parallel(t *testing.T) {
 
 	exec := NewExecutor(4, mock) // 4 parallel workers
 	start := time.Now()
-	results := exec.Execute(tasks)
+	results := exec.Execute(context.Background(), tasks)
 	duration := time.Since(start)
 
 	assert.Equal(t, 10, len(results))
@@ -142,7 +143,7 @@ func TestExecutor_Execute_WithErrors(t *testing.T) {
 	tasks := GenerateTasks("test.json", 3, false)
 
 	exec := NewExecutor(2, mock)
-	results := exec.Execute(tasks)
+	results := exec.Execute(context.Background(), tasks)
 
 	assert.Equal(t, 3, len(results))
 	for _, r := range results {
@@ -154,15 +155,16 @@ func TestExecutor_Execute_WithErrors(t *testing.T) {
 func TestSummary(t *testing.T) {
 	results := []*Result{
 		{Task: &Task{RunID: "test_001"}, Error: nil, Duration: 10 * time.Second},
-		{Task: &Task{RunID: "test_002"}, Error: errors.New("failed"), Duration: 5 * time.Second},
+		{Task: &Task{RunID: "test_002"}, Error: errors.New("failed"), Duration: 5 * time.Second, Attempts: 3},
 		{Task: &Task{RunID: "test_003"}, Error: nil, Duration: 15 * time.Second},
 	}
 
-	total, succeeded, failed, totalDuration := Summary(results)
+	total, succeeded, failed, retried, totalDuration := Summary(results)
 
 	assert.Equal(t, 3, total)
 	assert.Equal(t, 2, succeeded)
 	assert.Equal(t, 1, failed)
+	assert.Equal(t, 1, retried)
 	assert.Equal(t, 30*time.Second, totalDuration)
 }
 
@@ -0,0 +1,100 @@
+// Package host abstracts where a task's containerlab/docker commands
+// actually run: on this machine (the default) or on a remote machine
+// reached over SSH, so a Plan can spread scenarios across several hosts
+// (see ScenarioEntry.Host and Plan.Hosts in pkg/executor).
+package host
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Config describes one entry in a Plan's "hosts:" block. The zero value (no
+// Address, or Address "local") resolves to LocalHost via New.
+type Config struct {
+	Address string `yaml:"address" json:"address"`
+	User    string `yaml:"user" json:"user"`
+	// IdentityFile and KnownHosts authenticate and verify the remote host
+	// the same way a scenario's ssh event does (see model.Event's
+	// SSHHost/IdentityFile/KnownHosts).
+	IdentityFile  string `yaml:"identityFile" json:"identityFile"`
+	KnownHosts    string `yaml:"knownHosts" json:"knownHosts"`
+	Sudo          bool   `yaml:"sudo" json:"sudo"`
+	DockerContext string `yaml:"dockerContext" json:"dockerContext"`
+	// MaxParallel caps how many tasks this host runs at once across a
+	// whole plan run (0 means no host-specific cap). See
+	// executor.Executor.SetHostLimits.
+	MaxParallel int `yaml:"maxParallel" json:"maxParallel"`
+}
+
+// Host runs commands and copies files for a ScenarioRunner task, either on
+// this machine (LocalHost) or a remote one over SSH (SSHHost). Run/
+// RunDetached match runtime.CommandRunner's signatures exactly, so a Host
+// can be used anywhere a ScenarioRunner currently uses
+// runtime.NewExecRunner().
+type Host interface {
+	// Run executes a command and returns its combined stdout/stderr.
+	// Canceling ctx aborts the command before waiting on it.
+	Run(ctx context.Context, name string, args ...string) ([]byte, error)
+	// RunDetached starts a command without waiting for it to finish.
+	RunDetached(name string, args ...string) error
+	// CopyTo copies localPath (a file) to remotePath on this host, creating
+	// remotePath's parent directory if needed.
+	CopyTo(ctx context.Context, localPath, remotePath string) error
+	// CopyFrom copies remotePath (a file) from this host to localPath,
+	// creating localPath's parent directory if needed.
+	CopyFrom(ctx context.Context, remotePath, localPath string) error
+}
+
+// New resolves cfg into a Host: LocalHost when cfg.Address is empty or
+// "local", otherwise an SSHHost dialed against cfg.Address.
+func New(cfg Config) (Host, error) {
+	if cfg.Address == "" || cfg.Address == "local" {
+		return LocalHost{}, nil
+	}
+	return NewSSHHost(cfg)
+}
+
+// LocalHost runs commands on this machine, the same way runtime.ExecRunner
+// does. It's the Host every task uses unless its ScenarioEntry.Host names
+// an entry in Plan.Hosts.
+type LocalHost struct{}
+
+func (LocalHost) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	return cmd.CombinedOutput()
+}
+
+func (LocalHost) RunDetached(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	return cmd.Start()
+}
+
+func (LocalHost) CopyTo(ctx context.Context, localPath, remotePath string) error {
+	return copyLocalPath(localPath, remotePath)
+}
+
+func (LocalHost) CopyFrom(ctx context.Context, remotePath, localPath string) error {
+	return copyLocalPath(remotePath, localPath)
+}
+
+// copyLocalPath copies a single file from src to dst, creating dst's parent
+// directory if needed. LocalHost only ever copies within one machine, so a
+// plain read/write is enough; SSHHost's CopyTo/CopyFrom is what actually
+// needs to cross a network, and goes over SFTP instead.
+func copyLocalPath(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", src, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(dst), err)
+	}
+	if err := os.WriteFile(dst, data, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", dst, err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package host
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_EmptyOrLocalAddressReturnsLocalHost(t *testing.T) {
+	h, err := New(Config{})
+	require.NoError(t, err)
+	assert.IsType(t, LocalHost{}, h)
+
+	h, err = New(Config{Address: "local"})
+	require.NoError(t, err)
+	assert.IsType(t, LocalHost{}, h)
+}
+
+func TestNew_RemoteAddressWithMissingIdentityFileFails(t *testing.T) {
+	_, err := New(Config{Address: "10.0.0.1", IdentityFile: "/no/such/key"})
+	assert.Error(t, err)
+}
+
+func TestLocalHost_Run(t *testing.T) {
+	h := LocalHost{}
+	out, err := h.Run(context.Background(), "echo", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(out))
+}
+
+func TestLocalHost_CopyToAndFrom_File(t *testing.T) {
+	h := LocalHost{}
+	dir := t.TempDir()
+
+	src := filepath.Join(dir, "src.txt")
+	require.NoError(t, os.WriteFile(src, []byte("payload"), 0644))
+
+	dst := filepath.Join(dir, "nested", "dst.txt")
+	require.NoError(t, h.CopyTo(context.Background(), src, dst))
+
+	data, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(data))
+
+	back := filepath.Join(dir, "back.txt")
+	require.NoError(t, h.CopyFrom(context.Background(), dst, back))
+	data, err = os.ReadFile(back)
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(data))
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'hello'`, shellQuote("hello"))
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+}
+
+func TestParentDir(t *testing.T) {
+	assert.Equal(t, "/remote/dir", parentDir("/remote/dir/file.txt"))
+	assert.Equal(t, ".", parentDir("file.txt"))
+}
@@ -0,0 +1,191 @@
+package host
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// dialTimeout bounds how long NewSSHHost waits for the TCP+SSH handshake.
+const dialTimeout = 15 * time.Second
+
+// SSHHost runs commands on a remote machine reached over SSH, authenticating
+// with cfg.IdentityFile and verifying the remote against cfg.KnownHosts, the
+// same way a scenario's ssh event does (see runtime.SSHClient). Sudo
+// prefixes every command with "sudo ", mirroring runtime.PrivilegeElevator's
+// local equivalent for containerlab/docker commands that need root.
+type SSHHost struct {
+	cfg    Config
+	client *ssh.Client
+	sftp   *sftp.Client
+}
+
+// NewSSHHost dials cfg.Address, authenticating with cfg.IdentityFile and
+// verifying the server against cfg.KnownHosts.
+func NewSSHHost(cfg Config) (*SSHHost, error) {
+	key, err := os.ReadFile(cfg.IdentityFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading identity file %s: %w", cfg.IdentityFile, err)
+	}
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("parsing identity file %s: %w", cfg.IdentityFile, err)
+	}
+
+	hostKeyCallback, err := knownhosts.New(cfg.KnownHosts)
+	if err != nil {
+		return nil, fmt.Errorf("loading known_hosts file %s: %w", cfg.KnownHosts, err)
+	}
+
+	address := cfg.Address
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "22")
+	}
+
+	client, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User:            cfg.User,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         dialTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dialing %s: %w", address, err)
+	}
+
+	sftpClient, err := sftp.NewClient(client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("starting sftp session to %s: %w", address, err)
+	}
+
+	return &SSHHost{cfg: cfg, client: client, sftp: sftpClient}, nil
+}
+
+// Run executes a command over a new SSH session and returns its combined
+// stdout/stderr. Canceling ctx signals the remote process to stop and
+// returns ctx.Err() without waiting further for it to exit.
+func (h *SSHHost) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	session, err := h.client.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("opening ssh session: %w", err)
+	}
+	defer session.Close()
+
+	var out bytes.Buffer
+	session.Stdout = &out
+	session.Stderr = &out
+
+	done := make(chan error, 1)
+	go func() { done <- session.Run(h.commandLine(name, args)) }()
+
+	select {
+	case <-ctx.Done():
+		_ = session.Signal(ssh.SIGKILL)
+		return out.Bytes(), ctx.Err()
+	case err := <-done:
+		return out.Bytes(), err
+	}
+}
+
+// RunDetached starts a command on the remote host without waiting for it to
+// finish, backgrounding it with "&" so it outlives this SSH session the same
+// way exec.Command.Start leaves a local command running after its caller
+// moves on.
+func (h *SSHHost) RunDetached(name string, args ...string) error {
+	session, err := h.client.NewSession()
+	if err != nil {
+		return fmt.Errorf("opening ssh session: %w", err)
+	}
+	defer session.Close()
+
+	if err := session.Start(h.commandLine(name, args) + " >/dev/null 2>&1 &"); err != nil {
+		return fmt.Errorf("starting detached command: %w", err)
+	}
+	return nil
+}
+
+// commandLine quotes name/args into a single shell command line, sudo-
+// prefixed when h.cfg.Sudo is set.
+func (h *SSHHost) commandLine(name string, args []string) string {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, shellQuote(name))
+	for _, a := range args {
+		parts = append(parts, shellQuote(a))
+	}
+	cmd := strings.Join(parts, " ")
+	if h.cfg.Sudo {
+		cmd = "sudo " + cmd
+	}
+	return cmd
+}
+
+// shellQuote wraps s in single quotes for a POSIX shell, escaping any
+// single quotes it contains.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// CopyTo uploads the file at localPath to remotePath on the remote host via
+// SFTP, creating remotePath's parent directory first.
+func (h *SSHHost) CopyTo(ctx context.Context, localPath, remotePath string) error {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", localPath, err)
+	}
+
+	if err := h.sftp.MkdirAll(parentDir(remotePath)); err != nil {
+		return fmt.Errorf("creating %s on remote host: %w", parentDir(remotePath), err)
+	}
+
+	dst, err := h.sftp.Create(remotePath)
+	if err != nil {
+		return fmt.Errorf("creating %s on remote host: %w", remotePath, err)
+	}
+	defer dst.Close()
+
+	if _, err := dst.Write(data); err != nil {
+		return fmt.Errorf("writing %s on remote host: %w", remotePath, err)
+	}
+	return nil
+}
+
+// CopyFrom downloads the file at remotePath on the remote host to localPath
+// via SFTP, creating localPath's parent directory first.
+func (h *SSHHost) CopyFrom(ctx context.Context, remotePath, localPath string) error {
+	src, err := h.sftp.Open(remotePath)
+	if err != nil {
+		return fmt.Errorf("opening %s on remote host: %w", remotePath, err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return fmt.Errorf("reading %s on remote host: %w", remotePath, err)
+	}
+
+	if err := os.MkdirAll(parentDir(localPath), 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", parentDir(localPath), err)
+	}
+	if err := os.WriteFile(localPath, data, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", localPath, err)
+	}
+	return nil
+}
+
+// parentDir returns path's parent directory, "." if path has no slash.
+func parentDir(path string) string {
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 {
+		return "."
+	}
+	return path[:idx]
+}
@@ -0,0 +1,198 @@
+// Package livelog streams a running task's log as it's written, so a user
+// can follow a batch run's scenario over HTTP instead of guessing its
+// control.log path and tailing it locally.
+package livelog
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DefaultTailBytes bounds how much of a Stream's history a late subscriber
+// receives before it starts following new writes.
+const DefaultTailBytes = 64 * 1024
+
+// Stream is a mutex-protected io.Writer that fans every write out to any
+// number of concurrent subscribers, keeping the last DefaultTailBytes as a
+// tail buffer for subscribers that join after the task has started. The
+// mutex is what lets concurrent logrus writes and HTTP streaming coexist
+// without racing on the tail buffer or the subscriber list.
+type Stream struct {
+	mu     sync.Mutex
+	tail   []byte
+	subs   []chan []byte
+	closed bool
+}
+
+// NewStream returns an empty Stream ready to write to and subscribe to.
+func NewStream() *Stream {
+	return &Stream{}
+}
+
+// Write implements io.Writer: it appends p to the tail buffer and fans it
+// out to every subscriber. A subscriber whose buffered channel is already
+// full (a slow reader) misses this chunk rather than blocking the writer.
+// Writes after Close are silently dropped, like writing to a closed log.
+func (s *Stream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return len(p), nil
+	}
+
+	s.tail = append(s.tail, p...)
+	if excess := len(s.tail) - DefaultTailBytes; excess > 0 {
+		s.tail = s.tail[excess:]
+	}
+
+	for _, ch := range s.subs {
+		select {
+		case ch <- append([]byte(nil), p...):
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// Subscribe registers a new reader and returns the current tail buffer
+// (everything Write has kept so far) plus a channel that receives every
+// subsequent Write. Callers must Unsubscribe when done.
+func (s *Stream) Subscribe() ([]byte, chan []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch := make(chan []byte, 16)
+	if !s.closed {
+		s.subs = append(s.subs, ch)
+	} else {
+		close(ch)
+	}
+	return append([]byte(nil), s.tail...), ch
+}
+
+// Unsubscribe removes and closes a subscriber channel previously returned by
+// Subscribe.
+func (s *Stream) Unsubscribe(ch chan []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, c := range s.subs {
+		if c == ch {
+			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			close(c)
+			return
+		}
+	}
+}
+
+// Close marks the stream finished: further Writes are dropped, and every
+// subscriber's channel is closed so its HTTP handler returns.
+func (s *Stream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+	for _, ch := range s.subs {
+		close(ch)
+	}
+	s.subs = nil
+}
+
+// Registry fans a batch's per-task log streams out over HTTP: each
+// RegisterTask'd run gets its own chunked GET /runs/{run_id}/log endpoint
+// until UnregisterTask closes it.
+type Registry struct {
+	mu      sync.Mutex
+	streams map[string]*Stream
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{streams: make(map[string]*Stream)}
+}
+
+// RegisterTask creates (or returns the existing) Stream for runID. Callers
+// write to the returned Stream alongside a task's other log output (e.g.
+// control.log) so GET /runs/{run_id}/log can follow along.
+func (reg *Registry) RegisterTask(runID string) *Stream {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	s, ok := reg.streams[runID]
+	if !ok {
+		s = NewStream()
+		reg.streams[runID] = s
+	}
+	return s
+}
+
+// UnregisterTask closes runID's Stream, ending every subscriber's request,
+// and removes it from the registry.
+func (reg *Registry) UnregisterTask(runID string) {
+	reg.mu.Lock()
+	s, ok := reg.streams[runID]
+	delete(reg.streams, runID)
+	reg.mu.Unlock()
+
+	if ok {
+		s.Close()
+	}
+}
+
+// Handler returns an http.Handler serving GET /runs/{run_id}/log: the
+// stream's tail buffer followed by a chunked stream of everything
+// subsequently written to it, until the task completes (UnregisterTask) or
+// the client disconnects.
+func (reg *Registry) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/runs/", reg.handleLog)
+	return mux
+}
+
+func (reg *Registry) handleLog(w http.ResponseWriter, r *http.Request) {
+	runID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/runs/"), "/log")
+	if runID == "" || runID == r.URL.Path {
+		http.NotFound(w, r)
+		return
+	}
+
+	reg.mu.Lock()
+	s, ok := reg.streams[runID]
+	reg.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	flusher, canFlush := w.(http.Flusher)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Transfer-Encoding", "chunked")
+
+	tail, ch := s.Subscribe()
+	defer s.Unsubscribe(ch)
+
+	w.Write(tail)
+	if canFlush {
+		flusher.Flush()
+	}
+
+	for {
+		select {
+		case chunk, ok := <-ch:
+			if !ok {
+				return
+			}
+			w.Write(chunk)
+			if canFlush {
+				flusher.Flush()
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
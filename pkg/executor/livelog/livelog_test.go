@@ -0,0 +1,106 @@
+package livelog
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream_SubscribeReceivesTailThenWrites(t *testing.T) {
+	s := NewStream()
+	s.Write([]byte("hello "))
+
+	tail, ch := s.Subscribe()
+	defer s.Unsubscribe(ch)
+	assert.Equal(t, "hello ", string(tail))
+
+	s.Write([]byte("world"))
+	select {
+	case chunk := <-ch:
+		assert.Equal(t, "world", string(chunk))
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for chunk")
+	}
+}
+
+func TestStream_TailBufferIsBounded(t *testing.T) {
+	s := NewStream()
+	for i := 0; i < DefaultTailBytes+100; i++ {
+		s.Write([]byte("x"))
+	}
+
+	tail, ch := s.Subscribe()
+	s.Unsubscribe(ch)
+	assert.LessOrEqual(t, len(tail), DefaultTailBytes)
+}
+
+func TestStream_CloseEndsSubscribers(t *testing.T) {
+	s := NewStream()
+	_, ch := s.Subscribe()
+
+	s.Close()
+
+	_, ok := <-ch
+	assert.False(t, ok, "channel should be closed")
+
+	n, err := s.Write([]byte("dropped"))
+	require.NoError(t, err)
+	assert.Equal(t, len("dropped"), n)
+}
+
+func TestStream_SubscribeAfterCloseReturnsClosedChannel(t *testing.T) {
+	s := NewStream()
+	s.Close()
+
+	_, ch := s.Subscribe()
+	_, ok := <-ch
+	assert.False(t, ok)
+}
+
+func TestRegistry_RegisterTaskReturnsSameStream(t *testing.T) {
+	reg := NewRegistry()
+	s1 := reg.RegisterTask("run-1")
+	s2 := reg.RegisterTask("run-1")
+	assert.Same(t, s1, s2)
+}
+
+func TestRegistry_HandleLog_StreamsChunksAndUnknownRunIs404(t *testing.T) {
+	reg := NewRegistry()
+	s := reg.RegisterTask("run-1")
+	defer reg.UnregisterTask("run-1")
+
+	srv := httptest.NewServer(reg.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/runs/does-not-exist/log")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	s.Write([]byte("line 1\n"))
+
+	resp, err = http.Get(srv.URL + "/runs/run-1/log")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	buf := make([]byte, len("line 1\n"))
+	_, err = io.ReadFull(resp.Body, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "line 1\n", string(buf))
+}
+
+func TestRegistry_UnregisterTaskClosesStream(t *testing.T) {
+	reg := NewRegistry()
+	s := reg.RegisterTask("run-1")
+	_, ch := s.Subscribe()
+
+	reg.UnregisterTask("run-1")
+
+	_, ok := <-ch
+	assert.False(t, ok)
+}
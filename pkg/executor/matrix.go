@@ -0,0 +1,194 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// matrixTmplDir is where expandMatrix writes rendered scenario files.
+const matrixTmplDir = "netroub-matrix"
+
+// expandMatrix renders entry.Pattern (a text/template) once per combination
+// in the cross-product of entry.Matrix's axes, minus entry.Exclude, plus
+// entry.Include, returning one ScenarioEntry per combination with Pattern
+// replaced by the rendered file's path and Params set to that combination.
+// An entry with no Matrix is returned unchanged.
+func expandMatrix(entry ScenarioEntry) ([]ScenarioEntry, error) {
+	if len(entry.Matrix) == 0 {
+		return []ScenarioEntry{entry}, nil
+	}
+
+	combos := matrixCrossProduct(entry.Matrix)
+	combos = excludeCombos(combos, entry.Exclude)
+	combos = appendIncludes(combos, entry.Include)
+
+	tmplContent, err := os.ReadFile(entry.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("reading matrix template %s: %w", entry.Pattern, err)
+	}
+	tmpl, err := template.New(filepath.Base(entry.Pattern)).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("parsing matrix template %s: %w", entry.Pattern, err)
+	}
+
+	outDir := filepath.Join(os.TempDir(), matrixTmplDir)
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating matrix output dir %s: %w", outDir, err)
+	}
+
+	baseName := matrixBaseName(entry.Pattern)
+	ext := ".json"
+	if entry.YAML {
+		ext = ".yaml"
+	}
+
+	expanded := make([]ScenarioEntry, 0, len(combos))
+	for _, combo := range combos {
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, combo); err != nil {
+			return nil, fmt.Errorf("rendering matrix template %s for %v: %w", entry.Pattern, combo, err)
+		}
+
+		renderedPath := filepath.Join(outDir, baseName+"_"+comboSuffix(combo)+ext)
+		if err := os.WriteFile(renderedPath, []byte(buf.String()), 0644); err != nil {
+			return nil, fmt.Errorf("writing rendered matrix scenario %s: %w", renderedPath, err)
+		}
+
+		expanded = append(expanded, ScenarioEntry{
+			Pattern:            renderedPath,
+			Repeat:             entry.Repeat,
+			YAML:               entry.YAML,
+			Runtime:            entry.Runtime,
+			Retry:              entry.Retry,
+			Retries:            entry.Retries,
+			RetryBackoff:       entry.RetryBackoff,
+			RetryBackoffFactor: entry.RetryBackoffFactor,
+			RetryOn:            entry.RetryOn,
+			Host:               entry.Host,
+			Weight:             entry.Weight,
+			Params:             combo,
+		})
+	}
+
+	return expanded, nil
+}
+
+// matrixBaseName strips a template's ".tmpl" suffix (if any) and its
+// remaining extension, e.g. "delay_template.yaml.tmpl" -> "delay_template".
+func matrixBaseName(pattern string) string {
+	name := strings.TrimSuffix(filepath.Base(pattern), ".tmpl")
+	return extractScenarioName(name)
+}
+
+// comboSuffix renders combo's axes (in sorted key order, for determinism)
+// into the RunID suffix netroub uses for matrix scenarios, e.g.
+// {"delay_ms": 10, "loss_pct": 1, "host": "r1"} -> "delay10_r1_loss1".
+// String values are used as-is (they're already self-descriptive); other
+// values are prefixed with the axis name's first "_"-separated segment, so
+// "delay_ms" becomes the "delay" in "delay10".
+func comboSuffix(combo map[string]any) string {
+	keys := matrixSortedKeys(combo)
+	tokens := make([]string, len(keys))
+	for i, key := range keys {
+		value := combo[key]
+		if s, ok := value.(string); ok {
+			tokens[i] = s
+			continue
+		}
+		label, _, _ := strings.Cut(key, "_")
+		tokens[i] = fmt.Sprintf("%s%v", label, value)
+	}
+	return strings.Join(tokens, "_")
+}
+
+// matrixSortedKeys returns m's keys sorted alphabetically, so matrix
+// expansion and RunID generation don't depend on Go's randomized map
+// iteration order.
+func matrixSortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// matrixCrossProduct returns every combination of one value per axis in
+// matrix, in the deterministic order produced by iterating axes in sorted
+// key order.
+func matrixCrossProduct(matrix map[string][]any) []map[string]any {
+	keys := matrixSortedKeys(matrix)
+	combos := []map[string]any{{}}
+
+	for _, key := range keys {
+		var next []map[string]any
+		for _, combo := range combos {
+			for _, value := range matrix[key] {
+				extended := make(map[string]any, len(combo)+1)
+				for k, v := range combo {
+					extended[k] = v
+				}
+				extended[key] = value
+				next = append(next, extended)
+			}
+		}
+		combos = next
+	}
+
+	return combos
+}
+
+// excludeCombos drops any combo matching every key/value pair of at least
+// one entry in exclude.
+func excludeCombos(combos []map[string]any, exclude []map[string]any) []map[string]any {
+	if len(exclude) == 0 {
+		return combos
+	}
+
+	var kept []map[string]any
+	for _, combo := range combos {
+		excluded := false
+		for _, ex := range exclude {
+			if comboMatches(combo, ex) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			kept = append(kept, combo)
+		}
+	}
+	return kept
+}
+
+// appendIncludes appends any include entry not already present in combos.
+func appendIncludes(combos []map[string]any, include []map[string]any) []map[string]any {
+	for _, inc := range include {
+		alreadyPresent := false
+		for _, combo := range combos {
+			if comboMatches(combo, inc) && comboMatches(inc, combo) {
+				alreadyPresent = true
+				break
+			}
+		}
+		if !alreadyPresent {
+			combos = append(combos, inc)
+		}
+	}
+	return combos
+}
+
+// comboMatches reports whether combo agrees with every key/value pair in
+// filter (combo may have additional keys filter doesn't mention).
+func comboMatches(combo, filter map[string]any) bool {
+	for k, v := range filter {
+		if combo[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandMatrix(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmplPath := filepath.Join(tmpDir, "delay_template.yaml.tmpl")
+	require.NoError(t, os.WriteFile(tmplPath, []byte("delayMs: {{.delay_ms}}\nlossPct: {{.loss_pct}}\nhost: {{.host}}\n"), 0644))
+
+	entry := ScenarioEntry{
+		Pattern: tmplPath,
+		Repeat:  5,
+		YAML:    true,
+		Matrix: map[string][]any{
+			"delay_ms": {10, 50},
+			"loss_pct": {0, 1},
+			"host":     {"r1", "r2"},
+		},
+	}
+
+	expanded, err := expandMatrix(entry)
+	require.NoError(t, err)
+	require.Len(t, expanded, 8) // 2 * 2 * 2
+
+	var runIDSuffixes []string
+	for _, e := range expanded {
+		assert.Equal(t, 5, e.Repeat)
+		assert.True(t, e.YAML)
+		assert.NotNil(t, e.Params)
+		runIDSuffixes = append(runIDSuffixes, extractScenarioName(e.Pattern))
+
+		data, err := os.ReadFile(e.Pattern)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "delayMs:")
+	}
+
+	assert.Contains(t, runIDSuffixes, "delay_template_delay10_r1_loss0")
+	assert.Contains(t, runIDSuffixes, "delay_template_delay50_r2_loss1")
+}
+
+func TestExpandMatrix_NoMatrixReturnsUnchanged(t *testing.T) {
+	entry := ScenarioEntry{Pattern: "baseline.json", Repeat: 3}
+	expanded, err := expandMatrix(entry)
+	require.NoError(t, err)
+	require.Len(t, expanded, 1)
+	assert.Equal(t, entry, expanded[0])
+}
+
+func TestExpandMatrix_Exclude(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmplPath := filepath.Join(tmpDir, "t.yaml.tmpl")
+	require.NoError(t, os.WriteFile(tmplPath, []byte("x: {{.x}}\n"), 0644))
+
+	entry := ScenarioEntry{
+		Pattern: tmplPath,
+		Repeat:  1,
+		YAML:    true,
+		Matrix:  map[string][]any{"x": {1, 2, 3}},
+		Exclude: []map[string]any{{"x": 2}},
+	}
+
+	expanded, err := expandMatrix(entry)
+	require.NoError(t, err)
+	require.Len(t, expanded, 2)
+	for _, e := range expanded {
+		assert.NotEqual(t, 2, e.Params["x"])
+	}
+}
+
+func TestExpandMatrix_Include(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmplPath := filepath.Join(tmpDir, "t.yaml.tmpl")
+	require.NoError(t, os.WriteFile(tmplPath, []byte("x: {{.x}}\ny: {{.y}}\n"), 0644))
+
+	entry := ScenarioEntry{
+		Pattern: tmplPath,
+		Repeat:  1,
+		YAML:    true,
+		Matrix:  map[string][]any{"x": {1, 2}, "y": {"a"}},
+		Include: []map[string]any{{"x": 99, "y": "b"}},
+	}
+
+	expanded, err := expandMatrix(entry)
+	require.NoError(t, err)
+	require.Len(t, expanded, 3) // 2 from cross product + 1 ad-hoc include
+
+	var found bool
+	for _, e := range expanded {
+		if e.Params["x"] == 99 && e.Params["y"] == "b" {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestComboSuffix(t *testing.T) {
+	suffix := comboSuffix(map[string]any{"delay_ms": 10, "loss_pct": 1, "host": "r1"})
+	assert.Equal(t, "delay10_r1_loss1", suffix)
+}
+
+func TestGenerateTasksFromPlan_Matrix(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmplPath := filepath.Join(tmpDir, "delay_template.yaml.tmpl")
+	require.NoError(t, os.WriteFile(tmplPath, []byte("delayMs: {{.delay_ms}}\n"), 0644))
+
+	plan := &Plan{
+		Scenarios: []ScenarioEntry{
+			{
+				Pattern: "delay_template.yaml.tmpl",
+				Repeat:  2,
+				YAML:    true,
+				Runtime: DefaultRuntimeKind,
+				Matrix:  map[string][]any{"delay_ms": {10, 50}},
+			},
+		},
+	}
+
+	tasks, err := GenerateTasksFromPlan(plan, tmpDir)
+	require.NoError(t, err)
+	require.Len(t, tasks, 4) // 2 combos * repeat 2
+
+	for _, task := range tasks {
+		assert.NotNil(t, task.Params)
+	}
+}
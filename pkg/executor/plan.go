@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/3atlab/netroub/pkg/executor/host"
 )
 
 // FileType represents the type of a netroub configuration file.
@@ -23,6 +25,33 @@ const (
 type Plan struct {
 	Parallel  int             `yaml:"parallel" json:"parallel"`
 	Scenarios []ScenarioEntry `yaml:"scenarios" json:"scenarios"`
+	// CheckpointPath, if set, is where GenerateTasksFromPlanWithResume reads
+	// and Executor.SetCheckpoint's Checkpoint records completed RunIDs, so a
+	// long-running plan can be interrupted and resumed without repeating
+	// already-successful tasks.
+	CheckpointPath string `yaml:"checkpointPath" json:"checkpointPath"`
+	// Schedule selects how expanded scenarios become tasks: "sequential"
+	// (default), "shuffled", "weighted", or "time_budget". See ScheduleKind.
+	Schedule ScheduleKind `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+	// Seed drives the schedule's RNG (Fisher-Yates shuffle order for
+	// ScheduleShuffled, sampling for ScheduleWeighted/ScheduleTimeBudget), so
+	// the same plan always schedules the same way. Its zero value is a
+	// valid, reproducible seed, not "unset".
+	Seed int64 `yaml:"seed,omitempty" json:"seed,omitempty"`
+	// TimeBudget is the wall-clock duration (e.g. "4h") ScheduleTimeBudget
+	// keeps sampling scenarios for, via GenerateTaskIteratorFromPlan. Unused
+	// by every other schedule.
+	TimeBudget string `yaml:"timeBudget,omitempty" json:"timeBudget,omitempty"`
+	// Retries and RetryBackoff are plan-wide defaults for ScenarioEntry's
+	// Retries/RetryBackoff, for entries that don't set their own (and don't
+	// set Retry directly). See effectiveRetry.
+	Retries      int    `yaml:"retries,omitempty" json:"retries,omitempty"`
+	RetryBackoff string `yaml:"retryBackoff,omitempty" json:"retryBackoff,omitempty"`
+	// Hosts names the machines a ScenarioEntry.Host can target, so a plan's
+	// scenarios can be spread across several hosts instead of all running
+	// on this machine. An entry with no Host (or naming an unlisted host)
+	// runs locally.
+	Hosts map[string]host.Config `yaml:"hosts,omitempty" json:"hosts,omitempty"`
 }
 
 // ScenarioEntry represents a single scenario entry in the plan.
@@ -30,6 +59,56 @@ type ScenarioEntry struct {
 	Pattern string `yaml:"pattern" json:"pattern"` // File path or glob pattern
 	Repeat  int    `yaml:"repeat" json:"repeat"`   // Number of repetitions
 	YAML    bool   `yaml:"yaml" json:"yaml"`       // Use YAML format (default: false, JSON)
+	// Runtime selects the container runtime backend ("containerlab" or
+	// "containerd"). Defaults to RuntimeContainerlab when empty.
+	Runtime RuntimeKind `yaml:"runtime" json:"runtime"`
+	// Retry overrides the retry/backoff policy for this scenario's tasks.
+	// Nil means no retries, unless Retries (or Plan.Retries) is set; see
+	// effectiveRetry. Set this directly only when Retries' simpler knobs
+	// (a count, a backoff duration/factor, and regex filters) aren't enough,
+	// e.g. to supply a custom RetryableErrors func or Jitter/MaxBackoff.
+	Retry *RetryPolicy `yaml:"retry,omitempty" json:"retry,omitempty"`
+	// Retries is the number of retry attempts (beyond the first) on
+	// failure, a flatter YAML/JSON-friendly alternative to Retry. 0 (the
+	// default) falls back to Plan.Retries; still 0 means no retries.
+	// Ignored when Retry is set.
+	Retries int `yaml:"retries,omitempty" json:"retries,omitempty"`
+	// RetryBackoff is the delay (e.g. "2s") before the first retry, growing
+	// by RetryBackoffFactor each subsequent attempt. Empty falls back to
+	// Plan.RetryBackoff, then to 1s.
+	RetryBackoff string `yaml:"retryBackoff,omitempty" json:"retryBackoff,omitempty"`
+	// RetryBackoffFactor is RetryBackoff's growth per attempt. <= 0
+	// defaults to 2.
+	RetryBackoffFactor float64 `yaml:"retryBackoffFactor,omitempty" json:"retryBackoffFactor,omitempty"`
+	// RetryOn restricts retries to errors whose message matches one of
+	// these regexes. Empty (the default) retries whatever defaultRetryable
+	// treats as transient.
+	RetryOn []string `yaml:"retryOn,omitempty" json:"retryOn,omitempty"`
+	// Host names an entry in Plan.Hosts whose containerlab/docker commands
+	// this scenario's tasks should run through, instead of this machine.
+	// Empty (the default) runs locally.
+	Host string `yaml:"host,omitempty" json:"host,omitempty"`
+	// Weight is this entry's sampling weight under Plan.Schedule
+	// ScheduleWeighted/ScheduleTimeBudget: entries are sampled with
+	// replacement proportional to Weight (default 1 when unset or <= 0).
+	// Ignored by ScheduleSequential/ScheduleShuffled.
+	Weight float64 `yaml:"weight,omitempty" json:"weight,omitempty"`
+	// Matrix, if non-empty, turns Pattern into a text/template rendered once
+	// per combination in the cross-product of its axes (see expandMatrix),
+	// instead of a scenario file expanded as-is.
+	Matrix map[string][]any `yaml:"matrix,omitempty" json:"matrix,omitempty"`
+	// Exclude drops specific combinations out of Matrix's cross-product. An
+	// exclude tuple need only set the axes it wants to match; a combination
+	// matching every key/value pair in any Exclude entry is dropped.
+	Exclude []map[string]any `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+	// Include adds ad-hoc combinations outside Matrix's cross-product. Each
+	// entry must set every axis in Matrix. A combination already produced by
+	// the cross-product (and not excluded) is not duplicated.
+	Include []map[string]any `yaml:"include,omitempty" json:"include,omitempty"`
+	// Params is the effective matrix parameter assignment for an expanded
+	// entry, populated by expandMatrix and copied onto its generated Tasks.
+	// Nil for non-matrix entries. Not set directly in a plan file.
+	Params map[string]any `yaml:"-" json:"-"`
 }
 
 // DetectFileType detects whether a file is a Plan or Scenario based on its content.
@@ -104,21 +183,33 @@ func LoadPlan(path string) (*Plan, error) {
 		if plan.Scenarios[i].Repeat < 1 {
 			plan.Scenarios[i].Repeat = 1
 		}
+		if plan.Scenarios[i].Runtime == "" {
+			plan.Scenarios[i].Runtime = DefaultRuntimeKind
+		}
 	}
 
 	return &plan, nil
 }
 
 // ExpandScenarios expands glob patterns in the plan and returns all matching files.
-// The baseDir is used as the base directory for relative patterns.
+// The baseDir is used as the base directory for relative patterns. A
+// pattern recognized by ParseSourceSpec (e.g. "git+https://...",
+// "https://....tar.gz#*.json") is first materialized into a local cache dir
+// via the matching SourceFetcher, and the glob is then applied there.
 func (p *Plan) ExpandScenarios(baseDir string) ([]ScenarioEntry, error) {
 	var expanded []ScenarioEntry
 
 	for _, entry := range p.Scenarios {
 		pattern := entry.Pattern
 
-		// Make pattern absolute if relative
-		if !filepath.IsAbs(pattern) {
+		if spec, ok := ParseSourceSpec(pattern); ok {
+			localDir, err := fetchSource(spec)
+			if err != nil {
+				return nil, fmt.Errorf("fetching remote source %q: %w", entry.Pattern, err)
+			}
+			pattern = filepath.Join(localDir, spec.SubPath)
+		} else if !filepath.IsAbs(pattern) {
+			// Make pattern absolute if relative
 			pattern = filepath.Join(baseDir, pattern)
 		}
 
@@ -131,18 +222,38 @@ func (p *Plan) ExpandScenarios(baseDir string) ([]ScenarioEntry, error) {
 		if len(matches) == 0 {
 			// If no matches and pattern contains no wildcards, treat as literal path
 			if !containsGlobChar(entry.Pattern) {
-				expanded = append(expanded, entry)
+				combos, err := expandMatrix(entry)
+				if err != nil {
+					return nil, err
+				}
+				expanded = append(expanded, combos...)
 			} else {
 				return nil, fmt.Errorf("no files match pattern %q", entry.Pattern)
 			}
 		} else {
 			// Create an entry for each matched file
 			for _, match := range matches {
-				expanded = append(expanded, ScenarioEntry{
-					Pattern: match,
-					Repeat:  entry.Repeat,
-					YAML:    entry.YAML,
-				})
+				matched := ScenarioEntry{
+					Pattern:            match,
+					Repeat:             entry.Repeat,
+					YAML:               entry.YAML,
+					Runtime:            entry.Runtime,
+					Retry:              entry.Retry,
+					Retries:            entry.Retries,
+					RetryBackoff:       entry.RetryBackoff,
+					RetryBackoffFactor: entry.RetryBackoffFactor,
+					RetryOn:            entry.RetryOn,
+					Host:               entry.Host,
+					Weight:             entry.Weight,
+					Matrix:             entry.Matrix,
+					Exclude:            entry.Exclude,
+					Include:            entry.Include,
+				}
+				combos, err := expandMatrix(matched)
+				if err != nil {
+					return nil, err
+				}
+				expanded = append(expanded, combos...)
 			}
 		}
 	}
@@ -150,21 +261,87 @@ func (p *Plan) ExpandScenarios(baseDir string) ([]ScenarioEntry, error) {
 	return expanded, nil
 }
 
-// GenerateTasksFromPlan generates tasks from a plan.
-// Returns all tasks for all scenarios with their repetitions.
+// GenerateTasksFromPlan generates tasks from a plan, ordered and sampled per
+// plan.Schedule (see ScheduleKind). ScheduleTimeBudget's task stream is
+// unbounded and isn't supported here; use GenerateTaskIteratorFromPlan for
+// it instead.
 func GenerateTasksFromPlan(plan *Plan, baseDir string) ([]*Task, error) {
 	expanded, err := plan.ExpandScenarios(baseDir)
 	if err != nil {
 		return nil, err
 	}
+	return scheduleTasks(plan, expanded)
+}
 
-	var allTasks []*Task
-	for _, entry := range expanded {
-		tasks := GenerateTasks(entry.Pattern, entry.Repeat, entry.YAML)
-		allTasks = append(allTasks, tasks...)
+// GenerateTasksFromPlanWithResume is GenerateTasksFromPlan with the result
+// filtered through plan.CheckpointPath's recorded outcomes per mode
+// (ResumeNone runs everything, same as GenerateTasksFromPlan). It also
+// returns the opened Checkpoint, nil if plan.CheckpointPath is empty, so the
+// caller can pass it to Executor.SetCheckpoint to keep recording outcomes as
+// the returned tasks run.
+func GenerateTasksFromPlanWithResume(plan *Plan, baseDir string, mode ResumeMode) ([]*Task, *Checkpoint, error) {
+	tasks, err := GenerateTasksFromPlan(plan, baseDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if plan.CheckpointPath == "" {
+		return tasks, nil, nil
 	}
 
-	return allTasks, nil
+	checkpoint, err := OpenCheckpoint(plan.CheckpointPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return FilterTasks(tasks, checkpoint, mode), checkpoint, nil
+}
+
+// ScenarioStatus summarizes a checkpoint's recorded outcomes for one
+// ScenarioEntry's expanded tasks, as reported by PlanStatus.
+type ScenarioStatus struct {
+	Pattern   string
+	Total     int
+	Completed int
+	Failed    int
+	Pending   int
+}
+
+// PlanStatus re-expands plan (the same way GenerateTasksFromPlan does) and
+// tallies each scenario pattern's tasks against checkpointPath's recorded
+// outcomes, for the `netroub plan status` command. Not supported for
+// schedule "time_budget", whose total task count isn't known in advance.
+func PlanStatus(plan *Plan, baseDir, checkpointPath string) ([]ScenarioStatus, error) {
+	if plan.Schedule == ScheduleTimeBudget {
+		return nil, fmt.Errorf("plan status is not supported for schedule %q (task count is unbounded)", plan.Schedule)
+	}
+
+	entries, err := LoadCheckpoint(checkpointPath)
+	if err != nil {
+		return nil, err
+	}
+	statuses := latestStatuses(entries)
+
+	var result []ScenarioStatus
+	for _, scenarioEntry := range plan.Scenarios {
+		single := &Plan{Scenarios: []ScenarioEntry{scenarioEntry}, Schedule: plan.Schedule, Seed: plan.Seed, Retries: plan.Retries, RetryBackoff: plan.RetryBackoff, Hosts: plan.Hosts}
+		tasks, err := GenerateTasksFromPlan(single, baseDir)
+		if err != nil {
+			return nil, err
+		}
+
+		status := ScenarioStatus{Pattern: scenarioEntry.Pattern, Total: len(tasks)}
+		for _, task := range tasks {
+			switch statuses[task.RunID] {
+			case CheckpointStatusSucceeded:
+				status.Completed++
+			case CheckpointStatusFailed:
+				status.Failed++
+			default:
+				status.Pending++
+			}
+		}
+		result = append(result, status)
+	}
+	return result, nil
 }
 
 // containsGlobChar checks if the pattern contains glob special characters.
@@ -177,9 +354,21 @@ func containsGlobChar(pattern string) bool {
 	return false
 }
 
-// PlanSummary returns a summary of the plan.
+// PlanSummary returns a summary of the plan: its scenario count and its
+// total run count. For ScheduleSequential, ScheduleShuffled, and
+// ScheduleWeighted, totalRuns is the sum of every entry's Repeat
+// (ScheduleWeighted still treats each entry's Repeat as a cap on the overall
+// total, just not on that entry individually). Entries are counted as
+// written, before glob and matrix expansion, so an entry whose pattern
+// matches several files or whose Matrix has several combinations still
+// adds its Repeat only once. For ScheduleTimeBudget the total isn't known in advance
+// (sampling continues until Plan.TimeBudget elapses, not until a count is
+// reached), so totalRuns is reported as -1.
 func (p *Plan) Summary() (scenarios, totalRuns int) {
 	scenarios = len(p.Scenarios)
+	if p.Schedule == ScheduleTimeBudget {
+		return scenarios, -1
+	}
 	for _, entry := range p.Scenarios {
 		totalRuns += entry.Repeat
 	}
@@ -17,10 +17,15 @@ type ProgressTracker struct {
 	failed      int
 	startTime   time.Time
 	taskResults map[string]*taskProgress // Track per-scenario progress
+	taskOrder   []string                 // RunIDs in creation order, for stable /api/tasks output
+	taskStatus  map[string]*taskState    // Per-task state keyed by RunID, for /api/tasks and ServeStatus
 	output      io.Writer
 	updateCh    chan struct{}
 	doneCh      chan struct{}
 	enabled     bool
+
+	subsMu sync.Mutex
+	subs   []chan ProgressSnapshot
 }
 
 // taskProgress tracks progress for a single scenario (all its repetitions).
@@ -31,11 +36,23 @@ type taskProgress struct {
 	failed       int
 }
 
+// taskState tracks the status of a single task, surfaced by the HTTP status
+// endpoint (see progress_http.go).
+type taskState struct {
+	task     *Task
+	status   string // "pending", "retrying", "completed" or "failed"
+	err      error
+	duration time.Duration
+	attempts int
+}
+
 // NewProgressTracker creates a new progress tracker.
 func NewProgressTracker(tasks []*Task, enabled bool) *ProgressTracker {
 	pt := &ProgressTracker{
 		total:       len(tasks),
 		taskResults: make(map[string]*taskProgress),
+		taskOrder:   make([]string, 0, len(tasks)),
+		taskStatus:  make(map[string]*taskState, len(tasks)),
 		output:      os.Stdout,
 		updateCh:    make(chan struct{}, 100),
 		doneCh:      make(chan struct{}),
@@ -51,6 +68,9 @@ func NewProgressTracker(tasks []*Task, enabled bool) *ProgressTracker {
 			}
 		}
 		pt.taskResults[scenarioName].total++
+
+		pt.taskOrder = append(pt.taskOrder, task.RunID)
+		pt.taskStatus[task.RunID] = &taskState{task: task, status: "pending"}
 	}
 
 	return pt
@@ -64,10 +84,40 @@ func (pt *ProgressTracker) Start() {
 	}
 }
 
+// TaskAttempted records one attempt at task, successful or not. The
+// executor calls this for every attempt (including retries), while
+// TaskCompletedWithDuration is only called once the task has its final
+// outcome. A failing attempt marks the task "retrying" until that happens.
+func (pt *ProgressTracker) TaskAttempted(task *Task, attempt int, err error) {
+	pt.mu.Lock()
+	ts, exists := pt.taskStatus[task.RunID]
+	if !exists {
+		pt.mu.Unlock()
+		return
+	}
+
+	ts.attempts = attempt
+	if err != nil {
+		ts.status = "retrying"
+		ts.err = err
+	}
+	snap := pt.snapshotLocked()
+	pt.mu.Unlock()
+
+	pt.broadcast(snap)
+}
+
 // TaskCompleted records a completed task.
 func (pt *ProgressTracker) TaskCompleted(task *Task, err error) {
+	pt.TaskCompletedWithDuration(task, err, 0)
+}
+
+// TaskCompletedWithDuration records a completed task along with how long it
+// took, so the HTTP status endpoint (see progress_http.go) can report
+// per-task durations. Executor.ExecuteWithProgress uses this; TaskCompleted
+// remains for callers (and tests) that don't track duration.
+func (pt *ProgressTracker) TaskCompletedWithDuration(task *Task, err error, duration time.Duration) {
 	pt.mu.Lock()
-	defer pt.mu.Unlock()
 
 	pt.completed++
 	scenarioName := extractScenarioName(task.ScenarioPath)
@@ -79,6 +129,18 @@ func (pt *ProgressTracker) TaskCompleted(task *Task, err error) {
 		}
 	}
 
+	if ts, exists := pt.taskStatus[task.RunID]; exists {
+		ts.duration = duration
+		ts.err = err
+		if err != nil {
+			ts.status = "failed"
+		} else {
+			ts.status = "completed"
+		}
+	}
+
+	snap := pt.snapshotLocked()
+
 	if pt.enabled {
 		// Print failure immediately
 		if err != nil {
@@ -90,6 +152,10 @@ func (pt *ProgressTracker) TaskCompleted(task *Task, err error) {
 		default:
 		}
 	}
+
+	pt.mu.Unlock()
+
+	pt.broadcast(snap)
 }
 
 // Stop stops the progress display.
@@ -0,0 +1,232 @@
+package executor
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+//go:embed static/index.html
+var statusHTML embed.FS
+
+// ProgressSnapshot is the JSON shape returned by GET /api/progress and
+// pushed over GET /api/events.
+type ProgressSnapshot struct {
+	Total       int                `json:"total"`
+	Completed   int                `json:"completed"`
+	Failed      int                `json:"failed"`
+	Elapsed     string             `json:"elapsed"`
+	ETA         string             `json:"eta"`
+	PerScenario []ScenarioSnapshot `json:"per_scenario"`
+}
+
+// ScenarioSnapshot is the per-scenario breakdown within a ProgressSnapshot.
+type ScenarioSnapshot struct {
+	Name      string `json:"name"`
+	Total     int    `json:"total"`
+	Completed int    `json:"completed"`
+	Failed    int    `json:"failed"`
+}
+
+// TaskSnapshot is the JSON shape of one entry in GET /api/tasks.
+type TaskSnapshot struct {
+	RunID      string `json:"run_id"`
+	Scenario   string `json:"scenario"`
+	Status     string `json:"status"` // "pending", "retrying", "completed" or "failed"
+	Attempts   int    `json:"attempts"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Snapshot returns the current progress as a JSON-serializable snapshot.
+func (pt *ProgressTracker) Snapshot() ProgressSnapshot {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	return pt.snapshotLocked()
+}
+
+// snapshotLocked builds a ProgressSnapshot. Callers must hold pt.mu.
+func (pt *ProgressTracker) snapshotLocked() ProgressSnapshot {
+	elapsed := time.Since(pt.startTime)
+	eta := pt.calculateETA(elapsed)
+
+	perScenario := make([]ScenarioSnapshot, 0, len(pt.taskResults))
+	for _, tp := range pt.taskResults {
+		perScenario = append(perScenario, ScenarioSnapshot{
+			Name:      tp.scenarioName,
+			Total:     tp.total,
+			Completed: tp.completed,
+			Failed:    tp.failed,
+		})
+	}
+
+	return ProgressSnapshot{
+		Total:       pt.total,
+		Completed:   pt.completed,
+		Failed:      pt.failed,
+		Elapsed:     formatDuration(elapsed),
+		ETA:         formatDuration(eta),
+		PerScenario: perScenario,
+	}
+}
+
+// TaskSnapshots returns the status of every task, in the order they were
+// generated, for GET /api/tasks.
+func (pt *ProgressTracker) TaskSnapshots() []TaskSnapshot {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	snaps := make([]TaskSnapshot, 0, len(pt.taskOrder))
+	for _, runID := range pt.taskOrder {
+		ts, exists := pt.taskStatus[runID]
+		if !exists {
+			continue
+		}
+		snap := TaskSnapshot{
+			RunID:      runID,
+			Scenario:   extractScenarioName(ts.task.ScenarioPath),
+			Status:     ts.status,
+			Attempts:   ts.attempts,
+			DurationMs: ts.duration.Milliseconds(),
+		}
+		if ts.err != nil {
+			snap.Error = ts.err.Error()
+		}
+		snaps = append(snaps, snap)
+	}
+	return snaps
+}
+
+// Handler returns an http.Handler exposing this tracker's progress as JSON
+// (/api/progress, /api/tasks), as an SSE stream (/api/events), and as a
+// minimal live-updating HTML page ("/").
+func (pt *ProgressTracker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/progress", pt.handleProgress)
+	mux.HandleFunc("/api/tasks", pt.handleTasks)
+	mux.HandleFunc("/api/events", pt.handleEvents)
+	mux.HandleFunc("/", pt.handleIndex)
+	return mux
+}
+
+// ServeStatus starts an HTTP server on addr exposing Handler, so operators
+// can point a browser or `curl -N` at a running batch. It returns
+// immediately; call Shutdown on the returned server once the batch
+// completes.
+func (pt *ProgressTracker) ServeStatus(addr string) (*http.Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	srv := &http.Server{Handler: pt.Handler()}
+	go func() {
+		if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+			logrus.Warnf("progress status server stopped: %v", err)
+		}
+	}()
+
+	return srv, nil
+}
+
+func (pt *ProgressTracker) handleProgress(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pt.Snapshot())
+}
+
+func (pt *ProgressTracker) handleTasks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pt.TaskSnapshots())
+}
+
+func (pt *ProgressTracker) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := pt.subscribe()
+	defer pt.unsubscribe(ch)
+
+	writeSSE(w, pt.Snapshot())
+	flusher.Flush()
+
+	for {
+		select {
+		case snap, ok := <-ch:
+			if !ok {
+				return
+			}
+			writeSSE(w, snap)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (pt *ProgressTracker) handleIndex(w http.ResponseWriter, r *http.Request) {
+	data, err := statusHTML.ReadFile("static/index.html")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(data)
+}
+
+func writeSSE(w http.ResponseWriter, snap ProgressSnapshot) {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
+
+// subscribe registers a new SSE client and returns the channel it should
+// read snapshots from.
+func (pt *ProgressTracker) subscribe() chan ProgressSnapshot {
+	ch := make(chan ProgressSnapshot, 1)
+	pt.subsMu.Lock()
+	pt.subs = append(pt.subs, ch)
+	pt.subsMu.Unlock()
+	return ch
+}
+
+// unsubscribe removes and closes a subscriber channel previously returned by
+// subscribe.
+func (pt *ProgressTracker) unsubscribe(ch chan ProgressSnapshot) {
+	pt.subsMu.Lock()
+	defer pt.subsMu.Unlock()
+	for i, c := range pt.subs {
+		if c == ch {
+			pt.subs = append(pt.subs[:i], pt.subs[i+1:]...)
+			close(c)
+			return
+		}
+	}
+}
+
+// broadcast pushes snap to every SSE subscriber. A subscriber whose buffered
+// channel is already full (a slow client) is skipped rather than blocking
+// task completion.
+func (pt *ProgressTracker) broadcast(snap ProgressSnapshot) {
+	pt.subsMu.Lock()
+	defer pt.subsMu.Unlock()
+	for _, ch := range pt.subs {
+		select {
+		case ch <- snap:
+		default:
+		}
+	}
+}
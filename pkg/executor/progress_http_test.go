@@ -0,0 +1,104 @@
+package executor
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressTracker_HandleProgress(t *testing.T) {
+	tasks := []*Task{
+		{ScenarioPath: "/path/to/A1.json", RunID: "A1_001"},
+		{ScenarioPath: "/path/to/A1.json", RunID: "A1_002"},
+	}
+	pt := NewProgressTracker(tasks, false)
+	pt.Start()
+	defer pt.Stop()
+
+	pt.TaskCompletedWithDuration(tasks[0], nil, 0)
+
+	srv := httptest.NewServer(pt.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/api/progress")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var snap ProgressSnapshot
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&snap))
+	assert.Equal(t, 2, snap.Total)
+	assert.Equal(t, 1, snap.Completed)
+	require.Len(t, snap.PerScenario, 1)
+	assert.Equal(t, "A1", snap.PerScenario[0].Name)
+}
+
+func TestProgressTracker_HandleTasks(t *testing.T) {
+	tasks := []*Task{
+		{ScenarioPath: "/path/to/A1.json", RunID: "A1_001"},
+	}
+	pt := NewProgressTracker(tasks, false)
+	pt.Start()
+	defer pt.Stop()
+
+	pt.TaskCompletedWithDuration(tasks[0], assert.AnError, 0)
+
+	srv := httptest.NewServer(pt.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/api/tasks")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var snaps []TaskSnapshot
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&snaps))
+	require.Len(t, snaps, 1)
+	assert.Equal(t, "A1_001", snaps[0].RunID)
+	assert.Equal(t, "failed", snaps[0].Status)
+	assert.NotEmpty(t, snaps[0].Error)
+}
+
+func TestProgressTracker_HandleEvents(t *testing.T) {
+	tasks := []*Task{
+		{ScenarioPath: "/path/to/A1.json", RunID: "A1_001"},
+	}
+	pt := NewProgressTracker(tasks, false)
+	pt.Start()
+	defer pt.Stop()
+
+	srv := httptest.NewServer(pt.Handler())
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/events", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	nextDataLine := func() string {
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			if strings.HasPrefix(line, "data: ") {
+				return line
+			}
+		}
+	}
+
+	// First event is the snapshot sent immediately on subscribe.
+	nextDataLine()
+
+	pt.TaskCompletedWithDuration(tasks[0], nil, 0)
+
+	line := nextDataLine()
+	var snap ProgressSnapshot
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "data: ")), &snap))
+	assert.Equal(t, 1, snap.Completed)
+}
@@ -0,0 +1,185 @@
+package executor
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// Reporter renders a completed batch run's results as a report document, for
+// consumption by something other than a human watching stdout (CI systems,
+// test dashboards). Format selects one via ReporterFor.
+type Reporter interface {
+	Report(results []*Result) []byte
+}
+
+// ReportFormat selects a Reporter implementation.
+type ReportFormat string
+
+const (
+	ReportFormatText  ReportFormat = "text"
+	ReportFormatJSON  ReportFormat = "json"
+	ReportFormatJUnit ReportFormat = "junit"
+)
+
+// ReporterFor returns the Reporter for format, or an error if format isn't
+// one of ReportFormatText/JSON/JUnit.
+func ReporterFor(format ReportFormat) (Reporter, error) {
+	switch format {
+	case ReportFormatText, "":
+		return TextReporter{}, nil
+	case ReportFormatJSON:
+		return JSONReporter{}, nil
+	case ReportFormatJUnit:
+		return JUnitReporter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown report format %q (want text, json, or junit)", format)
+	}
+}
+
+// TextReporter renders the same human-readable summary PrintSummary prints,
+// as a []byte instead of directly to stdout.
+type TextReporter struct{}
+
+func (TextReporter) Report(results []*Result) []byte {
+	return []byte(summaryText(results))
+}
+
+// jsonTaskReport is one Result's JSON representation, per chunk8-2's field
+// list (run_id, scenario_path, start_time, duration_ms, error, log_dir).
+type jsonTaskReport struct {
+	RunID        string    `json:"run_id"`
+	ScenarioPath string    `json:"scenario_path"`
+	StartTime    time.Time `json:"start_time"`
+	DurationMs   int64     `json:"duration_ms"`
+	Error        string    `json:"error,omitempty"`
+	LogDir       string    `json:"log_dir"`
+}
+
+// jsonReportSummary is the JSON report's aggregate footer.
+type jsonReportSummary struct {
+	Total      int   `json:"total"`
+	Succeeded  int   `json:"succeeded"`
+	Failed     int   `json:"failed"`
+	Retried    int   `json:"retried"`
+	DurationMs int64 `json:"duration_ms"`
+}
+
+type jsonReport struct {
+	Tasks   []jsonTaskReport  `json:"tasks"`
+	Summary jsonReportSummary `json:"summary"`
+}
+
+// JSONReporter renders one JSON object per task plus an aggregate summary
+// footer, for machine consumption by CI pipelines (see ReporterFor).
+type JSONReporter struct{}
+
+func (JSONReporter) Report(results []*Result) []byte {
+	tasks := make([]jsonTaskReport, len(results))
+	for i, r := range results {
+		errMsg := ""
+		if r.Error != nil {
+			errMsg = r.Error.Error()
+		}
+		tasks[i] = jsonTaskReport{
+			RunID:        r.Task.RunID,
+			ScenarioPath: r.Task.ScenarioPath,
+			StartTime:    r.StartTime,
+			DurationMs:   r.Duration.Milliseconds(),
+			Error:        errMsg,
+			LogDir:       r.LogDir,
+		}
+	}
+
+	total, succeeded, failed, retried, totalDuration := Summary(results)
+	report := jsonReport{
+		Tasks: tasks,
+		Summary: jsonReportSummary{
+			Total:      total,
+			Succeeded:  succeeded,
+			Failed:     failed,
+			Retried:    retried,
+			DurationMs: totalDuration.Milliseconds(),
+		},
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		// report only holds plain strings/times/ints, so encoding can't
+		// fail in practice.
+		return []byte("{}")
+	}
+	return data
+}
+
+// junitFailure is a JUnit <failure> element, present on a testcase only when
+// its Result.Error was non-nil.
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// junitTestcase is one Task's outcome as a JUnit <testcase>.
+type junitTestcase struct {
+	Name    string        `xml:"name,attr"`
+	Time    float64       `xml:"time,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+// junitTestsuite groups every task run against one scenario path (JUnit's
+// closest analogue to a "scenario pattern": once a Plan's glob patterns are
+// expanded, each resolved Task.ScenarioPath is what distinguishes one
+// scenario from another).
+type junitTestsuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     float64         `xml:"time,attr"`
+	Cases    []junitTestcase `xml:"testcase"`
+}
+
+type junitTestsuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestsuite `xml:"testsuite"`
+}
+
+// JUnitReporter renders results as JUnit XML, one <testsuite> per scenario
+// path and one <testcase> per Task, for CI systems that already parse JUnit
+// reports from other test runners.
+type JUnitReporter struct{}
+
+func (JUnitReporter) Report(results []*Result) []byte {
+	var order []string
+	suiteByPath := make(map[string]*junitTestsuite)
+
+	for _, r := range results {
+		path := r.Task.ScenarioPath
+		suite, ok := suiteByPath[path]
+		if !ok {
+			suite = &junitTestsuite{Name: path}
+			suiteByPath[path] = suite
+			order = append(order, path)
+		}
+
+		tc := junitTestcase{Name: r.Task.RunID, Time: r.Duration.Seconds()}
+		if r.Error != nil {
+			tc.Failure = &junitFailure{Message: "task failed", Content: r.Error.Error()}
+			suite.Failures++
+		}
+		suite.Tests++
+		suite.Time += r.Duration.Seconds()
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	suites := make([]junitTestsuite, 0, len(order))
+	for _, path := range order {
+		suites = append(suites, *suiteByPath[path])
+	}
+
+	data, err := xml.MarshalIndent(junitTestsuites{Suites: suites}, "", "  ")
+	if err != nil {
+		return []byte("<testsuites></testsuites>")
+	}
+	return append([]byte(xml.Header), data...)
+}
@@ -0,0 +1,128 @@
+package executor
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleResults() []*Result {
+	start := time.Date(2026, 7, 26, 10, 0, 0, 0, time.UTC)
+	return []*Result{
+		{
+			Task:      &Task{ScenarioPath: "a.json", RunID: "a_001"},
+			StartTime: start,
+			Duration:  2 * time.Second,
+			LogDir:    "logs/a_001",
+			Attempts:  1,
+		},
+		{
+			Task:      &Task{ScenarioPath: "a.json", RunID: "a_002"},
+			StartTime: start.Add(2 * time.Second),
+			Duration:  time.Second,
+			Error:     errors.New("boom"),
+			LogDir:    "logs/a_002",
+			Attempts:  2,
+		},
+		{
+			Task:      &Task{ScenarioPath: "b.json", RunID: "b_001"},
+			StartTime: start.Add(3 * time.Second),
+			Duration:  500 * time.Millisecond,
+			Attempts:  1,
+		},
+	}
+}
+
+func TestReporterFor(t *testing.T) {
+	tests := []struct {
+		format  ReportFormat
+		want    Reporter
+		wantErr bool
+	}{
+		{ReportFormatText, TextReporter{}, false},
+		{"", TextReporter{}, false},
+		{ReportFormatJSON, JSONReporter{}, false},
+		{ReportFormatJUnit, JUnitReporter{}, false},
+		{"yaml", nil, true},
+	}
+
+	for _, tt := range tests {
+		reporter, err := ReporterFor(tt.format)
+		if tt.wantErr {
+			assert.Error(t, err)
+			assert.Nil(t, reporter)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, reporter)
+	}
+}
+
+func TestTextReporter_MatchesSummaryText(t *testing.T) {
+	results := sampleResults()
+	assert.Equal(t, summaryText(results), string(TextReporter{}.Report(results)))
+}
+
+func TestJSONReporter_Report(t *testing.T) {
+	data := JSONReporter{}.Report(sampleResults())
+
+	var report jsonReport
+	require.NoError(t, json.Unmarshal(data, &report))
+
+	require.Len(t, report.Tasks, 3)
+	assert.Equal(t, "a_001", report.Tasks[0].RunID)
+	assert.Equal(t, "a.json", report.Tasks[0].ScenarioPath)
+	assert.Equal(t, int64(2000), report.Tasks[0].DurationMs)
+	assert.Equal(t, "", report.Tasks[0].Error)
+	assert.Equal(t, "logs/a_001", report.Tasks[0].LogDir)
+
+	assert.Equal(t, "boom", report.Tasks[1].Error)
+
+	assert.Equal(t, jsonReportSummary{
+		Total:      3,
+		Succeeded:  2,
+		Failed:     1,
+		Retried:    1,
+		DurationMs: 3500,
+	}, report.Summary)
+
+	// error field should be omitted entirely for a successful task
+	assert.NotContains(t, string(data), `"error": ""`)
+	assert.Contains(t, string(data), `"run_id": "a_001"`)
+}
+
+func TestJUnitReporter_Report(t *testing.T) {
+	data := JUnitReporter{}.Report(sampleResults())
+
+	var suites junitTestsuites
+	require.NoError(t, xml.Unmarshal(data, &suites))
+
+	require.Len(t, suites.Suites, 2)
+
+	a := suites.Suites[0]
+	assert.Equal(t, "a.json", a.Name)
+	assert.Equal(t, 2, a.Tests)
+	assert.Equal(t, 1, a.Failures)
+	require.Len(t, a.Cases, 2)
+	assert.Nil(t, a.Cases[0].Failure)
+	require.NotNil(t, a.Cases[1].Failure)
+	assert.Equal(t, "boom", a.Cases[1].Failure.Content)
+
+	b := suites.Suites[1]
+	assert.Equal(t, "b.json", b.Name)
+	assert.Equal(t, 1, b.Tests)
+	assert.Equal(t, 0, b.Failures)
+}
+
+func TestJUnitReporter_Report_Empty(t *testing.T) {
+	data := JUnitReporter{}.Report(nil)
+
+	var suites junitTestsuites
+	require.NoError(t, xml.Unmarshal(data, &suites))
+	assert.Empty(t, suites.Suites)
+}
@@ -0,0 +1,320 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures how many times a Task is retried and how long the
+// executor waits between attempts. The zero value means "never retry"
+// (MaxAttempts defaults to 1).
+type RetryPolicy struct {
+	MaxAttempts    int // Total attempts including the first; <1 is treated as 1
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64 // Backoff growth per attempt; <=0 defaults to 2
+	Jitter         float64 // Fraction of the computed backoff to randomize by, e.g. 0.2 for ±20%
+
+	// RetryableErrors classifies whether err is worth retrying. If nil,
+	// defaultRetryable is used.
+	RetryableErrors func(error) bool
+}
+
+// maxAttempts returns p.MaxAttempts, defaulting to 1 (no retries).
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// shouldRetry reports whether err is retryable under this policy.
+func (p *RetryPolicy) shouldRetry(err error) bool {
+	if p == nil {
+		return false
+	}
+	if p.RetryableErrors != nil {
+		return p.RetryableErrors(err)
+	}
+	return defaultRetryable(err)
+}
+
+// backoff computes the delay before the given attempt (1-based: the delay
+// before attempt 2 is backoff(1)), as
+// min(MaxBackoff, InitialBackoff*Multiplier^(attempt-1)) plus ±Jitter*d.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	if p == nil || p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	d := time.Duration(float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1)))
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		delta := float64(d) * p.Jitter
+		d += time.Duration((rand.Float64()*2 - 1) * delta)
+		if d < 0 {
+			d = 0
+		}
+	}
+
+	return d
+}
+
+// NewCountRetryPolicy builds a RetryPolicy that makes up to maxAttempts
+// attempts in all (the first plus maxAttempts-1 retries) with a sensible
+// exponential backoff, for simple "--retry N" style
+// configuration where the caller doesn't need to tune backoff/jitter.
+func NewCountRetryPolicy(maxAttempts int) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// ApplyDefaultRetry sets policy on every task in tasks that doesn't already
+// have a Retry (e.g. from a per-scenario Plan override), for a CLI-level
+// "--retry N" default that a Plan entry can still override.
+func ApplyDefaultRetry(tasks []*Task, policy *RetryPolicy) {
+	for _, task := range tasks {
+		if task.Retry == nil {
+			task.Retry = policy
+		}
+	}
+}
+
+// effectiveRetry resolves a ScenarioEntry's retry configuration into a
+// *RetryPolicy: entry.Retry, if set, wins outright (the full-control
+// escape hatch); otherwise entry.Retries/RetryBackoff/RetryBackoffFactor/
+// RetryOn are compiled into one, falling back to plan's Retries/RetryBackoff
+// for whichever of Retries/RetryBackoff the entry left unset. Returns nil
+// (no retry) when neither the entry nor the plan sets a positive retry
+// count.
+func effectiveRetry(entry ScenarioEntry, plan *Plan) (*RetryPolicy, error) {
+	if entry.Retry != nil {
+		return entry.Retry, nil
+	}
+
+	retries := entry.Retries
+	if retries == 0 {
+		retries = plan.Retries
+	}
+	if retries <= 0 {
+		return nil, nil
+	}
+
+	backoffStr := entry.RetryBackoff
+	if backoffStr == "" {
+		backoffStr = plan.RetryBackoff
+	}
+	backoff := time.Second
+	if backoffStr != "" {
+		d, err := time.ParseDuration(backoffStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retryBackoff %q: %w", backoffStr, err)
+		}
+		backoff = d
+	}
+
+	factor := entry.RetryBackoffFactor
+	if factor <= 0 {
+		factor = 2
+	}
+
+	retryableErrors, err := retryOnMatcher(entry.RetryOn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RetryPolicy{
+		MaxAttempts:     retries + 1,
+		InitialBackoff:  backoff,
+		Multiplier:      factor,
+		RetryableErrors: retryableErrors,
+	}, nil
+}
+
+// retryOnMatcher compiles patterns into a RetryPolicy.RetryableErrors
+// classifier that matches if any pattern matches the error's message. A nil
+// classifier (patterns empty) leaves RetryPolicy to fall back to
+// defaultRetryable, which retries every error it does not treat as terminal.
+func retryOnMatcher(patterns []string) (func(error) bool, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	compiled := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retryOn pattern %q: %w", pattern, err)
+		}
+		compiled[i] = re
+	}
+
+	return func(err error) bool {
+		if err == nil {
+			return false
+		}
+		msg := err.Error()
+		for _, re := range compiled {
+			if re.MatchString(msg) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// Backoff implements a bounded, jittered exponential-backoff retry loop for
+// a single sub-step (e.g. RunWithResult's deploy phase), as opposed to
+// RetryPolicy, which Executor drives from the outside by re-invoking
+// RunWithResult from scratch for each attempt. The zero value allows exactly
+// one attempt (MaxRetries 0), i.e. "no retry". Typical use:
+//
+//	var b Backoff = ScenarioRunner.DeployRetry
+//	for b.Ongoing() {
+//	    err := attempt()
+//	    b.Record(err)
+//	    if err == nil || !isRetryable(err) || b.Wait(ctx) != nil {
+//	        break
+//	    }
+//	}
+//	return b.ErrCause(ctx)
+type Backoff struct {
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	MaxRetries int
+
+	attempt int
+	lastErr error
+}
+
+// Ongoing reports whether another attempt is still allowed under MaxRetries.
+func (b *Backoff) Ongoing() bool {
+	return b.attempt <= b.MaxRetries
+}
+
+// Record stores the outcome of the attempt just made, for Err/ErrCause, and
+// counts it against MaxRetries.
+func (b *Backoff) Record(err error) {
+	b.lastErr = err
+	b.attempt++
+}
+
+// Wait sleeps the jittered backoff for the attempt just Recorded, or returns
+// ctx.Err() immediately if ctx is cancelled first.
+func (b *Backoff) Wait(ctx context.Context) error {
+	d := b.delay()
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Err returns the error recorded by the most recent Record call.
+func (b *Backoff) Err() error {
+	return b.lastErr
+}
+
+// ErrCause returns context.Cause(ctx) if ctx was cancelled, and Err()
+// otherwise, so a cancelled batch is reported as a cancellation rather than
+// whatever error the in-flight attempt happened to return.
+func (b *Backoff) ErrCause(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	return b.lastErr
+}
+
+// delay computes min(MaxBackoff, MinBackoff*2^(attempt-1)) plus ±20% jitter.
+func (b *Backoff) delay() time.Duration {
+	if b.MinBackoff <= 0 {
+		return 0
+	}
+
+	d := time.Duration(float64(b.MinBackoff) * math.Pow(2, float64(b.attempt-1)))
+	if b.MaxBackoff > 0 && d > b.MaxBackoff {
+		d = b.MaxBackoff
+	}
+
+	delta := float64(d) * 0.2
+	d += time.Duration((rand.Float64()*2 - 1) * delta)
+	if d < 0 {
+		d = 0
+	}
+	return d
+}
+
+// defaultRetryable treats context.Canceled, missing scenario files, and
+// containerlab schema errors as terminal (not worth retrying), and
+// everything else (e.g. Docker daemon hiccups, `clab deploy` network races)
+// as transient.
+func defaultRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+
+	msg := err.Error()
+	for _, terminal := range []string{"no such file or directory", "schema validation", "invalid topology"} {
+		if strings.Contains(msg, terminal) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// defaultDeployRetryable is ScenarioRunner's default classifier for its
+// deploy-phase Backoff: only the "failed to deploy network"/"failed to
+// create Docker client"/"failed to create container runtime" errors
+// RunWithResult wraps around network.Deploy and the Docker client/runtime
+// constructors are retried (transient Docker-daemon and `clab deploy`
+// races), so a bad scenario file or host-validation failure fails fast
+// instead of retrying something that will never succeed.
+func defaultDeployRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	msg := err.Error()
+	for _, retryable := range []string{"failed to deploy network", "failed to create Docker client", "failed to create container runtime"} {
+		if strings.Contains(msg, retryable) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,293 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_MaxAttempts(t *testing.T) {
+	var nilPolicy *RetryPolicy
+	assert.Equal(t, 1, nilPolicy.maxAttempts())
+
+	p := &RetryPolicy{MaxAttempts: 0}
+	assert.Equal(t, 1, p.maxAttempts())
+
+	p = &RetryPolicy{MaxAttempts: 5}
+	assert.Equal(t, 5, p.maxAttempts())
+}
+
+func TestRetryPolicy_ShouldRetry_Default(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 3}
+
+	assert.True(t, p.shouldRetry(errors.New("i/o timeout")))
+	assert.False(t, p.shouldRetry(context.Canceled))
+	assert.False(t, p.shouldRetry(errors.New("open scenario.json: no such file or directory")))
+
+	var nilPolicy *RetryPolicy
+	assert.False(t, nilPolicy.shouldRetry(errors.New("anything")))
+}
+
+func TestRetryPolicy_ShouldRetry_Custom(t *testing.T) {
+	p := &RetryPolicy{
+		MaxAttempts:     3,
+		RetryableErrors: func(err error) bool { return err.Error() == "flaky" },
+	}
+
+	assert.True(t, p.shouldRetry(errors.New("flaky")))
+	assert.False(t, p.shouldRetry(errors.New("i/o timeout")))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := &RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     1 * time.Second,
+		Multiplier:     2,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, p.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, p.backoff(2))
+	assert.Equal(t, 400*time.Millisecond, p.backoff(3))
+	// Capped at MaxBackoff
+	assert.Equal(t, 1*time.Second, p.backoff(10))
+}
+
+func TestRetryPolicy_Backoff_Jitter(t *testing.T) {
+	p := &RetryPolicy{
+		InitialBackoff: 1 * time.Second,
+		Multiplier:     1,
+		Jitter:         0.5,
+	}
+
+	for i := 0; i < 20; i++ {
+		d := p.backoff(1)
+		assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+		assert.LessOrEqual(t, d, 1500*time.Millisecond)
+	}
+}
+
+func TestExecutor_RetriesTransientFailures(t *testing.T) {
+	mock := &mockTaskRunner{runError: errors.New("docker: connection refused")}
+	tasks := GenerateTasks("test.json", 1, false)
+	tasks[0].Retry = &RetryPolicy{MaxAttempts: 3}
+
+	exec := NewExecutor(1, mock)
+	results := exec.Execute(context.Background(), tasks)
+
+	assert.Equal(t, int32(3), mock.runCount)
+	assert.Equal(t, 3, results[0].Attempts)
+	assert.Error(t, results[0].Error)
+}
+
+func TestExecutor_StopsRetryingOnNonRetryableError(t *testing.T) {
+	mock := &mockTaskRunner{runError: context.Canceled}
+	tasks := GenerateTasks("test.json", 1, false)
+	tasks[0].Retry = &RetryPolicy{MaxAttempts: 5}
+
+	exec := NewExecutor(1, mock)
+	results := exec.Execute(context.Background(), tasks)
+
+	assert.Equal(t, int32(1), mock.runCount)
+	assert.Equal(t, 1, results[0].Attempts)
+}
+
+func TestExecutor_RetriesRecordPriorAttemptsWithSuffixedRunID(t *testing.T) {
+	mock := &mockTaskRunner{runError: errors.New("docker: connection refused")}
+	tasks := GenerateTasks("test.json", 1, false)
+	tasks[0].Retry = &RetryPolicy{MaxAttempts: 3}
+
+	exec := NewExecutor(1, mock)
+	results := exec.Execute(context.Background(), tasks)
+
+	result := results[0]
+	assert.Equal(t, "test_001", result.Task.RunID, "the authoritative Result keeps the task's original RunID")
+	require.Len(t, result.PriorAttempts, 2)
+	assert.Equal(t, "test_001_retry1", result.PriorAttempts[0].Task.RunID)
+	assert.Equal(t, "test_001_retry2", result.PriorAttempts[1].Task.RunID)
+	for _, attempt := range result.PriorAttempts {
+		assert.Error(t, attempt.Error)
+		assert.Equal(t, 1, attempt.Attempts)
+	}
+
+	assert.Equal(t, []string{"test_001", "test_001_retry1", "test_001_retry2"}, mock.runCalled)
+}
+
+func TestExecutor_FailFast_CancelsRemainingTasks(t *testing.T) {
+	// A single serial worker (parallel=1) means each task only starts once
+	// the previous one's result (and any fail-fast cancellation) is
+	// recorded, so every task after the first fails must be skipped.
+	failing := &mockTaskRunner{runError: errors.New("boom")}
+	tasks := GenerateTasks("test.json", 3, false)
+
+	exec := NewExecutor(1, failing)
+	exec.SetFailFast(true)
+	results := exec.Execute(context.Background(), tasks)
+
+	assert.Error(t, results[0].Error)
+	assert.Equal(t, int32(1), failing.runCount, "fail-fast must stop further tasks from running once one fails")
+	for _, r := range results[1:] {
+		assert.Error(t, r.Error)
+	}
+}
+
+func TestExecutor_SucceedsAfterRetry(t *testing.T) {
+	mock := &flakyTaskRunner{failUntilAttempt: 2}
+	tasks := GenerateTasks("test.json", 1, false)
+	tasks[0].Retry = &RetryPolicy{MaxAttempts: 3}
+
+	exec := NewExecutor(1, mock)
+	results := exec.Execute(context.Background(), tasks)
+
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, 2, results[0].Attempts)
+}
+
+// flakyTaskRunner fails until the given attempt number, then succeeds.
+type flakyTaskRunner struct {
+	failUntilAttempt int
+	calls            int
+}
+
+func (f *flakyTaskRunner) Run(ctx context.Context, task *Task) error {
+	f.calls++
+	if f.calls < f.failUntilAttempt {
+		return errors.New("transient failure")
+	}
+	return nil
+}
+
+func TestBackoff_Ongoing(t *testing.T) {
+	b := &Backoff{MaxRetries: 2}
+
+	assert.True(t, b.Ongoing())
+	b.Record(errors.New("fail"))
+	assert.True(t, b.Ongoing())
+	b.Record(errors.New("fail"))
+	assert.True(t, b.Ongoing())
+	b.Record(errors.New("fail"))
+	assert.False(t, b.Ongoing())
+}
+
+func TestBackoff_ZeroValueAllowsOneAttempt(t *testing.T) {
+	var b Backoff
+
+	assert.True(t, b.Ongoing())
+	b.Record(errors.New("fail"))
+	assert.False(t, b.Ongoing())
+}
+
+func TestBackoff_WaitRespectsContextCancellation(t *testing.T) {
+	b := &Backoff{MinBackoff: time.Hour, MaxRetries: 1}
+	b.Record(errors.New("fail"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := b.Wait(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestBackoff_ErrCause(t *testing.T) {
+	b := &Backoff{MaxRetries: 1}
+	b.Record(errors.New("deploy failed"))
+
+	assert.Equal(t, "deploy failed", b.ErrCause(context.Background()).Error())
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errors.New("user aborted"))
+	assert.Equal(t, "user aborted", b.ErrCause(ctx).Error())
+}
+
+func TestBackoff_DelayGrowsAndCaps(t *testing.T) {
+	b := &Backoff{MinBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, MaxRetries: 5}
+
+	b.Record(errors.New("fail"))
+	assert.InDelta(t, 100*time.Millisecond, b.delay(), float64(20*time.Millisecond))
+
+	b.Record(errors.New("fail"))
+	assert.InDelta(t, 200*time.Millisecond, b.delay(), float64(40*time.Millisecond))
+
+	b.Record(errors.New("fail"))
+	assert.InDelta(t, 300*time.Millisecond, b.delay(), float64(60*time.Millisecond))
+}
+
+func TestDefaultDeployRetryable(t *testing.T) {
+	assert.True(t, defaultDeployRetryable(errors.New("failed to deploy network: exit status 1")))
+	assert.True(t, defaultDeployRetryable(errors.New("failed to create Docker client: connection refused")))
+	assert.False(t, defaultDeployRetryable(errors.New("host validation failed: host r1 not found in topology")))
+	assert.False(t, defaultDeployRetryable(context.Canceled))
+	assert.False(t, defaultDeployRetryable(nil))
+}
+
+func TestEffectiveRetry_ExplicitRetryWins(t *testing.T) {
+	want := &RetryPolicy{MaxAttempts: 9}
+	entry := ScenarioEntry{Retry: want, Retries: 5}
+
+	policy, err := effectiveRetry(entry, &Plan{Retries: 5})
+	require.NoError(t, err)
+	assert.Same(t, want, policy)
+}
+
+func TestEffectiveRetry_NoneConfigured(t *testing.T) {
+	policy, err := effectiveRetry(ScenarioEntry{}, &Plan{})
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestEffectiveRetry_EntryOverridesPlanDefaults(t *testing.T) {
+	entry := ScenarioEntry{Retries: 2, RetryBackoff: "5s", RetryBackoffFactor: 3}
+	plan := &Plan{Retries: 9, RetryBackoff: "1m"}
+
+	policy, err := effectiveRetry(entry, plan)
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+	assert.Equal(t, 3, policy.MaxAttempts)
+	assert.Equal(t, 5*time.Second, policy.InitialBackoff)
+	assert.Equal(t, float64(3), policy.Multiplier)
+}
+
+func TestEffectiveRetry_FallsBackToPlanDefaults(t *testing.T) {
+	plan := &Plan{Retries: 2, RetryBackoff: "3s"}
+
+	policy, err := effectiveRetry(ScenarioEntry{}, plan)
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+	assert.Equal(t, 3, policy.MaxAttempts)
+	assert.Equal(t, 3*time.Second, policy.InitialBackoff)
+	assert.Equal(t, float64(2), policy.Multiplier, "factor defaults to 2 when unset")
+}
+
+func TestEffectiveRetry_InvalidBackoff(t *testing.T) {
+	_, err := effectiveRetry(ScenarioEntry{Retries: 1, RetryBackoff: "not-a-duration"}, &Plan{})
+	assert.Error(t, err)
+}
+
+func TestEffectiveRetry_RetryOnFiltersErrors(t *testing.T) {
+	entry := ScenarioEntry{Retries: 1, RetryOn: []string{"connection refused", "i/o timeout"}}
+
+	policy, err := effectiveRetry(entry, &Plan{})
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+
+	assert.True(t, policy.shouldRetry(errors.New("dial tcp: connection refused")))
+	assert.False(t, policy.shouldRetry(errors.New("schema validation failed")))
+}
+
+func TestEffectiveRetry_InvalidRetryOnPattern(t *testing.T) {
+	_, err := effectiveRetry(ScenarioEntry{Retries: 1, RetryOn: []string{"("}}, &Plan{})
+	assert.Error(t, err)
+}
+
+func TestApplyDefaultRetry(t *testing.T) {
+	tasks := GenerateTasks("test.json", 2, false)
+	tasks[0].Retry = &RetryPolicy{MaxAttempts: 9}
+
+	ApplyDefaultRetry(tasks, NewCountRetryPolicy(3))
+
+	assert.Equal(t, 9, tasks[0].Retry.MaxAttempts, "existing per-scenario override must not be replaced")
+	assert.Equal(t, 3, tasks[1].Retry.MaxAttempts)
+}
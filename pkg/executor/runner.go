@@ -1,36 +1,215 @@
 package executor
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/3atlab/netroub/pkg/events"
+	"github.com/3atlab/netroub/pkg/executor/host"
 	"github.com/3atlab/netroub/pkg/model"
 	"github.com/3atlab/netroub/pkg/network"
+	"github.com/3atlab/netroub/pkg/network/eventmon"
 	"github.com/3atlab/netroub/pkg/runtime"
+	"github.com/3atlab/netroub/pkg/telemetry"
 	"github.com/sirupsen/logrus"
-	"github.com/urfave/cli"
 )
 
-// scenarioLoadMu protects the scenario loading process.
-// This is necessary because model.ReadYaml/ReadJsonScenar use global variables
-// (os.Args, model.Scenar, model.Devices) that would cause race conditions
-// when multiple scenarios are loaded in parallel.
-var scenarioLoadMu sync.Mutex
+// shutdownGrace bounds how long RunWithResult lets Destroy/CleanupReused run
+// once it gives them a fresh context, so a containerlab destroy that's
+// itself stuck can't hang a Ctrl-C shutdown forever.
+const shutdownGrace = 60 * time.Second
 
 // ScenarioRunner executes a single scenario task.
 type ScenarioRunner struct {
-	CLIContext *cli.Context
-	QuietMode  bool // When true, suppress stdout logging (file only)
+	// NoReuse forces a fresh topology per trial even when the scenario sets
+	// Reuse, mirroring the CLI's --no-reuse flag.
+	NoReuse bool
+
+	// Vars holds "key=value" pairs (one per --var flag) available for
+	// envsubst substitution in a task's scenario file, alongside the
+	// implicit NETROUB_* identifiers scenarioVars always adds.
+	Vars []string
+
+	// Docker carries the --docker-host/--tls* connection options used to
+	// build both the Pumba chaos client and this runner's container runtime.
+	Docker network.DockerOptions
+
+	QuietMode bool // When true, suppress stdout logging (file only)
+
+	// DeployRetry configures the bounded/backoff retry loop RunWithResult
+	// runs around a task's Deploy/Docker-client-init failures, destroying
+	// the partial lab before each retry. The zero value (MaxRetries 0)
+	// disables deploy retry.
+	DeployRetry Backoff
+
+	// IsRetryable classifies a deploy-phase error as worth retrying under
+	// DeployRetry. If nil, defaultDeployRetryable is used.
+	IsRetryable func(error) bool
+
+	// BatchLogger, if set, gets each task's RunID/control.log path through
+	// RegisterTask/UnregisterTask, so its livelog.Registry (see SetLiveLog) can
+	// tail control.log over HTTP while the task runs.
+	BatchLogger *BatchLogger
+
+	// Telemetry, if set, receives deploy_done/destroy_done records around
+	// this runner's network teardown/setup. Unset, telemetry is a no-op.
+	Telemetry telemetry.Sink
+
+	// Hosts maps a Task.Host name to the host.Config it should run
+	// against, mirroring Plan.Hosts. A task whose Host is empty or doesn't
+	// name an entry here runs against host.LocalHost, same as before Hosts
+	// existed.
+	Hosts map[string]host.Config
+
+	reuseMu    sync.Mutex
+	reuseCache map[string]*reuseEntry // keyed by Task.ScenarioPath
 }
 
-// NewScenarioRunner creates a new ScenarioRunner.
-func NewScenarioRunner(c *cli.Context) *ScenarioRunner {
-	return &ScenarioRunner{CLIContext: c}
+// sink returns r.Telemetry, or telemetry.NoopSink{} if none is configured.
+func (r *ScenarioRunner) sink() telemetry.Sink {
+	if r.Telemetry == nil {
+		return telemetry.NoopSink{}
+	}
+	return r.Telemetry
+}
+
+// reuseEntry holds the still-deployed topology and EventExecutor for a
+// Scenario with Reuse set, across its trial repetitions. mu serializes
+// trials of the same scenario against each other: Reset between trials
+// isn't safe to run concurrently with the next trial's events, so reused
+// scenarios run their repetitions one at a time even under a parallel
+// Executor.
+type reuseEntry struct {
+	mu                sync.Mutex
+	labName           string
+	networkController *network.NetworkController
+	eventExecutor     *events.EventExecutor
+	deployed          bool
+}
+
+// NewScenarioRunner creates a new ScenarioRunner configured with opts.
+func NewScenarioRunner(opts RunnerOptions) *ScenarioRunner {
+	return &ScenarioRunner{
+		NoReuse: opts.NoReuse,
+		Vars:    opts.Vars,
+		Docker:  opts.Docker,
+		Hosts:   opts.Hosts,
+	}
+}
+
+// RunnerOptions configures a ScenarioRunner built via NewScenarioRunner.
+type RunnerOptions struct {
+	NoReuse bool
+	Vars    []string
+	Docker  network.DockerOptions
+	Hosts   map[string]host.Config
+}
+
+// resolveHost resolves taskHost (a Task.Host value) into a host.Host and the
+// network.DockerOptions its deploy/collect calls should use: host.LocalHost
+// and r.Docker unchanged when taskHost is empty or names no entry in
+// r.Hosts, otherwise the named host.Config's Host (dialed via host.New) and
+// r.Docker with Host overridden by the config's DockerContext, if set.
+func (r *ScenarioRunner) resolveHost(taskHost string) (host.Host, network.DockerOptions, error) {
+	dockerOpts := r.Docker
+	if taskHost == "" {
+		return host.LocalHost{}, dockerOpts, nil
+	}
+
+	cfg, ok := r.Hosts[taskHost]
+	if !ok {
+		return host.LocalHost{}, dockerOpts, nil
+	}
+
+	h, err := host.New(cfg)
+	if err != nil {
+		return nil, dockerOpts, fmt.Errorf("resolving host %q: %w", taskHost, err)
+	}
+	if cfg.DockerContext != "" {
+		dockerOpts.Host = cfg.DockerContext
+	}
+	return h, dockerOpts, nil
+}
+
+// CleanupReused destroys the topology of every scenario still deployed for
+// reuse. Callers that run scenarios with Reuse set must call this once after
+// all of their trials have finished, since RunWithResult intentionally
+// leaves a reused topology running between trials. Destroy always runs
+// against a fresh, bounded-deadline context rather than one the caller might
+// have already canceled (e.g. on Ctrl-C), so this cleanup still gets a
+// chance to run.
+func (r *ScenarioRunner) CleanupReused() error {
+	r.reuseMu.Lock()
+	entries := r.reuseCache
+	r.reuseCache = nil
+	r.reuseMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+	defer cancel()
+
+	var lastErr error
+	for _, entry := range entries {
+		entry.mu.Lock()
+		if entry.deployed {
+			if entry.eventExecutor != nil {
+				if entry.eventExecutor.VtyshSessions != nil {
+					if err := entry.eventExecutor.VtyshSessions.Close(); err != nil {
+						logrus.Warnf("Failed to close vtysh sessions for %s: %v", entry.labName, err)
+					}
+				}
+				if err := entry.eventExecutor.RestoreConfigChanges(); err != nil {
+					logrus.Warnf("Failed to restore config file changes for %s: %v", entry.labName, err)
+				}
+			}
+			if entry.networkController != nil {
+				destroyStart := time.Now()
+				destroyErr := entry.networkController.Destroy(ctx)
+				if destroyErr != nil {
+					logrus.Errorf("Failed to destroy reused network %s: %v", entry.labName, destroyErr)
+					lastErr = destroyErr
+				}
+				destroyRec := telemetry.Record{
+					Type:     telemetry.RecordDestroyDone,
+					Time:     time.Now(),
+					RunID:    entry.labName,
+					Duration: time.Since(destroyStart),
+				}
+				if destroyErr != nil {
+					destroyRec.Error = destroyErr.Error()
+				}
+				if err := r.sink().Publish(ctx, destroyRec); err != nil {
+					logrus.Debugf("Telemetry publish failed: %v", err)
+				}
+			}
+		}
+		entry.mu.Unlock()
+	}
+	return lastErr
+}
+
+// reuseEntryFor returns the (creating if necessary) reuseEntry for
+// scenarioPath.
+func (r *ScenarioRunner) reuseEntryFor(scenarioPath string) *reuseEntry {
+	r.reuseMu.Lock()
+	defer r.reuseMu.Unlock()
+
+	if r.reuseCache == nil {
+		r.reuseCache = make(map[string]*reuseEntry)
+	}
+	entry, ok := r.reuseCache[scenarioPath]
+	if !ok {
+		entry = &reuseEntry{}
+		r.reuseCache[scenarioPath] = entry
+	}
+	return entry
 }
 
 // SetQuietMode enables or disables quiet mode.
@@ -40,22 +219,41 @@ func (r *ScenarioRunner) SetQuietMode(quiet bool) {
 }
 
 // Run executes a single scenario task.
-func (r *ScenarioRunner) Run(task *Task) error {
-	result := r.RunWithResult(task, time.Now())
+func (r *ScenarioRunner) Run(ctx context.Context, task *Task) error {
+	result := r.RunWithResult(ctx, task, time.Now())
 	return result.Error
 }
 
 // RunWithResult executes a single scenario task and returns detailed result.
-func (r *ScenarioRunner) RunWithResult(task *Task, startTime time.Time) TaskRunnerResult {
-	// Use task.RunID directly as lab name to avoid global state race conditions
-	labName := task.RunID
-
-	// Load scenario and devices (protected by mutex due to global state)
+// Canceling ctx (e.g. Ctrl-C at the CLI) stops event execution and any
+// in-flight Deploy/SetupTcpdump call as soon as they observe it; the
+// deferred network teardown and log collection still run afterwards,
+// against a fresh context, so Ctrl-C never leaves a topology or pcap
+// capture running.
+func (r *ScenarioRunner) RunWithResult(ctx context.Context, task *Task, startTime time.Time) TaskRunnerResult {
+	// Load scenario and devices
 	scenario, devices, err := r.loadScenarioAndDevices(task)
 	if err != nil {
 		return TaskRunnerResult{Error: fmt.Errorf("failed to load scenario: %w", err)}
 	}
 
+	reuse := scenario.Reuse && !r.NoReuse && scenario.Topo != ""
+
+	var entry *reuseEntry
+	if reuse {
+		entry = r.reuseEntryFor(task.ScenarioPath)
+		entry.mu.Lock()
+		defer entry.mu.Unlock()
+	}
+
+	// Use task.RunID as the lab name, except for repeat trials of a reused
+	// scenario, which keep reusing the lab name the first trial picked so
+	// they land on the same already-deployed containers.
+	labName := task.RunID
+	if reuse && entry.deployed {
+		labName = entry.labName
+	}
+
 	// Calculate log directory path using labName
 	logDir := scenario.TrialLogDirectoryWithLabName(startTime, labName)
 
@@ -70,15 +268,24 @@ func (r *ScenarioRunner) RunWithResult(task *Task, startTime time.Time) TaskRunn
 	}
 	defer controlLogFile.Close()
 
+	// Live-tail control.log over HTTP for as long as this task runs, if a
+	// BatchLogger with a livelog.Registry (see BatchLogger.SetLiveLog) is
+	// configured; otherwise liveWriter is io.Discard.
+	liveWriter := io.Discard
+	if r.BatchLogger != nil {
+		liveWriter = r.BatchLogger.RegisterTask(task.RunID, controlLogPath)
+		defer r.BatchLogger.UnregisterTask(task.RunID)
+	}
+
 	// Configure logrus output based on QuietMode
 	// IMPORTANT: Always use os.Stdout as the original output, not logrus.StandardLogger().Out.
 	// In parallel execution, another worker might have changed logrus output to include
 	// a file that is now closed, causing "file already closed" errors when we try to
 	// write to the MultiWriter that references the closed file.
 	if r.QuietMode {
-		logrus.SetOutput(controlLogFile) // File only
+		logrus.SetOutput(io.MultiWriter(controlLogFile, liveWriter)) // File (+ live tail) only
 	} else {
-		logrus.SetOutput(io.MultiWriter(os.Stdout, controlLogFile)) // Stdout + file
+		logrus.SetOutput(io.MultiWriter(os.Stdout, controlLogFile, liveWriter)) // Stdout + file + live tail
 	}
 	defer logrus.SetOutput(os.Stdout) // Always restore to stdout
 
@@ -98,44 +305,197 @@ func (r *ScenarioRunner) RunWithResult(task *Task, startTime time.Time) TaskRunn
 		Type:      model.EventTypeDummy,
 	})
 
+	// Reusing an already-deployed topology: swap in the cached
+	// EventExecutor/NetworkController instead of building fresh ones, and
+	// undo the previous trial's mutable state rather than tearing it down.
+	if reuse && entry.deployed {
+		eventExecutor := entry.eventExecutor
+		eventExecutor.Scenario = scenario
+		eventExecutor.Devices = devices
+		eventExecutor.SetTrialLogDir(logDir)
+		eventExecutor.Telemetry = r.Telemetry
+
+		if err := eventExecutor.Reset(context.Background()); err != nil {
+			logrus.Warnf("Reset before reused trial failed, trial may start from stale state: %v", err)
+		}
+
+		defer func() {
+			logCtx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+			defer cancel()
+			if err := r.collectLogs(logCtx, scenario, entry.networkController, logDir); err != nil {
+				logrus.Warnf("Log collection failed: %v", err)
+			}
+		}()
+
+		if err := r.executeEvents(ctx, scenario, eventExecutor); err != nil {
+			return TaskRunnerResult{LogDir: logDir, Error: fmt.Errorf("event execution failed: %w", err)}
+		}
+		return TaskRunnerResult{LogDir: logDir, Error: nil}
+	}
+
 	// Create runner and controllers
-	cmdRunner := runtime.NewExecRunner()
+	cmdRunner, dockerOpts, err := r.resolveHost(task.Host)
+	if err != nil {
+		return TaskRunnerResult{LogDir: logDir, Error: err}
+	}
 
-	eventExecutor := events.NewEventExecutor(scenario, devices, labName, cmdRunner)
+	dockerEndpoint := network.NewDockerEndpoint(dockerOpts)
+	containerRuntime, err := runtime.NewContainerRuntimeWithEndpoint(runtime.Engine(scenario.ContainerEngine), dockerEndpoint)
+	if err != nil {
+		return TaskRunnerResult{LogDir: logDir, Error: fmt.Errorf("failed to create container runtime: %w", err)}
+	}
+
+	eventExecutor := events.NewEventExecutor(scenario, devices, labName, cmdRunner, containerRuntime)
 	eventExecutor.SetTrialLogDir(logDir)
+	eventExecutor.Telemetry = r.Telemetry
+
+	if scenario.VtyshMode == "session" {
+		if pool, ok := events.NewVtyshSessionPoolFor(containerRuntime); ok {
+			eventExecutor.VtyshSessions = pool
+		} else {
+			logrus.Warnf("VtyshMode \"session\" requested but %s does not support interactive exec, falling back to oneshot", scenario.ContainerEngine)
+		}
+	}
+
+	// runCtx is what executeEvents runs against. It's replaced with a
+	// cancelable context below once the topology is deployed, so a node
+	// dying unexpectedly cuts the run short instead of waiting out the rest
+	// of the scenario's duration.
+	runCtx := ctx
 
 	if noDeploy {
 		logrus.Info("No topology specified, running in noDeploy mode (events only)")
 	} else {
-		networkController := network.NewNetworkController(scenario, devices, labName, cmdRunner)
-
-		// Create Docker client for Pumba
-		if err := network.CreateDockerClient(r.CLIContext); err != nil {
-			return TaskRunnerResult{LogDir: logDir, Error: fmt.Errorf("failed to create Docker client: %w", err)}
+		// Build the Docker client, Pumba's client, and the deployed lab
+		// together, retrying the whole deploy phase on a transient failure
+		// (Docker-daemon flakes, containerlab races) under DeployRetry,
+		// destroying any partial lab before each retry.
+		isRetryable := r.IsRetryable
+		if isRetryable == nil {
+			isRetryable = defaultDeployRetryable
 		}
 
-		// Deploy network
-		if err := networkController.Deploy(); err != nil {
-			return TaskRunnerResult{LogDir: logDir, Error: fmt.Errorf("failed to deploy network: %w", err)}
+		deployStart := time.Now()
+		var networkController *network.NetworkController
+		backoff := r.DeployRetry
+		var deployErr error
+		for backoff.Ongoing() {
+			networkController, deployErr = r.deployNetworkController(ctx, scenario, devices, labName, cmdRunner, dockerEndpoint)
+			backoff.Record(deployErr)
+			if deployErr == nil || !isRetryable(deployErr) {
+				break
+			}
+			if networkController != nil {
+				logrus.Warnf("Deploy failed, destroying partial lab and retrying: %v", deployErr)
+				destroyCtx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+				if err := networkController.Destroy(destroyCtx); err != nil {
+					logrus.Warnf("Failed to destroy partial lab before retry: %v", err)
+				}
+				cancel()
+			}
+			if err := backoff.Wait(ctx); err != nil {
+				break
+			}
 		}
-
-		// Ensure cleanup on exit
-		defer func() {
-			if err := networkController.Destroy(); err != nil {
-				logrus.Errorf("Failed to destroy network: %v", err)
+		deployRec := telemetry.Record{
+			Type:         telemetry.RecordDeployDone,
+			Time:         time.Now(),
+			RunID:        task.RunID,
+			ScenarioPath: task.ScenarioPath,
+			Duration:     time.Since(deployStart),
+		}
+		if deployErr != nil {
+			deployRec.Error = deployErr.Error()
+		}
+		if err := r.sink().Publish(ctx, deployRec); err != nil {
+			logrus.Debugf("Telemetry publish failed: %v", err)
+		}
+		if deployErr != nil {
+			if ctx.Err() != nil {
+				return TaskRunnerResult{LogDir: logDir, Error: backoff.ErrCause(ctx)}
 			}
-		}()
+			return TaskRunnerResult{LogDir: logDir, Error: deployErr}
+		}
+
+		if reuse {
+			// Leave the topology running: CleanupReused destroys it once
+			// all of this scenario's trials are done.
+			entry.labName = labName
+			entry.networkController = networkController
+			entry.eventExecutor = eventExecutor
+			entry.deployed = true
+		} else {
+			// Ensure cleanup on exit, against a fresh context so a canceled
+			// ctx (e.g. Ctrl-C) can't stop Destroy from running. Config file
+			// changes are reverted first, so a host-side file this trial
+			// edited never leaks into whatever runs against the same topo
+			// path next, whether the trial succeeded or failed.
+			defer func() {
+				if eventExecutor.VtyshSessions != nil {
+					if err := eventExecutor.VtyshSessions.Close(); err != nil {
+						logrus.Warnf("Failed to close vtysh sessions: %v", err)
+					}
+				}
+				if err := eventExecutor.RestoreConfigChanges(); err != nil {
+					logrus.Warnf("Failed to restore config file changes: %v", err)
+				}
+				destroyStart := time.Now()
+				destroyCtx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+				defer cancel()
+				destroyErr := networkController.Destroy(destroyCtx)
+				if destroyErr != nil {
+					logrus.Errorf("Failed to destroy network: %v", destroyErr)
+				}
+				destroyRec := telemetry.Record{
+					Type:         telemetry.RecordDestroyDone,
+					Time:         time.Now(),
+					RunID:        task.RunID,
+					ScenarioPath: task.ScenarioPath,
+					Duration:     time.Since(destroyStart),
+				}
+				if destroyErr != nil {
+					destroyRec.Error = destroyErr.Error()
+				}
+				if err := r.sink().Publish(destroyCtx, destroyRec); err != nil {
+					logrus.Debugf("Telemetry publish failed: %v", err)
+				}
+			}()
+		}
 
 		// Setup tcpdump
 		for _, node := range scenario.Hosts {
-			if err := networkController.SetupTcpdump(node); err != nil {
+			if err := networkController.SetupTcpdump(ctx, node); err != nil {
 				return TaskRunnerResult{LogDir: logDir, Error: fmt.Errorf("failed to setup tcpdump on %s: %w", node, err)}
 			}
 		}
 
-		// Execute events and collect logs
+		// Watch for scenario.Hosts containers dying unexpectedly while events
+		// run, so a crashed node fails the run immediately instead of only
+		// showing up once the final logs are inspected. Not fatal if the
+		// watch can't be set up: the scenario still runs, just without this
+		// fail-fast.
+		if eventsCli, err := eventmon.NewClient(); err != nil {
+			logrus.Warnf("Unable to watch for unexpected container exits: %v", err)
+		} else {
+			watchCtx, cancel := context.WithCancelCause(ctx)
+			runCtx = watchCtx
+			watcher := eventmon.Watch(ctx, eventsCli, labName)
+			go r.watchUnexpectedExit(watcher, scenario, labName, eventsCli, logDir, cancel)
+			// Stop the watcher before Destroy (deferred above, so it runs
+			// after this) tears down the lab, so the containerlab destroy
+			// itself isn't mistaken for an unexpected exit.
+			defer func() {
+				watcher.Stop()
+				cancel(nil)
+			}()
+		}
+
+		// Execute events and collect logs, against a fresh context so a
+		// canceled ctx can't stop log/pcap collection from running.
 		defer func() {
-			if err := r.collectLogs(scenario, networkController, logDir); err != nil {
+			logCtx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+			defer cancel()
+			if err := r.collectLogs(logCtx, scenario, networkController, logDir); err != nil {
 				logrus.Warnf("Log collection failed: %v", err)
 			}
 		}()
@@ -143,52 +503,122 @@ func (r *ScenarioRunner) RunWithResult(task *Task, startTime time.Time) TaskRunn
 
 	// Create Docker client for Pumba (needed even in noDeploy mode for pumba events)
 	if noDeploy {
-		if err := network.CreateDockerClient(r.CLIContext); err != nil {
+		if err := network.CreateDockerClient(r.Docker); err != nil {
 			return TaskRunnerResult{LogDir: logDir, Error: fmt.Errorf("failed to create Docker client: %w", err)}
 		}
 	}
 
 	// Execute events
-	if err := r.executeEvents(scenario, eventExecutor); err != nil {
+	if err := r.executeEvents(runCtx, scenario, eventExecutor); err != nil {
+		if runCtx.Err() != nil {
+			if cause := context.Cause(runCtx); cause != nil && !errors.Is(cause, context.Canceled) {
+				return TaskRunnerResult{LogDir: logDir, Error: cause}
+			}
+		}
 		return TaskRunnerResult{LogDir: logDir, Error: fmt.Errorf("event execution failed: %w", err)}
 	}
 
 	return TaskRunnerResult{LogDir: logDir, Error: nil}
 }
 
-// loadScenarioAndDevices loads the scenario and device data from files.
-// This function is protected by a mutex because model.ReadYaml/ReadJsonScenar
-// and model.ReadJsonData use global variables (os.Args, model.Scenar, model.Devices).
-// Without the mutex, parallel scenario loading would cause race conditions.
-func (r *ScenarioRunner) loadScenarioAndDevices(task *Task) (*model.Scenario, *model.Data, error) {
-	scenarioLoadMu.Lock()
-	defer scenarioLoadMu.Unlock()
+// watchUnexpectedExit consumes watcher.Events until it closes, and on the
+// first die/oom/kill for one of scenario.Hosts's containers, dumps that
+// container's docker logs into logDir and cancels cause so executeEvents's
+// runCtx ends the run instead of waiting out the rest of the scenario's
+// duration.
+func (r *ScenarioRunner) watchUnexpectedExit(watcher *eventmon.Watcher, scenario *model.Scenario, labName string, cli eventmon.Client, logDir string, cancel context.CancelCauseFunc) {
+	containers := make(map[string]bool, len(scenario.Hosts))
+	for _, node := range scenario.Hosts {
+		containers["clab-"+labName+"-"+node] = true
+	}
 
-	// Set the scenario path for model package
-	os.Args = []string{"netroub", task.ScenarioPath}
+	for ev := range watcher.Events {
+		if !containers[ev.Container] {
+			continue
+		}
 
-	// Load scenario
-	if task.YAML {
-		if err := model.ReadYaml(); err != nil {
-			return nil, nil, err
+		dumpCtx, dumpCancel := context.WithTimeout(context.Background(), shutdownGrace)
+		if err := eventmon.DumpLogs(dumpCtx, cli, ev.Container, logDir); err != nil {
+			logrus.Warnf("Failed to dump logs for %s: %v", ev.Container, err)
 		}
+		dumpCancel()
+
+		cancel(fmt.Errorf("unexpected container exit: %s status=%s", ev.Container, ev.Status))
+		return
+	}
+}
+
+// deployNetworkController builds a NetworkController for scenario/devices
+// and deploys its topology, including creating the container runtime and
+// Pumba clients Deploy need. Returns the controller even on a Deploy
+// failure (so the caller can Destroy the partial lab before retrying), but
+// nil if the failure was before a controller could be built at all.
+func (r *ScenarioRunner) deployNetworkController(ctx context.Context, scenario *model.Scenario, devices *model.Data, labName string, cmdRunner runtime.CommandRunner, dockerEndpoint runtime.DockerEndpoint) (*network.NetworkController, error) {
+	containerRuntime, err := runtime.NewContainerRuntimeWithEndpoint(runtime.Engine(scenario.ContainerEngine), dockerEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container runtime: %w", err)
+	}
+	networkController := network.NewNetworkController(scenario, devices, labName, cmdRunner, containerRuntime)
+
+	// Create Docker client for Pumba
+	if err := network.CreateDockerClient(r.Docker); err != nil {
+		return nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+
+	if err := networkController.Deploy(ctx); err != nil {
+		return networkController, fmt.Errorf("failed to deploy network: %w", err)
+	}
+	return networkController, nil
+}
+
+// loadScenarioAndDevices parses the scenario and device data from files
+// through model's pure Parse* API, so Executor's parallel workers load
+// distinct scenarios concurrently without contending on package-level state.
+func (r *ScenarioRunner) loadScenarioAndDevices(task *Task) (*model.Scenario, *model.Data, error) {
+	vars := r.scenarioVars(task)
+
+	// Load scenario, substituting vars/env into the raw file before unmarshalling
+	var scenario *model.Scenario
+	var err error
+	if task.YAML {
+		scenario, err = model.ParseScenarioYAML(task.ScenarioPath, vars)
 	} else {
-		if err := model.ReadJsonScenar(); err != nil {
-			return nil, nil, err
-		}
+		scenario, err = model.ParseScenarioJSON(task.ScenarioPath, vars)
+	}
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Load device data (skip if no data file specified - noDeploy mode)
-	if model.Scenar.Data != "" {
-		if err := model.ReadJsonData(); err != nil {
+	devices := &model.Data{}
+	if scenario.Data != "" {
+		devices, err = model.ParseDataJSON(scenario.Data)
+		if err != nil {
 			return nil, nil, err
 		}
 	}
 
-	// Return copies to avoid global state issues
-	scenario := model.Scenar
-	devices := model.Devices
-	return &scenario, &devices, nil
+	return scenario, devices, nil
+}
+
+// scenarioVars builds the variable set available for envsubst substitution
+// in task's scenario file: --var CLI flags first, then implicit run-scoped
+// identifiers so a single scenario file can be swept across a Plan's
+// repetitions without duplicating it per run.
+func (r *ScenarioRunner) scenarioVars(task *Task) map[string]string {
+	vars := map[string]string{}
+
+	for _, kv := range r.Vars {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			vars[key] = value
+		}
+	}
+
+	vars["NETROUB_RUN_ID"] = task.RunID
+	vars["NETROUB_LAB_NAME"] = task.RunID
+	vars["NETROUB_RUN_INDEX"] = strconv.Itoa(task.Index)
+
+	return vars
 }
 
 // validateHosts validates that all hosts exist in the topology.
@@ -208,8 +638,11 @@ func validateHosts(scenario *model.Scenario, devices *model.Data) error {
 	return nil
 }
 
-// executeEvents executes all scenario events.
-func (r *ScenarioRunner) executeEvents(scenario *model.Scenario, executor *events.EventExecutor) error {
+// executeEvents executes all scenario events. Canceling ctx (e.g. Ctrl-C at
+// the CLI, or a ProgressTracker-driven abort) cuts short any event still
+// waiting on its begin time and is passed into executor.Execute, which stops
+// in-flight per-host fan-out once the current host finishes.
+func (r *ScenarioRunner) executeEvents(ctx context.Context, scenario *model.Scenario, executor *events.EventExecutor) error {
 	done := make(chan error, len(scenario.Event))
 
 	// Parse begin times
@@ -229,9 +662,15 @@ func (r *ScenarioRunner) executeEvents(scenario *model.Scenario, executor *event
 	// Execute events concurrently
 	for i := range scenario.Event {
 		go func(index int) {
-			time.Sleep(beginTimes[index])
-			err := executor.Execute(index)
-			done <- err
+			timer := time.NewTimer(beginTimes[index])
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				done <- ctx.Err()
+				return
+			}
+			done <- executor.Execute(ctx, index)
 		}(i)
 	}
 
@@ -247,8 +686,10 @@ func (r *ScenarioRunner) executeEvents(scenario *model.Scenario, executor *event
 	return lastError
 }
 
-// collectLogs collects logs from the scenario execution.
-func (r *ScenarioRunner) collectLogs(scenario *model.Scenario, controller *network.NetworkController, trialLogDir string) error {
+// collectLogs collects logs from the scenario execution. Callers invoke this
+// from a defer, typically with a fresh context (see shutdownGrace) rather
+// than the task's own ctx, so collection still runs after a cancellation.
+func (r *ScenarioRunner) collectLogs(ctx context.Context, scenario *model.Scenario, controller *network.NetworkController, trialLogDir string) error {
 	topoPath := filepath.Dir(scenario.Topo)
 
 	// Get initial file sizes for comparison
@@ -265,7 +706,7 @@ func (r *ScenarioRunner) collectLogs(scenario *model.Scenario, controller *netwo
 	}
 
 	// Collect tcpdump logs
-	if err := controller.CollectTcpdumpLogs(); err != nil {
+	if err := controller.CollectTcpdumpLogs(ctx); err != nil {
 		return fmt.Errorf("failed to collect tcpdump logs: %w", err)
 	}
 
@@ -281,4 +722,3 @@ func (r *ScenarioRunner) collectLogs(scenario *model.Scenario, controller *netwo
 
 	return nil
 }
-
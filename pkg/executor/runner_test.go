@@ -38,9 +38,10 @@ func createTestScenario(t *testing.T, dir, name, duration string) string {
 }
 
 // TestLoadScenarioAndDevices_Parallel tests that parallel scenario loading
-// returns the correct scenario for each goroutine.
-// This test verifies that the mutex protection in loadScenarioAndDevices
-// prevents race conditions when multiple goroutines load scenarios simultaneously.
+// returns the correct scenario for each goroutine. loadScenarioAndDevices
+// goes through model's pure Parse* API, so this also guards against a
+// regression back to package-level state that would serialize or corrupt
+// concurrent loads.
 //
 // Run with: go test -race ./pkg/executor/...
 func TestLoadScenarioAndDevices_Parallel(t *testing.T) {
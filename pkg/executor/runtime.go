@@ -0,0 +1,151 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// RuntimeKind identifies which container runtime backend a scenario or task
+// targets.
+type RuntimeKind string
+
+const (
+	// RuntimeContainerlab deploys topologies with containerlab on top of
+	// Docker. This is the default and the only backend netroub has ever
+	// supported.
+	RuntimeContainerlab RuntimeKind = "containerlab"
+
+	// RuntimeContainerd deploys topologies directly against a containerd
+	// daemon (via CRI/namespaces), for hosts that don't have Docker.
+	RuntimeContainerd RuntimeKind = "containerd"
+)
+
+// DefaultRuntimeKind is used when a scenario or plan does not specify one.
+const DefaultRuntimeKind = RuntimeContainerlab
+
+// Runtime abstracts the container runtime backend used to deploy and tear
+// down a scenario's topology, and to enumerate the resources a lab created.
+// GenerateLabNamesFromScenario/CleanContainers/CleanDockerNetworks do not go
+// through a Runtime: they take no runtime selector, and the cleanup helpers
+// always list and remove through the Docker Engine SDK.
+type Runtime interface {
+	// Deploy brings up the topology described by topoPath under labName.
+	Deploy(ctx context.Context, topoPath, labName string) error
+
+	// Destroy tears down the lab identified by labName.
+	Destroy(ctx context.Context, labName string) error
+
+	// ListContainers returns the container names belonging to labFilter.
+	ListContainers(ctx context.Context, labFilter string) ([]string, error)
+
+	// ListNetworks returns the network names belonging to labFilter.
+	ListNetworks(ctx context.Context, labFilter string) ([]string, error)
+}
+
+// NewRuntime returns the Runtime implementation for the given kind.
+func NewRuntime(kind RuntimeKind) (Runtime, error) {
+	switch kind {
+	case "", RuntimeContainerlab:
+		return NewContainerlabRuntime()
+	case RuntimeContainerd:
+		return NewContainerdRuntime()
+	default:
+		return nil, fmt.Errorf("unknown runtime kind %q", kind)
+	}
+}
+
+// ContainerlabRuntime deploys topologies with containerlab and enumerates
+// the resulting resources through the Docker Engine SDK.
+type ContainerlabRuntime struct {
+	docker dockerAPIClient
+}
+
+// NewContainerlabRuntime creates a ContainerlabRuntime backed by the Docker
+// daemon resolved from the environment.
+func NewContainerlabRuntime() (*ContainerlabRuntime, error) {
+	cli, err := newDockerClient()
+	if err != nil {
+		return nil, err
+	}
+	return &ContainerlabRuntime{docker: cli}, nil
+}
+
+// Deploy runs `containerlab deploy` for the given topology and lab name.
+func (r *ContainerlabRuntime) Deploy(ctx context.Context, topoPath, labName string) error {
+	_, err := runCommandContext(ctx, "sudo", "containerlab", "deploy", "--name", labName, "--topo", topoPath)
+	if err != nil {
+		return fmt.Errorf("containerlab deploy failed: %w", err)
+	}
+	return nil
+}
+
+// Destroy runs `containerlab destroy` for the given lab name.
+func (r *ContainerlabRuntime) Destroy(ctx context.Context, labName string) error {
+	_, err := runCommandContext(ctx, "sudo", "containerlab", "destroy", "--name", labName, "--cleanup")
+	if err != nil {
+		return fmt.Errorf("containerlab destroy failed: %w", err)
+	}
+	return nil
+}
+
+// ListContainers lists containers whose name is prefixed "clab-<labFilter>-".
+func (r *ContainerlabRuntime) ListContainers(ctx context.Context, labFilter string) ([]string, error) {
+	return listDockerContainerNames(ctx, r.docker, labFilter)
+}
+
+// ListNetworks lists networks named "clab-<labFilter>".
+func (r *ContainerlabRuntime) ListNetworks(ctx context.Context, labFilter string) ([]string, error) {
+	return listDockerNetworkNames(ctx, r.docker, labFilter)
+}
+
+// runCommandContext runs a command to completion, returning its combined
+// output and an error including that output on failure.
+func runCommandContext(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return output, fmt.Errorf("%w, output: %s", err, strings.TrimSpace(string(output)))
+	}
+	return output, nil
+}
+
+// listDockerContainerNames lists the names of containers prefixed
+// "clab-<labFilter>-".
+func listDockerContainerNames(ctx context.Context, cli dockerAPIClient, labFilter string) ([]string, error) {
+	f := filters.NewArgs()
+	f.Add("name", fmt.Sprintf("clab-%s-", labFilter))
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: f})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	names := make([]string, 0, len(containers))
+	for _, c := range containers {
+		names = append(names, containerDisplayName(c))
+	}
+	return names, nil
+}
+
+// listDockerNetworkNames lists networks named exactly "clab-<labFilter>".
+func listDockerNetworkNames(ctx context.Context, cli dockerAPIClient, labFilter string) ([]string, error) {
+	want := "clab-" + labFilter
+	f := filters.NewArgs()
+	f.Add("name", want)
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: f})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	var names []string
+	for _, n := range networks {
+		if n.Name == want {
+			names = append(names, n.Name)
+		}
+	}
+	return names, nil
+}
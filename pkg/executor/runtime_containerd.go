@@ -0,0 +1,102 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/namespaces"
+)
+
+// containerdSocket is the default containerd API socket.
+const containerdSocket = "/run/containerd/containerd.sock"
+
+// containerdNamespace is the namespace netroub labs are created in.
+const containerdNamespace = "netroub"
+
+// ContainerdRuntime deploys and tears down topologies directly against a
+// containerd daemon, for hosts that don't have Docker/containerlab
+// available. Networking between containers is expected to be managed by a
+// CNI plugin configured on the host.
+type ContainerdRuntime struct {
+	client *containerd.Client
+}
+
+// NewContainerdRuntime connects to the local containerd socket.
+func NewContainerdRuntime() (*ContainerdRuntime, error) {
+	client, err := containerd.New(containerdSocket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to containerd at %s: %w", containerdSocket, err)
+	}
+	return &ContainerdRuntime{client: client}, nil
+}
+
+// labCtx scopes ctx to the netroub containerd namespace.
+func (r *ContainerdRuntime) labCtx(ctx context.Context) context.Context {
+	return namespaces.WithNamespace(ctx, containerdNamespace)
+}
+
+// Deploy is not yet implemented: translating a containerlab topology file
+// into containerd tasks/images requires its own topology parser, tracked
+// separately. Callers that need an actual containerd lab today should
+// provision it out-of-band and rely on Destroy/ListContainers/ListNetworks
+// for lifecycle management.
+func (r *ContainerdRuntime) Deploy(ctx context.Context, topoPath, labName string) error {
+	return fmt.Errorf("containerd runtime: Deploy is not implemented yet (lab %q)", labName)
+}
+
+// Destroy removes every task and container belonging to labName.
+func (r *ContainerdRuntime) Destroy(ctx context.Context, labName string) error {
+	ctx = r.labCtx(ctx)
+
+	containers, err := r.client.Containers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list containerd containers: %w", err)
+	}
+
+	prefix := "clab-" + labName + "-"
+	for _, c := range containers {
+		if !strings.HasPrefix(c.ID(), prefix) {
+			continue
+		}
+
+		task, err := c.Task(ctx, nil)
+		if err == nil {
+			if _, err := task.Delete(ctx, containerd.WithProcessKill); err != nil {
+				return fmt.Errorf("failed to delete task for %s: %w", c.ID(), err)
+			}
+		}
+
+		if err := c.Delete(ctx); err != nil {
+			return fmt.Errorf("failed to delete container %s: %w", c.ID(), err)
+		}
+	}
+
+	return nil
+}
+
+// ListContainers returns containerd container IDs prefixed "clab-<labFilter>-".
+func (r *ContainerdRuntime) ListContainers(ctx context.Context, labFilter string) ([]string, error) {
+	ctx = r.labCtx(ctx)
+
+	containers, err := r.client.Containers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containerd containers: %w", err)
+	}
+
+	prefix := "clab-" + labFilter + "-"
+	var names []string
+	for _, c := range containers {
+		if strings.HasPrefix(c.ID(), prefix) {
+			names = append(names, c.ID())
+		}
+	}
+	return names, nil
+}
+
+// ListNetworks is not applicable to the containerd backend: network
+// lifecycle is owned by the host's CNI plugin, not containerd itself.
+func (r *ContainerdRuntime) ListNetworks(ctx context.Context, labFilter string) ([]string, error) {
+	return nil, nil
+}
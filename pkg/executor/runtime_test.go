@@ -0,0 +1,43 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerlabRuntime_ListContainers(t *testing.T) {
+	fake := &fakeDockerClient{
+		containers: []types.Container{
+			{ID: "c1", Names: []string{"/clab-baseline_001-r1"}},
+			{ID: "c2", Names: []string{"/clab-baseline_001-r2"}},
+		},
+	}
+	rt := &ContainerlabRuntime{docker: fake}
+
+	names, err := rt.ListContainers(context.Background(), "baseline_001")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"clab-baseline_001-r1", "clab-baseline_001-r2"}, names)
+}
+
+func TestContainerlabRuntime_ListNetworks(t *testing.T) {
+	fake := &fakeDockerClient{
+		networks: []types.NetworkResource{
+			{ID: "n1", Name: "clab-baseline_001"},
+			{ID: "n2", Name: "clab-baseline_001-extra"},
+		},
+	}
+	rt := &ContainerlabRuntime{docker: fake}
+
+	names, err := rt.ListNetworks(context.Background(), "baseline_001")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"clab-baseline_001"}, names)
+}
+
+func TestNewRuntime_UnknownKind(t *testing.T) {
+	_, err := NewRuntime(RuntimeKind("bogus"))
+	assert.Error(t, err)
+}
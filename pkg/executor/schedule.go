@@ -0,0 +1,247 @@
+package executor
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// ScheduleKind selects the order (and, for ScheduleTimeBudget, the stopping
+// condition) in which a Plan's expanded scenarios are turned into tasks.
+type ScheduleKind string
+
+const (
+	// ScheduleSequential runs each expanded ScenarioEntry's Repeat tasks in
+	// declaration order. This is the default and netroub's original
+	// behavior.
+	ScheduleSequential ScheduleKind = "sequential"
+
+	// ScheduleShuffled generates the same tasks ScheduleSequential would,
+	// then Fisher-Yates shuffles them using Plan.Seed.
+	ScheduleShuffled ScheduleKind = "shuffled"
+
+	// ScheduleWeighted treats each ScenarioEntry's Repeat as a cap on the
+	// plan's total run count rather than an exact per-entry count: it
+	// samples entries with replacement, proportional to ScenarioEntry.Weight
+	// (default 1), until that total is reached.
+	ScheduleWeighted ScheduleKind = "weighted"
+
+	// ScheduleTimeBudget samples entries the same way ScheduleWeighted does,
+	// but keeps sampling until Plan.TimeBudget's wall-clock duration elapses
+	// instead of a fixed count. Because the total isn't known in advance,
+	// it's only available via GenerateTaskIteratorFromPlan, not
+	// GenerateTasksFromPlan.
+	ScheduleTimeBudget ScheduleKind = "time_budget"
+)
+
+// DefaultScheduleKind is used when a plan does not specify one.
+const DefaultScheduleKind = ScheduleSequential
+
+// scheduleTasks turns expanded (the result of Plan.ExpandScenarios) into
+// tasks per plan.Schedule. ScheduleTimeBudget is rejected here since its
+// task stream is unbounded; use GenerateTaskIteratorFromPlan for it instead.
+func scheduleTasks(plan *Plan, expanded []ScenarioEntry) ([]*Task, error) {
+	if err := resolveEntryRetries(plan, expanded); err != nil {
+		return nil, err
+	}
+
+	switch plan.Schedule {
+	case "", ScheduleSequential:
+		return sequentialTasks(expanded), nil
+	case ScheduleShuffled:
+		tasks := sequentialTasks(expanded)
+		shuffleTasks(tasks, plan.Seed)
+		return tasks, nil
+	case ScheduleWeighted:
+		return weightedTasks(expanded, plan.Seed), nil
+	case ScheduleTimeBudget:
+		return nil, fmt.Errorf("schedule %q produces an unbounded task stream; use GenerateTaskIteratorFromPlan instead of GenerateTasksFromPlan", plan.Schedule)
+	default:
+		return nil, fmt.Errorf("unknown schedule %q", plan.Schedule)
+	}
+}
+
+// resolveEntryRetries replaces each expanded entry's Retry with the
+// effectiveRetry policy computed from its (or plan's) Retries/RetryBackoff/
+// RetryBackoffFactor/RetryOn, in place, so sequentialTasks/weightedTasks/
+// taskForEntrySample only ever need to look at entry.Retry.
+func resolveEntryRetries(plan *Plan, expanded []ScenarioEntry) error {
+	for i, entry := range expanded {
+		policy, err := effectiveRetry(entry, plan)
+		if err != nil {
+			return fmt.Errorf("scenario %q: %w", entry.Pattern, err)
+		}
+		expanded[i].Retry = policy
+	}
+	return nil
+}
+
+// sequentialTasks is GenerateTasksFromPlan's original behavior: every
+// expanded entry's Repeat tasks, generated and appended in declaration
+// order.
+func sequentialTasks(expanded []ScenarioEntry) []*Task {
+	var allTasks []*Task
+	for _, entry := range expanded {
+		rt := entry.Runtime
+		if rt == "" {
+			rt = DefaultRuntimeKind
+		}
+		tasks := GenerateTasksWithRuntime(entry.Pattern, entry.Repeat, entry.YAML, rt)
+		for _, task := range tasks {
+			if entry.Retry != nil {
+				task.Retry = entry.Retry
+			}
+			if entry.Params != nil {
+				task.Params = entry.Params
+			}
+			task.Host = entry.Host
+		}
+		allTasks = append(allTasks, tasks...)
+	}
+	return allTasks
+}
+
+// shuffleTasks Fisher-Yates shuffles tasks in place, using seed so the same
+// plan always shuffles the same way.
+func shuffleTasks(tasks []*Task, seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	for i := len(tasks) - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		tasks[i], tasks[j] = tasks[j], tasks[i]
+	}
+}
+
+// weightedTasks samples expanded's entries with replacement, proportional to
+// weight, until the sum of every entry's Repeat (the plan's total run cap)
+// is reached.
+func weightedTasks(expanded []ScenarioEntry, seed int64) []*Task {
+	totalRuns := 0
+	for _, entry := range expanded {
+		totalRuns += entry.Repeat
+	}
+
+	weights := entryWeights(expanded)
+	rng := rand.New(rand.NewSource(seed))
+	counters := make([]int, len(expanded))
+
+	tasks := make([]*Task, 0, totalRuns)
+	for i := 0; i < totalRuns; i++ {
+		idx := weightedSample(weights, rng)
+		counters[idx]++
+		tasks = append(tasks, taskForEntrySample(expanded[idx], counters[idx]))
+	}
+	return tasks
+}
+
+// entryWeights returns each entry's sampling weight, defaulting to 1 for an
+// entry with no (or non-positive) Weight set.
+func entryWeights(expanded []ScenarioEntry) []float64 {
+	weights := make([]float64, len(expanded))
+	for i, entry := range expanded {
+		if entry.Weight > 0 {
+			weights[i] = entry.Weight
+		} else {
+			weights[i] = 1
+		}
+	}
+	return weights
+}
+
+// weightedSample picks an index into weights with probability proportional
+// to its value.
+func weightedSample(weights []float64, rng *rand.Rand) int {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+
+	r := rng.Float64() * total
+	var cumulative float64
+	for i, w := range weights {
+		cumulative += w
+		if r < cumulative {
+			return i
+		}
+	}
+	return len(weights) - 1
+}
+
+// taskForEntrySample generates the index'th task sampled from entry
+// (ScheduleWeighted/ScheduleTimeBudget generate tasks one sample at a time,
+// rather than Repeat-many up front like ScheduleSequential).
+func taskForEntrySample(entry ScenarioEntry, index int) *Task {
+	rt := entry.Runtime
+	if rt == "" {
+		rt = DefaultRuntimeKind
+	}
+
+	task := &Task{
+		ScenarioPath: entry.Pattern,
+		RunID:        fmt.Sprintf("%s_%03d", extractScenarioName(entry.Pattern), index),
+		Index:        index,
+		YAML:         entry.YAML,
+		Runtime:      rt,
+		Host:         entry.Host,
+	}
+	if entry.Retry != nil {
+		task.Retry = entry.Retry
+	}
+	if entry.Params != nil {
+		task.Params = entry.Params
+	}
+	return task
+}
+
+// TaskIterator yields tasks one at a time for schedules whose total task
+// count isn't known ahead of time (ScheduleTimeBudget). Next returns
+// ok=false once the schedule has nothing left to give.
+type TaskIterator struct {
+	next func() (*Task, bool)
+}
+
+// Next returns the next task the schedule produces, or ok=false once it's
+// exhausted (for ScheduleTimeBudget, once the time budget has elapsed).
+func (it *TaskIterator) Next() (task *Task, ok bool) {
+	return it.next()
+}
+
+// GenerateTaskIteratorFromPlan is GenerateTasksFromPlan for
+// ScheduleTimeBudget: rather than materializing every task up front (the
+// total isn't known in advance), it returns a TaskIterator that samples one
+// task at a time, proportional to each entry's Weight, until
+// plan.TimeBudget's wall-clock duration elapses since this call.
+func GenerateTaskIteratorFromPlan(plan *Plan, baseDir string) (*TaskIterator, error) {
+	if plan.Schedule != ScheduleTimeBudget {
+		return nil, fmt.Errorf("GenerateTaskIteratorFromPlan requires schedule %q, got %q", ScheduleTimeBudget, plan.Schedule)
+	}
+
+	budget, err := time.ParseDuration(plan.TimeBudget)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeBudget %q: %w", plan.TimeBudget, err)
+	}
+
+	expanded, err := plan.ExpandScenarios(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(expanded) == 0 {
+		return nil, fmt.Errorf("plan has no scenarios to schedule")
+	}
+	if err := resolveEntryRetries(plan, expanded); err != nil {
+		return nil, err
+	}
+
+	weights := entryWeights(expanded)
+	rng := rand.New(rand.NewSource(plan.Seed))
+	counters := make([]int, len(expanded))
+	deadline := time.Now().Add(budget)
+
+	return &TaskIterator{next: func() (*Task, bool) {
+		if time.Now().After(deadline) {
+			return nil, false
+		}
+		idx := weightedSample(weights, rng)
+		counters[idx]++
+		return taskForEntrySample(expanded[idx], counters[idx]), true
+	}}, nil
+}
@@ -0,0 +1,181 @@
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTasksFromPlan_Sequential(t *testing.T) {
+	tmpDir := t.TempDir()
+	plan := &Plan{
+		Scenarios: []ScenarioEntry{
+			{Pattern: "A1.json", Repeat: 3, Runtime: DefaultRuntimeKind},
+			{Pattern: "A2.json", Repeat: 2, Runtime: DefaultRuntimeKind},
+		},
+	}
+
+	tasks, err := GenerateTasksFromPlan(plan, tmpDir)
+	require.NoError(t, err)
+	require.Len(t, tasks, 5)
+	assert.Equal(t, "A1_001", tasks[0].RunID)
+	assert.Equal(t, "A1_003", tasks[2].RunID)
+	assert.Equal(t, "A2_001", tasks[3].RunID)
+}
+
+func TestGenerateTasksFromPlan_RetryDefaults(t *testing.T) {
+	tmpDir := t.TempDir()
+	plan := &Plan{
+		Retries:      2,
+		RetryBackoff: "1s",
+		Scenarios: []ScenarioEntry{
+			{Pattern: "A1.json", Repeat: 1, Runtime: DefaultRuntimeKind},
+			{Pattern: "A2.json", Repeat: 1, Runtime: DefaultRuntimeKind, Retries: 5, RetryBackoff: "2s"},
+			{Pattern: "A3.json", Repeat: 1, Runtime: DefaultRuntimeKind, Retry: &RetryPolicy{MaxAttempts: 9}},
+		},
+	}
+
+	tasks, err := GenerateTasksFromPlan(plan, tmpDir)
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+
+	require.NotNil(t, tasks[0].Retry, "entry with no retry config inherits the plan's defaults")
+	assert.Equal(t, 3, tasks[0].Retry.MaxAttempts)
+	assert.Equal(t, time.Second, tasks[0].Retry.InitialBackoff)
+
+	require.NotNil(t, tasks[1].Retry)
+	assert.Equal(t, 6, tasks[1].Retry.MaxAttempts, "entry's own Retries overrides the plan default")
+	assert.Equal(t, 2*time.Second, tasks[1].Retry.InitialBackoff)
+
+	require.NotNil(t, tasks[2].Retry)
+	assert.Equal(t, 9, tasks[2].Retry.MaxAttempts, "explicit Retry is untouched by the plan's defaults")
+}
+
+func TestGenerateTasksFromPlan_Shuffled_DeterministicBySeed(t *testing.T) {
+	tmpDir := t.TempDir()
+	plan := &Plan{
+		Schedule: ScheduleShuffled,
+		Seed:     42,
+		Scenarios: []ScenarioEntry{
+			{Pattern: "A1.json", Repeat: 10, Runtime: DefaultRuntimeKind},
+		},
+	}
+
+	tasksA, err := GenerateTasksFromPlan(plan, tmpDir)
+	require.NoError(t, err)
+	tasksB, err := GenerateTasksFromPlan(plan, tmpDir)
+	require.NoError(t, err)
+
+	require.Len(t, tasksA, 10)
+	for i := range tasksA {
+		assert.Equal(t, tasksA[i].RunID, tasksB[i].RunID)
+	}
+
+	// The shuffle should actually reorder something relative to sequential.
+	sequential, err := GenerateTasksFromPlan(&Plan{Scenarios: plan.Scenarios}, tmpDir)
+	require.NoError(t, err)
+	reordered := false
+	for i := range tasksA {
+		if tasksA[i].RunID != sequential[i].RunID {
+			reordered = true
+			break
+		}
+	}
+	assert.True(t, reordered, "expected shuffled order to differ from sequential order")
+}
+
+func TestGenerateTasksFromPlan_Weighted_TotalMatchesRepeatSum(t *testing.T) {
+	tmpDir := t.TempDir()
+	plan := &Plan{
+		Schedule: ScheduleWeighted,
+		Seed:     1,
+		Scenarios: []ScenarioEntry{
+			{Pattern: "heavy.json", Repeat: 80, Weight: 9, Runtime: DefaultRuntimeKind},
+			{Pattern: "light.json", Repeat: 20, Weight: 1, Runtime: DefaultRuntimeKind},
+		},
+	}
+
+	tasks, err := GenerateTasksFromPlan(plan, tmpDir)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 100) // still capped at the sum of Repeat
+
+	counts := map[string]int{}
+	for _, task := range tasks {
+		counts[task.ScenarioPath]++
+	}
+	// heavy.json has 9x light.json's weight, so it should dominate the sample.
+	assert.Greater(t, counts["heavy.json"], counts["light.json"])
+}
+
+func TestGenerateTasksFromPlan_TimeBudgetRejected(t *testing.T) {
+	tmpDir := t.TempDir()
+	plan := &Plan{
+		Schedule:   ScheduleTimeBudget,
+		TimeBudget: "1s",
+		Scenarios: []ScenarioEntry{
+			{Pattern: "A1.json", Repeat: 5, Runtime: DefaultRuntimeKind},
+		},
+	}
+
+	_, err := GenerateTasksFromPlan(plan, tmpDir)
+	assert.Error(t, err)
+}
+
+func TestGenerateTaskIteratorFromPlan(t *testing.T) {
+	tmpDir := t.TempDir()
+	plan := &Plan{
+		Schedule:   ScheduleTimeBudget,
+		TimeBudget: "50ms",
+		Seed:       7,
+		Scenarios: []ScenarioEntry{
+			{Pattern: "A1.json", Repeat: 1, Runtime: DefaultRuntimeKind},
+		},
+	}
+
+	it, err := GenerateTaskIteratorFromPlan(plan, tmpDir)
+	require.NoError(t, err)
+
+	var n int
+	for {
+		task, ok := it.Next()
+		if !ok {
+			break
+		}
+		assert.Equal(t, "A1.json", task.ScenarioPath)
+		n++
+		if n > 100000 {
+			t.Fatal("iterator did not stop within a reasonable number of samples")
+		}
+	}
+	assert.Greater(t, n, 0)
+}
+
+func TestGenerateTaskIteratorFromPlan_WrongSchedule(t *testing.T) {
+	plan := &Plan{Scenarios: []ScenarioEntry{{Pattern: "A1.json", Repeat: 1}}}
+	_, err := GenerateTaskIteratorFromPlan(plan, t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestPlanSummary_TimeBudgetReportsUnknownTotal(t *testing.T) {
+	plan := &Plan{
+		Schedule: ScheduleTimeBudget,
+		Scenarios: []ScenarioEntry{
+			{Pattern: "A1.json", Repeat: 50},
+		},
+	}
+	scenarios, totalRuns := plan.Summary()
+	assert.Equal(t, 1, scenarios)
+	assert.Equal(t, -1, totalRuns)
+}
+
+func TestShuffleTasks_PreservesElements(t *testing.T) {
+	tasks := []*Task{{RunID: "a"}, {RunID: "b"}, {RunID: "c"}, {RunID: "d"}}
+	shuffleTasks(tasks, time.Now().UnixNano())
+	ids := map[string]bool{}
+	for _, task := range tasks {
+		ids[task.RunID] = true
+	}
+	assert.Len(t, ids, 4)
+}
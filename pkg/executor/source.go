@@ -0,0 +1,217 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// SourceSpec is a parsed ScenarioEntry.Pattern that points at a remote
+// scenario source rather than a local glob.
+type SourceSpec struct {
+	// Scheme is "git", "http" (covers https too), or "s3".
+	Scheme string
+	// Fetch is the scheme-specific location to hand to the SourceFetcher:
+	// a repo URL for git, a full URL for http, or "bucket/key" for s3.
+	Fetch string
+	// Ref is a git tag/branch/commit to check out, parsed off a trailing
+	// "@ref". Empty means the repo's default branch.
+	Ref string
+	// SubPath is, for git, the path within the repo to glob (from a
+	// "//subpath" segment); for http/s3, the glob pattern to apply within
+	// the fetched archive (from a "#pattern" fragment). Defaults to "*".
+	SubPath string
+}
+
+// remoteSchemes are the ScenarioEntry.Pattern prefixes ParseSourceSpec
+// recognizes as remote sources rather than local globs.
+var remoteSchemes = map[string]string{
+	"git":       "git",
+	"git+https": "git",
+	"git+http":  "git",
+	"git+ssh":   "git",
+	"http":      "http",
+	"https":     "http",
+	"s3":        "s3",
+}
+
+// ParseSourceSpec parses pattern as a remote source spec, mirroring how
+// Docker's builder resolves remote build contexts:
+//
+//	git+https://example.com/repo.git//scenarios/A*.json@v1.2
+//	https://example.com/pack.tar.gz#A*_*.json
+//	s3://bucket/scenarios.tar.gz#A*_*.json
+//
+// It returns ok=false for anything without a recognized scheme, so plain
+// local paths/globs keep working exactly as before.
+func ParseSourceSpec(pattern string) (spec SourceSpec, ok bool) {
+	idx := strings.Index(pattern, "://")
+	if idx < 0 {
+		return SourceSpec{}, false
+	}
+	scheme, ok := remoteSchemes[pattern[:idx]]
+	if !ok {
+		return SourceSpec{}, false
+	}
+	rest := pattern[idx+len("://"):]
+
+	switch scheme {
+	case "git":
+		vcsScheme := strings.TrimPrefix(pattern[:idx], "git+")
+		repoURL := vcsScheme + "://" + rest
+		subPath := ""
+		if i := strings.Index(rest, "//"); i >= 0 {
+			repoURL = vcsScheme + "://" + rest[:i]
+			subPath = rest[i+len("//"):]
+		}
+		ref := ""
+		if at := strings.LastIndex(subPath, "@"); at >= 0 {
+			ref = subPath[at+1:]
+			subPath = subPath[:at]
+		}
+		if subPath == "" {
+			subPath = "*"
+		}
+		return SourceSpec{Scheme: "git", Fetch: repoURL, Ref: ref, SubPath: subPath}, true
+
+	case "http":
+		url := pattern[:idx] + "://" + rest
+		glob := "*"
+		if h := strings.Index(url, "#"); h >= 0 {
+			glob = url[h+1:]
+			url = url[:h]
+		}
+		// A "sha256=<hex>" fragment stays on Fetch for httpSourceFetcher to
+		// verify; a glob may follow it after a second "#".
+		if strings.HasPrefix(glob, "sha256=") {
+			checksum, after, hasGlob := strings.Cut(glob, "#")
+			url += "#" + checksum
+			glob = "*"
+			if hasGlob {
+				glob = after
+			}
+		}
+		return SourceSpec{Scheme: "http", Fetch: url, SubPath: glob}, true
+
+	case "s3":
+		loc := rest
+		glob := "*"
+		if h := strings.Index(loc, "#"); h >= 0 {
+			glob = loc[h+1:]
+			loc = loc[:h]
+		}
+		return SourceSpec{Scheme: "s3", Fetch: loc, SubPath: glob}, true
+	}
+
+	return SourceSpec{}, false
+}
+
+// SourceFetcher materializes a remote scenario source onto local disk.
+// Implementations: gitSourceFetcher, httpSourceFetcher, s3SourceFetcher.
+type SourceFetcher interface {
+	// Fetch resolves spec into a local directory containing its tree/archive
+	// contents. cleanup removes any temporary state Fetch created that
+	// outlives the cache (e.g. a checkout's lock); it is non-nil when err is
+	// nil, and the implementations return a nil cleanup alongside an error.
+	Fetch(ctx context.Context, spec SourceSpec) (localDir string, cleanup func(), err error)
+}
+
+// NewSourceFetcher returns the SourceFetcher for scheme ("git", "http", or
+// "s3", as produced by ParseSourceSpec).
+func NewSourceFetcher(scheme string) (SourceFetcher, error) {
+	switch scheme {
+	case "git":
+		return &gitSourceFetcher{}, nil
+	case "http":
+		return &httpSourceFetcher{}, nil
+	case "s3":
+		return &s3SourceFetcher{}, nil
+	default:
+		return nil, fmt.Errorf("unknown remote source scheme %q", scheme)
+	}
+}
+
+// sourceCacheDir returns the directory remote sources are cached under:
+// $XDG_CACHE_HOME/netroub/sources, or $HOME/.cache/netroub/sources if
+// XDG_CACHE_HOME is unset.
+func sourceCacheDir() (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("resolving cache dir: %w", err)
+		}
+		base = filepath.Join(home, ".cache")
+	}
+	dir := filepath.Join(base, "netroub", "sources")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating cache dir %s: %w", dir, err)
+	}
+	return dir, nil
+}
+
+// cacheKeyDir returns the cache subdirectory a given remote fetch key
+// (typically a hash of its URL+ref) should be materialized into.
+func cacheKeyDir(key string) (string, error) {
+	cacheDir, err := sourceCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, key), nil
+}
+
+// lockCacheDir takes an exclusive, advisory flock on dir+".lock" so parallel
+// workers fetching the same remote source serialize instead of racing to
+// populate (or corrupt) the same cache directory. The returned release must
+// be called once the caller is done with dir.
+func lockCacheDir(dir string) (release func() error, err error) {
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return nil, fmt.Errorf("creating cache parent dir: %w", err)
+	}
+	lockPath := dir + ".lock"
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening cache lock %s: %w", lockPath, err)
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("locking cache dir %s: %w", dir, err)
+	}
+	return func() error {
+		defer f.Close()
+		return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+	}, nil
+}
+
+// gitAuthToken returns the token to authenticate git fetches with, set via
+// NETROUB_GIT_TOKEN.
+func gitAuthToken() string { return os.Getenv("NETROUB_GIT_TOKEN") }
+
+// httpAuthToken returns the bearer token to authenticate http(s) fetches
+// with, set via NETROUB_HTTP_TOKEN.
+func httpAuthToken() string { return os.Getenv("NETROUB_HTTP_TOKEN") }
+
+// fetchTimeout bounds how long a single remote source fetch may take.
+const fetchTimeout = 5 * time.Minute
+
+// fetchSource resolves spec via its matching SourceFetcher and returns the
+// local directory it materialized into. The fetched cache dir is left in
+// place (ExpandScenarios globs files out of it after this returns), so the
+// fetcher's cleanup is invoked immediately; all three SourceFetcher
+// implementations only use it for fetch-scoped state, not the cache dir
+// itself.
+func fetchSource(spec SourceSpec) (string, error) {
+	fetcher, err := NewSourceFetcher(spec.Scheme)
+	if err != nil {
+		return "", err
+	}
+	localDir, cleanup, err := fetcher.Fetch(context.Background(), spec)
+	if cleanup != nil {
+		cleanup()
+	}
+	return localDir, err
+}
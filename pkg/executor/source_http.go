@@ -0,0 +1,197 @@
+package executor
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// httpSourceFetcher downloads a tarball (.tar.gz/.tgz) or zip archive and
+// extracts it into the cache, optionally verifying its contents against a
+// "#sha256=..." checksum fragment on spec.Fetch.
+type httpSourceFetcher struct{}
+
+func (f *httpSourceFetcher) Fetch(ctx context.Context, spec SourceSpec) (string, func(), error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	url, wantSum := splitChecksumFragment(spec.Fetch)
+
+	dir, err := cacheKeyDir(httpCacheKey(url, wantSum))
+	if err != nil {
+		return "", nil, err
+	}
+	release, err := lockCacheDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	defer release()
+
+	// A populated cache dir for this exact (url, checksum) pair is reused
+	// as-is; only an empty/missing one triggers a download.
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return dir, func() {}, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("building request for %s: %w", url, err)
+	}
+	if token := httpAuthToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("fetching %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("fetching %s: unexpected status %s", url, resp.Status)
+	}
+
+	archive, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading %s: %w", url, err)
+	}
+	if wantSum != "" {
+		sum := sha256.Sum256(archive)
+		if got := hex.EncodeToString(sum[:]); got != wantSum {
+			return "", nil, fmt.Errorf("checksum mismatch for %s: got sha256=%s, want %s", url, got, wantSum)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", nil, fmt.Errorf("creating cache dir %s: %w", dir, err)
+	}
+	if strings.HasSuffix(url, ".zip") {
+		err = extractZip(archive, dir)
+	} else {
+		err = extractTarGz(archive, dir)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("extracting %s: %w", url, err)
+	}
+
+	return dir, func() {}, nil
+}
+
+// splitChecksumFragment splits a "#sha256=..." fragment off url, returning
+// the bare URL and the expected hex-encoded checksum (empty if absent).
+func splitChecksumFragment(url string) (bareURL, sha256Sum string) {
+	h := strings.Index(url, "#")
+	if h < 0 {
+		return url, ""
+	}
+	fragment := url[h+1:]
+	bareURL = url[:h]
+	if sum, ok := strings.CutPrefix(fragment, "sha256="); ok {
+		return bareURL, sum
+	}
+	return bareURL, ""
+}
+
+func httpCacheKey(url, checksum string) string {
+	sum := sha256.Sum256([]byte(url + "#" + checksum))
+	return "http-" + hex.EncodeToString(sum[:])[:16]
+}
+
+func extractTarGz(archive []byte, dir string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return fmt.Errorf("opening gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target, err := safeJoin(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode))
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return err
+			}
+			out.Close()
+		}
+	}
+}
+
+func extractZip(archive []byte, dir string) error {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return fmt.Errorf("opening zip archive: %w", err)
+	}
+	for _, f := range zr.File {
+		target, err := safeJoin(dir, f.Name)
+		if err != nil {
+			return err
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, f.Mode())
+		if err != nil {
+			rc.Close()
+			return err
+		}
+		_, copyErr := io.Copy(out, rc)
+		out.Close()
+		rc.Close()
+		if copyErr != nil {
+			return copyErr
+		}
+	}
+	return nil
+}
+
+// safeJoin joins dir and name, rejecting an archive entry that would
+// traverse outside dir (a zip-slip/tar-slip path).
+func safeJoin(dir, name string) (string, error) {
+	target := filepath.Join(dir, name)
+	if target != dir && !strings.HasPrefix(target, dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("archive entry %q escapes extraction dir", name)
+	}
+	return target, nil
+}
@@ -0,0 +1,86 @@
+package executor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3SourceFetcher downloads an archive (tar.gz or zip, matched by key's
+// extension, same as httpSourceFetcher) from S3 and extracts it into the
+// cache. spec.Fetch is "bucket/key".
+type s3SourceFetcher struct{}
+
+func (f *s3SourceFetcher) Fetch(ctx context.Context, spec SourceSpec) (string, func(), error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	bucket, key, ok := strings.Cut(spec.Fetch, "/")
+	if !ok {
+		return "", nil, fmt.Errorf("invalid s3 source %q, expected bucket/key", spec.Fetch)
+	}
+
+	dir, err := cacheKeyDir(s3CacheKey(spec.Fetch))
+	if err != nil {
+		return "", nil, err
+	}
+	release, err := lockCacheDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	defer release()
+
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return dir, func() {}, nil
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	if region := os.Getenv("NETROUB_S3_REGION"); region != "" {
+		cfg.Region = region
+	}
+
+	client := s3.NewFromConfig(cfg)
+	out, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("fetching s3://%s/%s: %w", bucket, key, err)
+	}
+	defer out.Body.Close()
+
+	archive, err := io.ReadAll(out.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading s3://%s/%s: %w", bucket, key, err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", nil, fmt.Errorf("creating cache dir %s: %w", dir, err)
+	}
+	if strings.HasSuffix(key, ".zip") {
+		err = extractZip(archive, dir)
+	} else {
+		err = extractTarGz(archive, dir)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("extracting s3://%s/%s: %w", bucket, key, err)
+	}
+
+	return dir, func() {}, nil
+}
+
+func s3CacheKey(bucketKey string) string {
+	sum := sha256.Sum256([]byte(bucketKey))
+	return "s3-" + hex.EncodeToString(sum[:])[:16]
+}
@@ -0,0 +1,64 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSourceSpec_Local(t *testing.T) {
+	_, ok := ParseSourceSpec("scenarios/A1_delay_pause.json")
+	assert.False(t, ok)
+
+	_, ok = ParseSourceSpec("A*_*.json")
+	assert.False(t, ok)
+}
+
+func TestParseSourceSpec_Git(t *testing.T) {
+	spec, ok := ParseSourceSpec("git+https://example.com/repo.git//scenarios/A*.json@v1.2")
+	assert.True(t, ok)
+	assert.Equal(t, "git", spec.Scheme)
+	assert.Equal(t, "https://example.com/repo.git", spec.Fetch)
+	assert.Equal(t, "scenarios/A*.json", spec.SubPath)
+	assert.Equal(t, "v1.2", spec.Ref)
+}
+
+func TestParseSourceSpec_Git_NoSubPathOrRef(t *testing.T) {
+	spec, ok := ParseSourceSpec("git://example.com/repo.git")
+	assert.True(t, ok)
+	assert.Equal(t, "git", spec.Scheme)
+	assert.Equal(t, "git://example.com/repo.git", spec.Fetch)
+	assert.Equal(t, "*", spec.SubPath)
+	assert.Equal(t, "", spec.Ref)
+}
+
+func TestParseSourceSpec_HTTP(t *testing.T) {
+	spec, ok := ParseSourceSpec("https://example.com/pack.tar.gz#A*_*.json")
+	assert.True(t, ok)
+	assert.Equal(t, "http", spec.Scheme)
+	assert.Equal(t, "https://example.com/pack.tar.gz", spec.Fetch)
+	assert.Equal(t, "A*_*.json", spec.SubPath)
+}
+
+func TestParseSourceSpec_S3(t *testing.T) {
+	spec, ok := ParseSourceSpec("s3://bucket/scenarios.tar.gz#A*_*.json")
+	assert.True(t, ok)
+	assert.Equal(t, "s3", spec.Scheme)
+	assert.Equal(t, "bucket/scenarios.tar.gz", spec.Fetch)
+	assert.Equal(t, "A*_*.json", spec.SubPath)
+}
+
+func TestNewSourceFetcher_UnknownScheme(t *testing.T) {
+	_, err := NewSourceFetcher("ftp")
+	assert.Error(t, err)
+}
+
+func TestSplitChecksumFragment(t *testing.T) {
+	url, sum := splitChecksumFragment("https://example.com/pack.tar.gz#sha256=abc123")
+	assert.Equal(t, "https://example.com/pack.tar.gz", url)
+	assert.Equal(t, "abc123", sum)
+
+	url, sum = splitChecksumFragment("https://example.com/pack.tar.gz")
+	assert.Equal(t, "https://example.com/pack.tar.gz", url)
+	assert.Equal(t, "", sum)
+}
@@ -0,0 +1,159 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/3atlab/netroub/pkg/events"
+	"github.com/3atlab/netroub/pkg/model"
+)
+
+// ValidatePlan loads and validates every scenario plan's entries expand to
+// (after glob/matrix expansion), collecting every problem found instead of
+// stopping at the first, so typos across a 500-scenario plan surface in one
+// pass instead of one at a time over a multi-hour run. A nil-slice return
+// means the plan is clean.
+func ValidatePlan(plan *Plan, baseDir string) []error {
+	entries, err := plan.ExpandScenarios(baseDir)
+	if err != nil {
+		return []error{err}
+	}
+	return ValidateExpandedScenarios(entries)
+}
+
+// ValidateExpandedScenarios runs ValidateScenarioFile over entries (as
+// already expanded by Plan.ExpandScenarios), collecting every problem found
+// across all of them. Callers that already have an expanded entry list
+// (e.g. to also report its length) should call this directly instead of
+// ValidatePlan, which would otherwise expand the plan a second time.
+func ValidateExpandedScenarios(entries []ScenarioEntry) []error {
+	var problems []error
+	for _, entry := range entries {
+		problems = append(problems, ValidateScenarioFile(entry.Pattern, entry.YAML, nil)...)
+	}
+	return problems
+}
+
+// ValidateScenarioFile loads the scenario file at path (and, if set, its
+// Data file) and runs every check ValidatePlan advertises against it: the
+// scenario/data files unmarshal cleanly, every Scenario.Hosts entry names a
+// node in the data file, every event is valid per its registered
+// EventHandler, and event begin times are monotonic once parsed as
+// durations (Scenario's own sort.Sort compares them as strings, so e.g.
+// "2s" sorting after "10s" silently produces an out-of-order run). vars is
+// substituted into the raw file the same way ParseScenarioJSON/YAML always
+// does; nil substitutes from the OS environment only. Every returned error
+// is prefixed with path, so a plan's worth of them can be reported together
+// without losing track of which scenario each belongs to.
+func ValidateScenarioFile(path string, asYAML bool, vars map[string]string) []error {
+	scenario, err := loadScenarioFile(path, asYAML, vars)
+	if err != nil {
+		return []error{fmt.Errorf("%s: %w", path, err)}
+	}
+
+	var problems []error
+	if scenario.Data != "" {
+		data, err := model.ParseDataJSON(scenario.Data)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s: loading data file %s: %w", path, scenario.Data, err))
+		} else {
+			for _, err := range validateHostsAgainstData(scenario.Hosts, data) {
+				problems = append(problems, fmt.Errorf("%s: %w", path, err))
+			}
+		}
+	}
+
+	if err := events.ValidateScenarioEvents(scenario.Event); err != nil {
+		problems = append(problems, fmt.Errorf("%s: %w", path, err))
+	}
+
+	for _, err := range validateMonotonicEventTimes(scenario.Event) {
+		problems = append(problems, fmt.Errorf("%s: %w", path, err))
+	}
+
+	for _, err := range validateCopySources(scenario.Event, filepath.Dir(path)) {
+		problems = append(problems, fmt.Errorf("%s: %w", path, err))
+	}
+
+	return problems
+}
+
+// loadScenarioFile parses path as YAML or JSON depending on asYAML.
+func loadScenarioFile(path string, asYAML bool, vars map[string]string) (*model.Scenario, error) {
+	if asYAML {
+		return model.ParseScenarioYAML(path, vars)
+	}
+	return model.ParseScenarioJSON(path, vars)
+}
+
+// validateHostsAgainstData is model.ValidateHostNames but against an
+// explicit *model.Data instead of the package-level model.Devices, and
+// reports every unmatched host instead of just the first.
+func validateHostsAgainstData(hosts []string, data *model.Data) []error {
+	var problems []error
+	for _, host := range hosts {
+		found := false
+		for _, node := range data.Nodes {
+			if host == node.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Errorf("host %s not found in topology %s", host, data.Name))
+		}
+	}
+	return problems
+}
+
+// validateCopySources checks that every ToContainer FileCopy.Src exists on
+// disk relative to scenarioDir (the scenario file's own directory, the same
+// base every other relative path in a scenario resolves against), skipping
+// templated sources (see events.IsTemplatedCopySrc) since those name a
+// template to render rather than a literal file to copy as-is. Already-
+// absolute Src paths are checked as given.
+func validateCopySources(event []model.Event, scenarioDir string) []error {
+	var problems []error
+	for i, ev := range event {
+		for _, fc := range ev.ToContainer {
+			if fc.Src == "" || events.IsTemplatedCopySrc(fc.Src) {
+				continue
+			}
+			src := fc.Src
+			if !filepath.IsAbs(src) {
+				src = filepath.Join(scenarioDir, src)
+			}
+			if _, err := os.Stat(src); err != nil {
+				problems = append(problems, fmt.Errorf("event %d: toContainer src %s: %w", i, fc.Src, err))
+			}
+		}
+	}
+	return problems
+}
+
+// validateMonotonicEventTimes parses every event's BeginTime (empty means
+// 0s, matching ScenarioRunner.executeEvents) and reports every pair of
+// adjacent events, in Scenario.Event's stored order, whose parsed durations
+// go backwards.
+func validateMonotonicEventTimes(event []model.Event) []error {
+	var problems []error
+	var prev time.Duration
+	for i, ev := range event {
+		var dur time.Duration
+		if ev.BeginTime != "" {
+			var err error
+			dur, err = time.ParseDuration(ev.BeginTime)
+			if err != nil {
+				problems = append(problems, fmt.Errorf("event %d: invalid begin time %q: %w", i, ev.BeginTime, err))
+				continue
+			}
+		}
+		if i > 0 && dur < prev {
+			problems = append(problems, fmt.Errorf("event %d: begin time %s runs before event %d's %s", i, ev.BeginTime, i-1, event[i-1].BeginTime))
+		}
+		prev = dur
+	}
+	return problems
+}
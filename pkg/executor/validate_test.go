@@ -0,0 +1,176 @@
+package executor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/3atlab/netroub/pkg/model"
+)
+
+func TestValidateScenarioFile_Valid(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestDataFile(t, tmpDir, "data.json")
+	scenarioPath := writeTestScenarioFile(t, tmpDir, "scenario.json", `{
+		"scenarioName": "baseline",
+		"data": "data.json",
+		"hosts": ["r1", "r2"],
+		"event": [
+			{"type": "dummy", "beginTime": "0s"},
+			{"type": "dummy", "beginTime": "5s"}
+		]
+	}`)
+
+	problems := ValidateScenarioFile(scenarioPath, false, nil)
+	assert.Empty(t, problems)
+}
+
+func TestValidateScenarioFile_UnknownHost(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestDataFile(t, tmpDir, "data.json")
+	scenarioPath := writeTestScenarioFile(t, tmpDir, "scenario.json", `{
+		"scenarioName": "baseline",
+		"data": "data.json",
+		"hosts": ["r1", "no-such-host"],
+		"event": [{"type": "dummy", "beginTime": "0s"}]
+	}`)
+
+	problems := ValidateScenarioFile(scenarioPath, false, nil)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Error(), "no-such-host")
+}
+
+func TestValidateScenarioFile_InvalidEventType(t *testing.T) {
+	tmpDir := t.TempDir()
+	scenarioPath := writeTestScenarioFile(t, tmpDir, "scenario.json", `{
+		"scenarioName": "baseline",
+		"event": [{"type": "bogus", "beginTime": "0s"}]
+	}`)
+
+	problems := ValidateScenarioFile(scenarioPath, false, nil)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Error(), "invalid event type")
+}
+
+func TestValidateScenarioFile_NonMonotonicBeginTimes(t *testing.T) {
+	tmpDir := t.TempDir()
+	scenarioPath := writeTestScenarioFile(t, tmpDir, "scenario.json", `{
+		"scenarioName": "baseline",
+		"event": [
+			{"type": "dummy", "beginTime": "10s"},
+			{"type": "dummy", "beginTime": "2s"}
+		]
+	}`)
+
+	problems := ValidateScenarioFile(scenarioPath, false, nil)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Error(), "runs before event 0")
+}
+
+func TestValidateScenarioFile_CollectsMultipleProblems(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestDataFile(t, tmpDir, "data.json")
+	scenarioPath := writeTestScenarioFile(t, tmpDir, "scenario.json", `{
+		"scenarioName": "baseline",
+		"data": "data.json",
+		"hosts": ["no-such-host"],
+		"event": [
+			{"type": "bogus", "beginTime": "0s"},
+			{"type": "dummy", "beginTime": "1s"},
+			{"type": "dummy", "beginTime": "0s"}
+		]
+	}`)
+
+	problems := ValidateScenarioFile(scenarioPath, false, nil)
+	assert.Len(t, problems, 3, "an unknown host, an invalid event type, and a backwards begin time should all be reported, not just the first")
+}
+
+func TestValidateScenarioFile_MissingCopySource(t *testing.T) {
+	tmpDir := t.TempDir()
+	scenarioPath := writeTestScenarioFile(t, tmpDir, "scenario.json", `{
+		"scenarioName": "baseline",
+		"event": [{
+			"type": "dummy",
+			"beginTime": "0s",
+			"toContainer": [{"src": "no-such-file.txt", "dst": "/etc/frr/frr.conf"}]
+		}]
+	}`)
+
+	problems := ValidateScenarioFile(scenarioPath, false, nil)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Error(), "no-such-file.txt")
+}
+
+func TestValidateScenarioFile_CopySourcePresent(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "frr.conf"), []byte("hostname r1\n"), 0644))
+	scenarioPath := writeTestScenarioFile(t, tmpDir, "scenario.json", `{
+		"scenarioName": "baseline",
+		"event": [{
+			"type": "dummy",
+			"beginTime": "0s",
+			"toContainer": [{"src": "frr.conf", "dst": "/etc/frr/frr.conf"}]
+		}]
+	}`)
+
+	problems := ValidateScenarioFile(scenarioPath, false, nil)
+	assert.Empty(t, problems)
+}
+
+func TestValidateScenarioFile_TemplatedCopySourceSkipsExistenceCheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	scenarioPath := writeTestScenarioFile(t, tmpDir, "scenario.json", `{
+		"scenarioName": "baseline",
+		"event": [{
+			"type": "dummy",
+			"beginTime": "0s",
+			"toContainer": [{"src": "frr.conf.tmpl", "dst": "/etc/frr/"}]
+		}]
+	}`)
+
+	problems := ValidateScenarioFile(scenarioPath, false, nil)
+	assert.Empty(t, problems, "a .tmpl src is rendered at copy time, not read as-is, so its absence here shouldn't be flagged")
+}
+
+func TestValidatePlan_AggregatesAcrossScenarios(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestScenarioFile(t, tmpDir, "good.json", `{
+		"scenarioName": "good",
+		"event": [{"type": "dummy", "beginTime": "0s"}]
+	}`)
+	writeTestScenarioFile(t, tmpDir, "bad.json", `{
+		"scenarioName": "bad",
+		"event": [{"type": "bogus", "beginTime": "0s"}]
+	}`)
+
+	plan := &Plan{Scenarios: []ScenarioEntry{
+		{Pattern: "good.json"},
+		{Pattern: "bad.json"},
+	}}
+
+	problems := ValidatePlan(plan, tmpDir)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Error(), "invalid event type")
+}
+
+// writeTestScenarioFile writes content to dir/name and returns its path.
+func writeTestScenarioFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+// writeTestDataFile writes a minimal dot2net device-data file (two nodes,
+// r1/r2) to dir/name.
+func writeTestDataFile(t *testing.T, dir, name string) {
+	t.Helper()
+	data := model.Data{Name: "testnet", Nodes: []model.Nodes{{Name: "r1"}, {Name: "r2"}}}
+	raw, err := json.Marshal(data)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), raw, 0644))
+}
@@ -0,0 +1,133 @@
+// Package loadtest drives a weighted mix of scenarios through
+// executor.TaskRunner at a configurable, optionally ramping parallelism,
+// for load-testing netroub's own execution path rather than generating a
+// fixed number of repetitions of one scenario the way executor.Plan does.
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WorkloadSpec describes a load test run: loaded from a JSON (or YAML)
+// file via LoadWorkloadSpec, the same way executor.LoadPlan reads a Plan.
+type WorkloadSpec struct {
+	// Mixes are the scenarios tasks are drawn from, weighted against each
+	// other.
+	Mixes []MixEntry `yaml:"mixes" json:"mixes"`
+	// Parallelism is the number of workers running tasks concurrently when
+	// RampUp is unset, or the starting point RampUp.From defaults to
+	// otherwise.
+	Parallelism int `yaml:"parallelism" json:"parallelism"`
+	// RampUp, if set, climbs the active worker count from From to To over
+	// the course of Over instead of holding Parallelism steady for the whole
+	// run.
+	RampUp *RampUp `yaml:"rampUp,omitempty" json:"rampUp,omitempty"`
+	// Duration bounds the run by wall-clock time (e.g. "10m"). At least one
+	// of Duration or Count must be set.
+	Duration string `yaml:"duration,omitempty" json:"duration,omitempty"`
+	// Count bounds the run by total task count. At least one of Duration or
+	// Count must be set; if both are set, whichever is reached first stops
+	// the run.
+	Count int `yaml:"count,omitempty" json:"count,omitempty"`
+	// ThinkTime, if set, pauses each worker for this long (e.g. "500ms")
+	// between one task finishing and it starting the next.
+	ThinkTime string `yaml:"thinkTime,omitempty" json:"thinkTime,omitempty"`
+	// OutputFile, if set, is where Runner.Run dumps the run's Report as
+	// JSON, so separate runs can be diffed in CI.
+	OutputFile string `yaml:"outputFile,omitempty" json:"outputFile,omitempty"`
+}
+
+// MixEntry is one scenario in a WorkloadSpec's mix.
+type MixEntry struct {
+	// ScenarioPath is the scenario file tasks drawn from this entry run.
+	ScenarioPath string `yaml:"scenarioPath" json:"scenarioPath"`
+	// Weight is this entry's sampling weight: entries are drawn with
+	// replacement proportional to Weight (default 1 when unset or <= 0),
+	// the same convention as executor.ScenarioEntry.Weight.
+	Weight float64 `yaml:"weight,omitempty" json:"weight,omitempty"`
+	// YAML selects whether ScenarioPath is a YAML or JSON scenario file.
+	YAML bool `yaml:"yaml,omitempty" json:"yaml,omitempty"`
+}
+
+// RampUp climbs Runner's active worker count linearly from From at the
+// start of the run to To over Over, then holds steady at To for the rest
+// of the run.
+type RampUp struct {
+	From int    `yaml:"from" json:"from"`
+	To   int    `yaml:"to" json:"to"`
+	Over string `yaml:"over" json:"over"` // e.g. "30s"
+}
+
+// LoadWorkloadSpec loads a WorkloadSpec from a YAML or JSON file, trying
+// YAML first since it's a superset of JSON, the same as executor.LoadPlan.
+func LoadWorkloadSpec(path string) (*WorkloadSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workload spec file: %w", err)
+	}
+
+	var spec WorkloadSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		if jsonErr := json.Unmarshal(data, &spec); jsonErr != nil {
+			return nil, fmt.Errorf("failed to parse workload spec (tried YAML and JSON): YAML error: %v, JSON error: %v", err, jsonErr)
+		}
+	}
+
+	if err := spec.applyDefaultsAndValidate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// applyDefaultsAndValidate fills in WorkloadSpec's defaults and rejects a
+// spec LoadWorkloadSpec/NewRunner couldn't act on.
+func (s *WorkloadSpec) applyDefaultsAndValidate() error {
+	if len(s.Mixes) == 0 {
+		return fmt.Errorf("workload spec: at least one mix entry is required")
+	}
+	for i := range s.Mixes {
+		if s.Mixes[i].ScenarioPath == "" {
+			return fmt.Errorf("workload spec: mix entry %d has no scenarioPath", i)
+		}
+		if s.Mixes[i].Weight <= 0 {
+			s.Mixes[i].Weight = 1
+		}
+	}
+
+	if s.Parallelism < 1 {
+		s.Parallelism = 1
+	}
+	if s.RampUp != nil && s.RampUp.From <= 0 {
+		s.RampUp.From = s.Parallelism
+	}
+
+	if s.Duration == "" && s.Count <= 0 {
+		return fmt.Errorf("workload spec: at least one of duration or count is required")
+	}
+
+	if s.RampUp != nil {
+		if s.RampUp.To < s.RampUp.From {
+			return fmt.Errorf("workload spec: rampUp.to (%d) must be >= rampUp.from (%d)", s.RampUp.To, s.RampUp.From)
+		}
+		if _, err := time.ParseDuration(s.RampUp.Over); err != nil {
+			return fmt.Errorf("workload spec: invalid rampUp.over %q: %w", s.RampUp.Over, err)
+		}
+	}
+	if s.Duration != "" {
+		if _, err := time.ParseDuration(s.Duration); err != nil {
+			return fmt.Errorf("workload spec: invalid duration %q: %w", s.Duration, err)
+		}
+	}
+	if s.ThinkTime != "" {
+		if _, err := time.ParseDuration(s.ThinkTime); err != nil {
+			return fmt.Errorf("workload spec: invalid thinkTime %q: %w", s.ThinkTime, err)
+		}
+	}
+
+	return nil
+}
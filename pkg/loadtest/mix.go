@@ -0,0 +1,49 @@
+package loadtest
+
+import (
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"strings"
+)
+
+// mixPicker draws MixEntry values with replacement, proportional to their
+// Weight, the same sampling convention executor.ScenarioEntry.Weight uses
+// under ScheduleWeighted.
+type mixPicker struct {
+	entries     []MixEntry
+	cumWeights  []float64
+	totalWeight float64
+}
+
+func newMixPicker(mixes []MixEntry) (*mixPicker, error) {
+	if len(mixes) == 0 {
+		return nil, fmt.Errorf("loadtest: at least one mix entry is required")
+	}
+
+	cum := make([]float64, len(mixes))
+	var total float64
+	for i, m := range mixes {
+		total += m.Weight
+		cum[i] = total
+	}
+	return &mixPicker{entries: mixes, cumWeights: cum, totalWeight: total}, nil
+}
+
+// pick draws one MixEntry proportional to its Weight.
+func (p *mixPicker) pick() MixEntry {
+	r := rand.Float64() * p.totalWeight
+	for i, c := range p.cumWeights {
+		if r < c {
+			return p.entries[i]
+		}
+	}
+	return p.entries[len(p.entries)-1]
+}
+
+// scenarioBaseName extracts a scenario's name from its path for use in a
+// generated RunID, e.g. "/path/to/A1_delay_pause.json" -> "A1_delay_pause".
+func scenarioBaseName(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
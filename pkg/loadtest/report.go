@@ -0,0 +1,118 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// TaskRecord is one task's outcome from a load test run. Only a task's
+// total duration is recorded: that's all executor.TaskRunner/Result expose
+// today, so a deploy/event/destroy breakdown isn't available without
+// instrumenting ScenarioRunner.RunWithResult further.
+type TaskRecord struct {
+	RunID         string        `json:"runId"`
+	ScenarioPath  string        `json:"scenarioPath"`
+	StartTime     time.Time     `json:"startTime"`
+	TotalDuration time.Duration `json:"totalDurationNs"`
+	Success       bool          `json:"success"`
+	Error         string        `json:"error,omitempty"`
+}
+
+// Report aggregates a load test run's TaskRecords into summary statistics,
+// the load-testing counterpart to executor.Summary/PrintSummary.
+type Report struct {
+	Tasks        []TaskRecord  `json:"tasks"`
+	Total        int           `json:"total"`
+	Succeeded    int           `json:"succeeded"`
+	Failed       int           `json:"failed"`
+	WallDuration time.Duration `json:"wallDurationNs"`
+	MeanDuration time.Duration `json:"meanDurationNs"`
+	P50Duration  time.Duration `json:"p50DurationNs"`
+	P90Duration  time.Duration `json:"p90DurationNs"`
+	P99Duration  time.Duration `json:"p99DurationNs"`
+	// Throughput is completed tasks per second of WallDuration.
+	Throughput float64 `json:"throughputPerSec"`
+}
+
+// BuildReport summarizes records into a Report. wallDuration is the load
+// test's total run time, used for Throughput.
+func BuildReport(records []TaskRecord, wallDuration time.Duration) *Report {
+	report := &Report{Tasks: records, Total: len(records), WallDuration: wallDuration}
+
+	durations := make([]time.Duration, len(records))
+	var sum time.Duration
+	for i, rec := range records {
+		durations[i] = rec.TotalDuration
+		sum += rec.TotalDuration
+		if rec.Success {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	if len(durations) > 0 {
+		report.MeanDuration = sum / time.Duration(len(durations))
+		report.P50Duration = percentile(durations, 0.50)
+		report.P90Duration = percentile(durations, 0.90)
+		report.P99Duration = percentile(durations, 0.99)
+	}
+	if wallDuration > 0 {
+		report.Throughput = float64(report.Total) / wallDuration.Seconds()
+	}
+
+	return report
+}
+
+// percentile returns the duration at fraction p (0-1) through sorted
+// (ascending): the element at index p*(len-1), rounded to the nearest
+// index.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p*float64(len(sorted)-1) + 0.5)
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// Print writes a human-readable summary to stdout, the loadtest
+// counterpart to executor.PrintSummary.
+func (r *Report) Print() {
+	fmt.Println()
+	fmt.Println("========== Load Test Report ==========")
+	fmt.Printf("Total: %d, Succeeded: %d, Failed: %d\n", r.Total, r.Succeeded, r.Failed)
+	fmt.Printf("Wall Duration: %s, Throughput: %.2f tasks/sec\n", r.WallDuration.Round(time.Second), r.Throughput)
+	fmt.Printf("Duration (mean/p50/p90/p99): %s / %s / %s / %s\n",
+		r.MeanDuration.Round(time.Millisecond), r.P50Duration.Round(time.Millisecond),
+		r.P90Duration.Round(time.Millisecond), r.P99Duration.Round(time.Millisecond))
+
+	if r.Failed > 0 {
+		fmt.Println("\nFailed tasks:")
+		for _, rec := range r.Tasks {
+			if !rec.Success {
+				fmt.Printf("  - %s: %s\n", rec.RunID, rec.Error)
+			}
+		}
+	}
+	fmt.Println("=======================================")
+}
+
+// WriteJSON dumps r to path as JSON, so separate load test runs can be
+// diffed in CI.
+func (r *Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal load test report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
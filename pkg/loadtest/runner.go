@@ -0,0 +1,195 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/3atlab/netroub/pkg/executor"
+)
+
+// rampPollInterval is how often an idle worker (one the ramp-up hasn't
+// reached yet) rechecks whether it should start claiming tasks.
+const rampPollInterval = 100 * time.Millisecond
+
+// Runner drives a WorkloadSpec's tasks through a TaskRunner at the spec's
+// ramp-up/parallelism/think-time pace, feeding each task through the same
+// executor.TaskRunner/TaskRunnerWithResult interface executor.Executor
+// uses, but with its own scheduling loop instead of Executor's fixed
+// worker pool, since parallelism here can change over the run.
+type Runner struct {
+	Spec       WorkloadSpec
+	TaskRunner executor.TaskRunner
+}
+
+// NewRunner builds a Runner for spec, running tasks through taskRunner
+// (typically an *executor.ScenarioRunner).
+func NewRunner(spec WorkloadSpec, taskRunner executor.TaskRunner) *Runner {
+	return &Runner{Spec: spec, TaskRunner: taskRunner}
+}
+
+// Run executes the workload until its Duration/Count bound is reached or
+// ctx is canceled, and returns the resulting Report. If Spec.OutputFile is
+// set, the Report is also dumped there as JSON before Run returns.
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	picker, err := newMixPicker(r.Spec.Mixes)
+	if err != nil {
+		return nil, err
+	}
+
+	var duration time.Duration
+	if r.Spec.Duration != "" {
+		duration, err = time.ParseDuration(r.Spec.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("loadtest: invalid duration %q: %w", r.Spec.Duration, err)
+		}
+	}
+
+	var thinkTime time.Duration
+	if r.Spec.ThinkTime != "" {
+		thinkTime, err = time.ParseDuration(r.Spec.ThinkTime)
+		if err != nil {
+			return nil, fmt.Errorf("loadtest: invalid thinkTime %q: %w", r.Spec.ThinkTime, err)
+		}
+	}
+
+	var rampOver time.Duration
+	if r.Spec.RampUp != nil {
+		rampOver, err = time.ParseDuration(r.Spec.RampUp.Over)
+		if err != nil {
+			return nil, fmt.Errorf("loadtest: invalid rampUp.over %q: %w", r.Spec.RampUp.Over, err)
+		}
+	}
+
+	start := time.Now()
+	var deadline time.Time
+	if duration > 0 {
+		deadline = start.Add(duration)
+	}
+
+	targetParallelism := func(elapsed time.Duration) int {
+		if r.Spec.RampUp == nil || rampOver <= 0 {
+			return r.Spec.Parallelism
+		}
+		if elapsed >= rampOver {
+			return r.Spec.RampUp.To
+		}
+		frac := float64(elapsed) / float64(rampOver)
+		return r.Spec.RampUp.From + int(frac*float64(r.Spec.RampUp.To-r.Spec.RampUp.From))
+	}
+
+	var started int64
+	shouldStop := func() bool {
+		if ctx.Err() != nil {
+			return true
+		}
+		if r.Spec.Count > 0 && atomic.LoadInt64(&started) >= int64(r.Spec.Count) {
+			return true
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return true
+		}
+		return false
+	}
+
+	var (
+		mu      sync.Mutex
+		records []TaskRecord
+		wg      sync.WaitGroup
+	)
+
+	worker := func(workerID int) {
+		defer wg.Done()
+		for {
+			if shouldStop() {
+				return
+			}
+
+			// Not yet reached by the ramp: wait instead of claiming a
+			// task, rather than exiting outright, since the target climbs
+			// as the run goes on.
+			if workerID >= targetParallelism(time.Since(start)) {
+				select {
+				case <-time.After(rampPollInterval):
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			n := atomic.AddInt64(&started, 1)
+			if r.Spec.Count > 0 && n > int64(r.Spec.Count) {
+				return
+			}
+
+			mix := picker.pick()
+			task := &executor.Task{
+				ScenarioPath: mix.ScenarioPath,
+				RunID:        fmt.Sprintf("%s_loadtest_%06d", scenarioBaseName(mix.ScenarioPath), n),
+				Index:        int(n),
+				YAML:         mix.YAML,
+				Runtime:      executor.DefaultRuntimeKind,
+			}
+
+			rec := r.runOne(ctx, task)
+
+			mu.Lock()
+			records = append(records, rec)
+			mu.Unlock()
+
+			if thinkTime > 0 {
+				select {
+				case <-time.After(thinkTime):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+
+	maxWorkers := r.Spec.Parallelism
+	if r.Spec.RampUp != nil && r.Spec.RampUp.To > maxWorkers {
+		maxWorkers = r.Spec.RampUp.To
+	}
+	for w := 0; w < maxWorkers; w++ {
+		wg.Add(1)
+		go worker(w)
+	}
+	wg.Wait()
+
+	report := BuildReport(records, time.Since(start))
+	if r.Spec.OutputFile != "" {
+		if err := report.WriteJSON(r.Spec.OutputFile); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// runOne runs a single task through r.TaskRunner, via RunWithResult if the
+// runner supports it (the same hasExtended check executor.Executor uses),
+// and times it start to finish.
+func (r *Runner) runOne(ctx context.Context, task *executor.Task) TaskRecord {
+	start := time.Now()
+
+	var err error
+	if withResult, ok := r.TaskRunner.(executor.TaskRunnerWithResult); ok {
+		err = withResult.RunWithResult(ctx, task, start).Error
+	} else {
+		err = r.TaskRunner.Run(ctx, task)
+	}
+
+	rec := TaskRecord{
+		RunID:         task.RunID,
+		ScenarioPath:  task.ScenarioPath,
+		StartTime:     start,
+		TotalDuration: time.Since(start),
+		Success:       err == nil,
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	return rec
+}
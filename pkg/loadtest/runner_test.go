@@ -0,0 +1,128 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/3atlab/netroub/pkg/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTaskRunner is a mock implementation of executor.TaskRunner for
+// testing, mirroring executor's own mockTaskRunner.
+type mockTaskRunner struct {
+	runCount  int32
+	runDelay  time.Duration
+	runError  error
+	runCalled []string
+	mu        sync.Mutex
+}
+
+func (m *mockTaskRunner) Run(ctx context.Context, task *executor.Task) error {
+	atomic.AddInt32(&m.runCount, 1)
+	m.mu.Lock()
+	m.runCalled = append(m.runCalled, task.RunID)
+	m.mu.Unlock()
+	if m.runDelay > 0 {
+		time.Sleep(m.runDelay)
+	}
+	return m.runError
+}
+
+func TestRunner_Run_StopsAtCount(t *testing.T) {
+	runner := &mockTaskRunner{}
+	spec := WorkloadSpec{
+		Mixes:       []MixEntry{{ScenarioPath: "baseline.json"}},
+		Parallelism: 2,
+		Count:       5,
+	}
+	require.NoError(t, spec.applyDefaultsAndValidate())
+
+	report, err := NewRunner(spec, runner).Run(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, 5, report.Succeeded)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&runner.runCount))
+}
+
+func TestRunner_Run_StopsAtDuration(t *testing.T) {
+	runner := &mockTaskRunner{runDelay: 10 * time.Millisecond}
+	spec := WorkloadSpec{
+		Mixes:       []MixEntry{{ScenarioPath: "baseline.json"}},
+		Parallelism: 2,
+		Duration:    "60ms",
+	}
+	require.NoError(t, spec.applyDefaultsAndValidate())
+
+	report, err := NewRunner(spec, runner).Run(context.Background())
+
+	require.NoError(t, err)
+	assert.Greater(t, report.Total, 0)
+}
+
+func TestRunner_Run_RecordsFailures(t *testing.T) {
+	runner := &mockTaskRunner{runError: errors.New("deploy failed")}
+	spec := WorkloadSpec{
+		Mixes:       []MixEntry{{ScenarioPath: "baseline.json"}},
+		Parallelism: 1,
+		Count:       3,
+	}
+	require.NoError(t, spec.applyDefaultsAndValidate())
+
+	report, err := NewRunner(spec, runner).Run(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Failed)
+	assert.Equal(t, 0, report.Succeeded)
+	for _, rec := range report.Tasks {
+		assert.Equal(t, "deploy failed", rec.Error)
+	}
+}
+
+func TestRunner_Run_RampUpStartsAtFromParallelism(t *testing.T) {
+	runner := &mockTaskRunner{runDelay: 5 * time.Millisecond}
+	spec := WorkloadSpec{
+		Mixes:    []MixEntry{{ScenarioPath: "baseline.json"}},
+		RampUp:   &RampUp{From: 1, To: 4, Over: "1h"}, // effectively never reaches 4 within this test
+		Duration: "30ms",
+	}
+	require.NoError(t, spec.applyDefaultsAndValidate())
+
+	report, err := NewRunner(spec, runner).Run(context.Background())
+
+	require.NoError(t, err)
+	// With a 1-hour ramp, only the first worker (index 0, within From=1)
+	// should ever claim a task during this short run.
+	assert.Greater(t, report.Total, 0)
+}
+
+func TestRunner_Run_RejectsEmptyMixes(t *testing.T) {
+	spec := WorkloadSpec{Count: 1}
+
+	_, err := NewRunner(spec, &mockTaskRunner{}).Run(context.Background())
+
+	assert.Error(t, err)
+}
+
+func TestRunner_Run_WritesReportJSON(t *testing.T) {
+	runner := &mockTaskRunner{}
+	outputFile := t.TempDir() + "/report.json"
+	spec := WorkloadSpec{
+		Mixes:       []MixEntry{{ScenarioPath: "baseline.json"}},
+		Parallelism: 1,
+		Count:       2,
+		OutputFile:  outputFile,
+	}
+	require.NoError(t, spec.applyDefaultsAndValidate())
+
+	_, err := NewRunner(spec, runner).Run(context.Background())
+
+	require.NoError(t, err)
+	assert.FileExists(t, outputFile)
+}
@@ -0,0 +1,22 @@
+package logstore
+
+import (
+	"io"
+	"os"
+)
+
+// LocalBackend stores run artifacts on the local filesystem.
+type LocalBackend struct{}
+
+// NewLocalBackend returns a Backend writing to the local filesystem.
+func NewLocalBackend() *LocalBackend {
+	return &LocalBackend{}
+}
+
+func (*LocalBackend) MkdirAll(path string) error {
+	return os.MkdirAll(path, os.ModePerm)
+}
+
+func (*LocalBackend) Writer(path string) (io.WriteCloser, error) {
+	return os.Create(path)
+}
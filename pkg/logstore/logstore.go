@@ -0,0 +1,85 @@
+// Package logstore owns the on-disk (and, via Backend, eventually off-host)
+// layout of a scenario run's collected artifacts: tcpdump captures, moved
+// log files, and the control log. It replaces the ad-hoc
+// "model.Scenar.LogPath + "/" + ..." string concatenation and
+// colon-containing timestamps that network.MoveLogFiles used to build by
+// hand, neither of which is safe on Windows/SMB shares nor disambiguates two
+// runs landing in the same second.
+package logstore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"path/filepath"
+	"time"
+)
+
+// Backend is the storage netroub writes run artifacts to. LocalBackend is
+// the only implementation today; an S3/MinIO-backed one can satisfy the
+// same interface later without Store's callers changing.
+type Backend interface {
+	// MkdirAll creates path and any missing parents, succeeding if path
+	// already exists as a directory.
+	MkdirAll(path string) error
+	// Writer opens path for writing, creating or truncating it.
+	Writer(path string) (io.WriteCloser, error)
+}
+
+// Store lays out one scenario run's artifacts on a Backend as
+// "<root>/<scenario>/<runID>/<deviceID>/<artifactName>", where runID is an
+// RFC3339-safe timestamp plus a short random suffix so two runs starting
+// within the same second never collide.
+type Store struct {
+	backend  Backend
+	root     string
+	scenario string
+	runID    string
+}
+
+// NewStore creates the run directory for scenario under root on backend and
+// returns a Store scoped to it.
+func NewStore(backend Backend, root, scenario string) (*Store, error) {
+	s := &Store{
+		backend:  backend,
+		root:     root,
+		scenario: scenario,
+		runID:    newRunID(time.Now()),
+	}
+	if err := backend.MkdirAll(s.RunDir()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// RunDir returns this run's directory, relative to the Store's backend.
+func (s *Store) RunDir() string {
+	return filepath.Join(s.root, s.scenario, s.runID)
+}
+
+// Writer opens artifactName for writing under deviceID's directory within
+// the run, creating that directory if needed. tcpdump streaming and log
+// moving both go through this one call, so there's a single place that
+// knows the on-disk layout.
+func (s *Store) Writer(deviceID, artifactName string) (io.WriteCloser, error) {
+	dir := filepath.Join(s.RunDir(), deviceID)
+	if err := s.backend.MkdirAll(dir); err != nil {
+		return nil, err
+	}
+	return s.backend.Writer(filepath.Join(dir, artifactName))
+}
+
+// newRunID formats t as a filesystem- and SMB-safe timestamp (no colons)
+// and appends a short random suffix to disambiguate runs within the same
+// second.
+func newRunID(t time.Time) string {
+	return t.UTC().Format("20060102T150405") + "-" + randomSuffix()
+}
+
+// randomSuffix returns a 4-byte random hex string. crypto/rand.Read never
+// returns an error on the platforms netroub targets.
+func randomSuffix() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
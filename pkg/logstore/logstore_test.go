@@ -0,0 +1,46 @@
+package logstore
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_Writer(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewStore(NewLocalBackend(), root, "myscenario")
+	require.NoError(t, err)
+
+	_, err = os.Stat(store.RunDir())
+	require.NoError(t, err, "NewStore should create the run directory")
+	assert.Equal(t, root, filepath.Dir(filepath.Dir(store.RunDir())))
+
+	w, err := store.Writer("r1", "control.log")
+	require.NoError(t, err)
+	_, err = io.WriteString(w, "hello")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	data, err := os.ReadFile(filepath.Join(store.RunDir(), "r1", "control.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+}
+
+func TestNewRunID_NoColons(t *testing.T) {
+	store, err := NewStore(NewLocalBackend(), t.TempDir(), "scenario")
+	require.NoError(t, err)
+	assert.NotContains(t, filepath.Base(store.RunDir()), ":")
+}
+
+func TestNewStore_DistinctRunsDoNotCollide(t *testing.T) {
+	root := t.TempDir()
+	s1, err := NewStore(NewLocalBackend(), root, "scenario")
+	require.NoError(t, err)
+	s2, err := NewStore(NewLocalBackend(), root, "scenario")
+	require.NoError(t, err)
+	assert.NotEqual(t, s1.RunDir(), s2.RunDir())
+}
@@ -38,27 +38,30 @@ type Data struct {
 
 var Devices Data
 
-// LabName is the custom lab name for containerlab.
-// If empty, Devices.Name (topology name) is used.
-var LabName string
-
-// GetLabName returns the lab name to use for containerlab.
-// Returns custom LabName if set, otherwise returns Devices.Name.
-func GetLabName() string {
-	if LabName != "" {
-		return LabName
-	}
-	return Devices.Name
+// LabContext identifies the deployed containerlab lab a scenario's events
+// run against. It is constructed once (from a Task's RunID, a scenario's
+// ScenarioRunner-assigned lab name, or Devices.Name for the legacy
+// single-scenario CLI path) and passed explicitly to ClabHostName and
+// anything that needs it, instead of the package-level LabName global this
+// replaced: that global raced the moment more than one scenario ran
+// concurrently (SetLabName → GetLabName → local copy, with no locking
+// between the two calls), which is exactly the ScenarioRunner pattern this
+// package's callers use. LabContext has no mutable state, so sharing one
+// (or constructing many) across goroutines is always safe.
+type LabContext struct {
+	// Name is the containerlab lab name, e.g. a Task's RunID.
+	Name string
 }
 
-// SetLabName sets a custom lab name for containerlab.
-func SetLabName(name string) {
-	LabName = name
+// NewLabContext returns the LabContext for lab name.
+func NewLabContext(name string) LabContext {
+	return LabContext{Name: name}
 }
 
-// ResetLabName clears the custom lab name.
-func ResetLabName() {
-	LabName = ""
+// ClabHostName returns the containerlab container name for host within l's
+// lab.
+func (l LabContext) ClabHostName(host string) string {
+	return "clab-" + l.Name + "-" + host
 }
 
 func ValidateHostNames(hosts []string) error {
@@ -76,10 +79,6 @@ func ValidateHostNames(hosts []string) error {
 	return nil
 }
 
-func ClabHostName(host string) string {
-	return "clab-" + GetLabName() + "-" + host
-}
-
 func GetDeviceIndex(device string) int {
 	for i, node := range Devices.Nodes {
 		if device == node.Name {
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLabContext(t *testing.T) {
+	lab := NewLabContext("test-topo")
+	assert.Equal(t, "test-topo", lab.Name)
+}
+
+func TestLabContext_ClabHostName(t *testing.T) {
+	lab := NewLabContext("test-topo")
+	assert.Equal(t, "clab-test-topo-r1", lab.ClabHostName("r1"))
+
+	other := NewLabContext("my-lab")
+	assert.Equal(t, "clab-my-lab-r1", other.ClabHostName("r1"))
+	// lab is unaffected by other's existence: LabContext carries no shared
+	// mutable state, unlike the package-level global it replaced.
+	assert.Equal(t, "clab-test-topo-r1", lab.ClabHostName("r1"))
+}
+
+// TestLabContext_ConcurrentAccess exercises many goroutines each
+// constructing their own LabContext and calling ClabHostName concurrently,
+// asserting every result is correct. Run with: go test -race ./pkg/model/...
+func TestLabContext_ConcurrentAccess(t *testing.T) {
+	const numGoroutines = 100
+	const numIterations = 100
+
+	var wg sync.WaitGroup
+	wg.Add(numGoroutines)
+
+	for i := 0; i < numGoroutines; i++ {
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < numIterations; j++ {
+				labName := fmt.Sprintf("lab_%d_%d", id, j)
+				lab := NewLabContext(labName)
+				assert.Equal(t, "clab-"+labName+"-r1", lab.ClabHostName("r1"))
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// TestLabContext_IsolationPattern mirrors the pattern used in ScenarioRunner:
+// a lab name is resolved once per task/scenario and the resulting LabContext
+// passed down, instead of mutating shared state. Because LabContext has no
+// mutable state, every goroutine's result is guaranteed correct.
+func TestLabContext_IsolationPattern(t *testing.T) {
+	const numGoroutines = 50
+	results := make(chan string, numGoroutines)
+
+	var wg sync.WaitGroup
+	wg.Add(numGoroutines)
+
+	for i := 0; i < numGoroutines; i++ {
+		go func(id int) {
+			defer wg.Done()
+
+			expectedName := fmt.Sprintf("task_%03d", id)
+			lab := NewLabContext(expectedName)
+
+			results <- lab.ClabHostName("r1")
+		}(i)
+	}
+
+	wg.Wait()
+	close(results)
+
+	want := make(map[string]bool, numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		want["clab-"+fmt.Sprintf("task_%03d", i)+"-r1"] = true
+	}
+
+	collected := make([]string, 0, numGoroutines)
+	for r := range results {
+		assert.True(t, want[r], "unexpected container name %q", r)
+		collected = append(collected, r)
+	}
+	assert.Equal(t, numGoroutines, len(collected))
+}
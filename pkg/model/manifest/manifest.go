@@ -0,0 +1,46 @@
+// Package manifest defines the machine-readable record network.WriteRunManifest
+// writes alongside a scenario run's collected logs, so downstream analysis
+// pipelines can enumerate runs without walking directories by hand.
+package manifest
+
+import "time"
+
+// Manifest is written as manifest.json at the root of a run directory (the
+// directory network.MoveLogFiles creates for one scenario execution).
+type Manifest struct {
+	ScenarioName   string     `json:"scenarioName"`
+	ScenarioPath   string     `json:"scenarioPath"`
+	ScenarioSHA256 string     `json:"scenarioSha256"`
+	TopologyPath   string     `json:"topologyPath"`
+	NetroubCommit  string     `json:"netroubCommit,omitempty"`
+	RunStart       time.Time  `json:"runStart"`
+	RunEnd         time.Time  `json:"runEnd"`
+	Hosts          []Host     `json:"hosts"`
+	Events         []Event    `json:"events"`
+	Artifacts      []Artifact `json:"artifacts"`
+}
+
+// Host records one host/container the scenario targeted.
+type Host struct {
+	Host      string `json:"host"`
+	Container string `json:"container"`
+}
+
+// Event is one scenario Event's execution timing and outcome, indexed the
+// same way as Scenario.Event.
+type Event struct {
+	Index     int       `json:"index"`
+	Type      string    `json:"type"`
+	Host      string    `json:"host,omitempty"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Artifact is one file collected into the run directory, recorded relative
+// to the run directory's root.
+type Artifact struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
@@ -5,75 +5,200 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 
+	"github.com/a8m/envsubst"
 	"gopkg.in/yaml.v3"
 )
 
+// ReadJsonScenar reads the scenario file named by os.Args[1] as JSON.
+// It is a thin wrapper over ReadScenarioJSON for the CLI entry point, which
+// does not need per-run variable substitution beyond the OS environment.
 func ReadJsonScenar() error {
-	file, err := os.Open(os.Args[1])
+	if len(os.Args) < 2 {
+		return fmt.Errorf("no scenario file specified")
+	}
+	return ReadScenarioJSON(os.Args[1], nil)
+}
+
+// ReadYaml reads the scenario file named by os.Args[2] as YAML.
+// It is a thin wrapper over ReadScenarioYAML for the CLI entry point, which
+// does not need per-run variable substitution beyond the OS environment.
+func ReadYaml() error {
+	if len(os.Args) < 3 {
+		return fmt.Errorf("no scenario file specified")
+	}
+	return ReadScenarioYAML(os.Args[2], nil)
+}
+
+// ReadScenarioJSON parses path as JSON via ParseScenarioJSON and stores the
+// result in Scenar, for callers that rely on the package-level global.
+func ReadScenarioJSON(path string, vars map[string]string) error {
+	scenario, err := ParseScenarioJSON(path, vars)
 	if err != nil {
-		fmt.Println("Fail to open the scenario file")
 		return err
 	}
-	defer file.Close()
+	Scenar = *scenario
+	return nil
+}
 
-	read, err := io.ReadAll(file)
+// ReadScenarioYAML parses path as YAML via ParseScenarioYAML and stores the
+// result in Scenar, for callers that rely on the package-level global.
+func ReadScenarioYAML(path string, vars map[string]string) error {
+	scenario, err := ParseScenarioYAML(path, vars)
 	if err != nil {
-		fmt.Println("Error during reading of the scenario file")
 		return err
 	}
+	Scenar = *scenario
+	return nil
+}
 
-	err = json.Unmarshal(read, &Scenar)
+// ParseScenarioJSON reads and unmarshals a JSON scenario file at path into a
+// fresh Scenario, touching no package-level state so callers (e.g.
+// ScenarioRunner) can load distinct scenarios in parallel. Before
+// unmarshalling, the raw bytes are run through envsubst-style variable
+// substitution (${VAR}, ${VAR:-default}, ${VAR:?err}), resolving each
+// variable from vars first and falling back to the OS environment. This
+// lets a single scenario file be parameterized (duration, stressImage,
+// shellPath, per-run seeds, ...) instead of duplicated per sweep.
+func ParseScenarioJSON(path string, vars map[string]string) (*Scenario, error) {
+	read, err := readScenarioSource(path, vars)
 	if err != nil {
+		return nil, err
+	}
+
+	var scenario Scenario
+	if err := json.Unmarshal(read, &scenario); err != nil {
 		fmt.Println("Error while decoding json data of scenario file")
-		return err
+		return nil, err
 	}
-	sort.Sort(Scenar)
-	return nil
+	sort.Sort(scenario)
+	return &scenario, nil
 }
 
-func ReadYaml() error {
-	file, err := os.Open(os.Args[2])
+// ParseScenarioYAML is ParseScenarioJSON for a YAML scenario file.
+func ParseScenarioYAML(path string, vars map[string]string) (*Scenario, error) {
+	read, err := readScenarioSource(path, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var scenario Scenario
+	if err := yaml.Unmarshal(read, &scenario); err != nil {
+		fmt.Println("Error while decoding yaml data of scenario file")
+		return nil, err
+	}
+	sort.Sort(scenario)
+	return &scenario, nil
+}
+
+// readScenarioSource reads path and applies substituteVars, shared by
+// ParseScenarioJSON and ParseScenarioYAML.
+func readScenarioSource(path string, vars map[string]string) ([]byte, error) {
+	file, err := os.Open(path)
 	if err != nil {
 		fmt.Println("Fail to open the scenario file")
-		return err
+		return nil, err
 	}
 	defer file.Close()
 
 	read, err := io.ReadAll(file)
 	if err != nil {
 		fmt.Println("Error during reading of the scenario file")
-		return err
+		return nil, err
 	}
 
-	err = yaml.Unmarshal(read, &Scenar)
+	read, err = substituteVars(read, vars)
+	if err != nil {
+		fmt.Println("Error while substituting variables in scenario file")
+		return nil, err
+	}
+	return read, nil
+}
+
+// substituteVars resolves ${VAR}, ${VAR:-default} and ${VAR:?err} references
+// in data, looking each variable up in vars before falling back to the OS
+// environment. A nil/empty vars map substitutes from the OS environment only.
+func substituteVars(data []byte, vars map[string]string) ([]byte, error) {
+	mapping := func(key string) string {
+		if v, ok := vars[key]; ok {
+			return v
+		}
+		return os.Getenv(key)
+	}
+
+	out, err := envsubst.Eval(string(data), mapping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables: %w", err)
+	}
+	return []byte(out), nil
+}
+
+// ReadJsonData parses Scenar.Data via ParseDataJSON and stores the result in
+// Devices, for callers that rely on the package-level global.
+func ReadJsonData() error {
+	data, err := ParseDataJSON(Scenar.Data)
 	if err != nil {
-		fmt.Println("Error while decoding yaml data of scenario file")
 		return err
 	}
-	sort.Sort(Scenar)
+	Devices = *data
 	return nil
 }
 
-func ReadJsonData() error {
-	file, err := os.Open(Scenar.Data)
+// GetLabNameFromScenario reads the scenario file at scenarioPath (YAML if
+// its extension is .yaml/.yml, JSON otherwise) and returns the containerlab
+// lab name: the topology name declared in the dot2net device-data file its
+// Data field names, resolved relative to baseDir if not already absolute.
+func GetLabNameFromScenario(scenarioPath, baseDir string) (string, error) {
+	var scenario *Scenario
+	var err error
+	if ext := strings.ToLower(filepath.Ext(scenarioPath)); ext == ".yaml" || ext == ".yml" {
+		scenario, err = ParseScenarioYAML(scenarioPath, nil)
+	} else {
+		scenario, err = ParseScenarioJSON(scenarioPath, nil)
+	}
+	if err != nil {
+		return "", err
+	}
+	if scenario.Data == "" {
+		return "", fmt.Errorf("scenario %s has no data file set", scenarioPath)
+	}
+
+	dataPath := scenario.Data
+	if !filepath.IsAbs(dataPath) {
+		dataPath = filepath.Join(baseDir, dataPath)
+	}
+
+	data, err := ParseDataJSON(dataPath)
+	if err != nil {
+		return "", err
+	}
+	return data.Name, nil
+}
+
+// ParseDataJSON reads and unmarshals the dot2net device-data JSON file at
+// path into a fresh Data, touching no package-level state so callers (e.g.
+// ScenarioRunner) can load distinct scenarios in parallel.
+func ParseDataJSON(path string) (*Data, error) {
+	file, err := os.Open(path)
 	if err != nil {
 		fmt.Println("Fail to open the file")
-		return err
+		return nil, err
 	}
 	defer file.Close()
 
 	read, err := io.ReadAll(file)
 	if err != nil {
 		fmt.Println("Error during reading of the file")
-		return err
+		return nil, err
 	}
 
-	err = json.Unmarshal(read, &Devices)
-	if err != nil {
+	var data Data
+	if err := json.Unmarshal(read, &data); err != nil {
 		fmt.Println("Error while decoding json data")
-		return err
+		return nil, err
 	}
-	return nil
+	return &data, nil
 }
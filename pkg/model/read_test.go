@@ -0,0 +1,81 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadScenarioJSON_SubstitutesVars(t *testing.T) {
+	tmpDir := t.TempDir()
+	scenarioPath := filepath.Join(tmpDir, "scenario.json")
+	content := `{
+		"scenarioName": "sweep",
+		"duration": "${DURATION:-10s}",
+		"hosts": ["${HOST}"],
+		"event": []
+	}`
+	require.NoError(t, os.WriteFile(scenarioPath, []byte(content), 0644))
+
+	err := ReadScenarioJSON(scenarioPath, map[string]string{"HOST": "r1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "10s", Scenar.Duration)
+	assert.Equal(t, []string{"r1"}, Scenar.Hosts)
+}
+
+func TestReadScenarioJSON_VarsOverrideEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	scenarioPath := filepath.Join(tmpDir, "scenario.json")
+	content := `{"scenarioName": "sweep", "duration": "${DURATION}", "event": []}`
+	require.NoError(t, os.WriteFile(scenarioPath, []byte(content), 0644))
+
+	t.Setenv("DURATION", "30s")
+
+	err := ReadScenarioJSON(scenarioPath, map[string]string{"DURATION": "5s"})
+	require.NoError(t, err)
+	assert.Equal(t, "5s", Scenar.Duration)
+}
+
+func TestReadScenarioYAML_SubstitutesVars(t *testing.T) {
+	tmpDir := t.TempDir()
+	scenarioPath := filepath.Join(tmpDir, "scenario.yaml")
+	content := "scenarioName: sweep\nduration: \"${DURATION:-10s}\"\nevent: []\n"
+	require.NoError(t, os.WriteFile(scenarioPath, []byte(content), 0644))
+
+	err := ReadScenarioYAML(scenarioPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "10s", Scenar.Duration)
+}
+
+func TestParseScenarioJSON_TouchesNoGlobalState(t *testing.T) {
+	tmpDir := t.TempDir()
+	scenarioPath := filepath.Join(tmpDir, "scenario.json")
+	content := `{"scenarioName": "pure", "duration": "15s", "event": []}`
+	require.NoError(t, os.WriteFile(scenarioPath, []byte(content), 0644))
+
+	Scenar = Scenario{ScenarioName: "untouched"}
+
+	scenario, err := ParseScenarioJSON(scenarioPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "pure", scenario.ScenarioName)
+	assert.Equal(t, "15s", scenario.Duration)
+	assert.Equal(t, "untouched", Scenar.ScenarioName)
+}
+
+func TestParseDataJSON_TouchesNoGlobalState(t *testing.T) {
+	tmpDir := t.TempDir()
+	dataPath := filepath.Join(tmpDir, "data.json")
+	content := `{"name": "topo", "nodes": [{"name": "r1"}]}`
+	require.NoError(t, os.WriteFile(dataPath, []byte(content), 0644))
+
+	Devices = Data{Name: "untouched"}
+
+	data, err := ParseDataJSON(dataPath)
+	require.NoError(t, err)
+	assert.Equal(t, "topo", data.Name)
+	assert.Equal(t, "untouched", Devices.Name)
+}
@@ -5,6 +5,27 @@ const EventTypePumba = "pumba"
 const EventTypeShell = "shell"
 const EventTypeConfig = "config"
 const EventTypeCopy = "copy"
+const EventTypeCollect = "collect"
+const EventTypeWait = "wait"
+const EventTypeSSH = "ssh"
+const EventTypeAssert = "assert"
+
+// EventTypeRevert reverts every config file change events.EventExecutor has
+// journaled so far in the current scenario (see
+// EventExecutor.RestoreConfigChanges), without waiting for scenario
+// teardown to do it.
+const EventTypeRevert = "revert"
+
+// WaitForHealthy and WaitForCommand are the values a wait (or wait-gated
+// collect) Event's For field accepts. WaitForHealthy is the default when
+// For is empty.
+const WaitForHealthy = "healthy"
+const WaitForCommand = "command"
+
+// RunnerAPI and RunnerShell are the values a shell Event's Runner field
+// accepts. RunnerAPI is the default.
+const RunnerAPI = "api"
+const RunnerShell = "shell"
 
 type CommandOptions struct {
 	Duration       string  `json:"duration" yaml:"duration"`
@@ -44,17 +65,99 @@ type PumbaCommand struct {
 }
 
 type Event struct {
-	BeginTime         string              `json:"beginTime" yaml:"beginTime"`
-	Type              string              `json:"type" yaml:"type"`
-	Host              string              `json:"host" yaml:"host"`
-	Hosts             []string            `json:"hosts" yaml:"hosts"`
-	PumbaCommand      PumbaCommand        `json:"pumbaCommand" yaml:"pumbaCommand"`
-	ShellPath         string              `json:"shellPath" yaml:"shellPath"`
-	ShellCommands     []string            `json:"shellCommands" yaml:"shellCommands"`
+	BeginTime     string       `json:"beginTime" yaml:"beginTime"`
+	Type          string       `json:"type" yaml:"type"`
+	Host          string       `json:"host" yaml:"host"`
+	Hosts         []string     `json:"hosts" yaml:"hosts"`
+	PumbaCommand  PumbaCommand `json:"pumbaCommand" yaml:"pumbaCommand"`
+	ShellPath     string       `json:"shellPath" yaml:"shellPath"`
+	ShellCommands []string     `json:"shellCommands" yaml:"shellCommands"`
+	// Runner selects how a shell event's ShellCommands run: RunnerAPI
+	// (default, including when empty) execs them directly against the
+	// Docker Engine API with Cmd as a real []string, no shell-string
+	// escaping involved; RunnerShell instead forks `sh -c "docker exec
+	// ..."` (see ExecShellCommand), for daemons only reachable through the
+	// docker CLI in this process's environment.
+	Runner            string              `json:"runner" yaml:"runner"`
 	VtyshChanges      []string            `json:"vtyshChanges" yaml:"vtyshChanges"`
 	ConfigFileChanges []ConfigFileChanges `json:"configFileChanges" yaml:"configFileChanges"`
 	ToContainer       []FileCopy          `json:"toContainer" yaml:"toContainer"`
 	FromContainer     []FileCopy          `json:"fromContainer" yaml:"fromContainer"`
+	// Vars are rendered into a ToContainer FileCopy whose Src ends in
+	// ".tmpl" via text/template, alongside the target host's name and its
+	// model.Nodes/Connections entry, so one scenario can push differentiated
+	// configs (BGP AS numbers, neighbor IPs) to N nodes from one template.
+	Vars map[string]any `json:"vars" yaml:"vars"`
+	// PerHostVars overrides/extends Vars per host (keyed by host name), for
+	// values that differ per node rather than being shared across the event.
+	PerHostVars map[string]map[string]any `json:"perHostVars" yaml:"perHostVars"`
+	// Files lists container paths a collect event copies to the trial log
+	// directory, one subdirectory per host.
+	Files []string `json:"files" yaml:"files"`
+	// For is the readiness condition for a wait event: WaitForHealthy polls
+	// ContainerRuntime.Inspect's health status, WaitForCommand runs Command
+	// and, if CommandRegex is set, matches it against Command's stdout.
+	// Empty defaults to WaitForHealthy. A collect event may also set For to
+	// gate its collection on the same check before copying files out.
+	For string `json:"for" yaml:"for"`
+	// Timeout bounds how long a wait (or wait-gated collect) polls for
+	// readiness before failing, e.g. "30s". Defaults to 30s. An assert event
+	// reuses the same field to bound how long it retries Command before
+	// recording the assertion as failed. A shell event reuses it too, as the
+	// deadline for each individual ShellCommand invocation; empty means no
+	// deadline (the original fire-and-forget behavior).
+	Timeout string `json:"timeout" yaml:"timeout"`
+	// PollInterval sets how often a wait (or wait-gated collect) re-checks
+	// readiness, e.g. "2s". Defaults to 2s. An assert event reuses the same
+	// field as its retry interval.
+	PollInterval string `json:"pollInterval" yaml:"pollInterval"`
+	// Command is the readiness probe run inside the container when For is
+	// WaitForCommand, e.g. "vtysh -c 'show bgp summary'". An assert event
+	// runs Command and checks its result against the Expect* fields below.
+	Command string `json:"command" yaml:"command"`
+	// CommandRegex, if set, requires Command's stdout to match it for the
+	// host to be considered ready. Empty treats any zero-exit-code run of
+	// Command as ready.
+	CommandRegex string `json:"commandRegex" yaml:"commandRegex"`
+	// ExpectExitCode is the exit code an assert event's Command must return.
+	// Defaults to 0 (success).
+	ExpectExitCode int `json:"expectExitCode" yaml:"expectExitCode"`
+	// ExpectStdoutContains, if set, requires Command's stdout to contain it.
+	ExpectStdoutContains string `json:"expectStdoutContains" yaml:"expectStdoutContains"`
+	// ExpectStdoutRegex, if set, requires Command's stdout to match it.
+	ExpectStdoutRegex string `json:"expectStdoutRegex" yaml:"expectStdoutRegex"`
+	// ExpectStderrContains, if set, requires Command's stderr to contain it.
+	ExpectStderrContains string `json:"expectStderrContains" yaml:"expectStderrContains"`
+	// Parallelism bounds how many hosts a shell, copy, or collect event
+	// processes at once. Empty/zero falls back to Scenario.Parallelism,
+	// then to 1 (serial, the behavior before per-event fan-out existed).
+	Parallelism int `json:"parallelism" yaml:"parallelism"`
+	// FailFast, for a shell event, cancels every other in-flight host/command
+	// as soon as one fails (errgroup's standard semantics) instead of the
+	// default of letting every host/command run to completion and reporting
+	// every failure in ExecShellCommand's returned results.
+	FailFast bool `json:"failFast" yaml:"failFast"`
+	// Retries is how many additional attempts a shell event makes at a
+	// ShellCommand after it exits non-zero, 0 meaning no retries. RetryBackoff
+	// is the delay before the first retry, doubling after each subsequent one
+	// (e.g. "500ms" retries at 500ms, 1s, 2s, ...). Empty means retry with no
+	// delay. Useful for readiness-style checks that are flaky for a few
+	// seconds after a fault-injection event rather than reliably broken.
+	Retries      int    `json:"retries" yaml:"retries"`
+	RetryBackoff string `json:"retryBackoff" yaml:"retryBackoff"`
+	// NetemDriver overrides Scenario.NetemDriver for this event only, e.g. to
+	// force a single flaky delay event back onto "pumba" while the rest of
+	// the scenario uses "netlink". Empty inherits the scenario-level setting.
+	NetemDriver string `json:"netemDriver" yaml:"netemDriver"`
+	// SSHHost, SSHUser, IdentityFile, and KnownHosts target an ssh event at
+	// a device that isn't wrapped in a containerlab node (a real VM or
+	// bare-metal router), so ShellCommands/ToContainer/FromContainer run
+	// over SSH/SFTP instead of docker exec/cp. SSHHost is "host" or
+	// "host:port" (default port 22).
+	SSHHost      string `json:"sshHost" yaml:"sshHost"`
+	SSHUser      string `json:"sshUser" yaml:"sshUser"`
+	IdentityFile string `json:"identityFile" yaml:"identityFile"`
+	KnownHosts   string `json:"knownHosts" yaml:"knownHosts"`
 }
 
 func (e Event) GetHosts() (hosts []string) {
@@ -78,6 +181,141 @@ type Scenario struct {
 	Hosts []string `json:"hosts" yaml:"hosts"`
 	// LogFiles []string `json:"logfiles" yaml:"logfiles"`
 	Event []Event `json:"event" yaml:"event"`
+	// Runtime selects the container runtime backend ("containerlab" or
+	// "containerd"). Empty means the executor's default (containerlab).
+	Runtime string `json:"runtime" yaml:"runtime"`
+	// ContainerEngine selects the engine EventExecutor uses to exec into and
+	// copy files in/out of containers ("docker" or "podman"). Empty means
+	// runtime.DefaultEngine (docker).
+	ContainerEngine string `json:"containerEngine" yaml:"containerEngine"`
+	// Reuse keeps the topology deployed across trial repetitions of this
+	// scenario instead of destroying and redeploying it for every trial.
+	// Between trials, EventExecutor.Reset undoes the mutable state a trial
+	// touched (copied files, vtysh changes) and runs ResetHooks, rather than
+	// the runner tearing the lab down. Ignored when --no-reuse is set.
+	Reuse bool `json:"reuse" yaml:"reuse"`
+	// ResetHooks are shell commands run on every host in Hosts by
+	// EventExecutor.Reset, after it undoes copied files and vtysh changes.
+	// Use this for state Reset cannot infer automatically, e.g. "ip route
+	// flush table all" or "tc qdisc del dev eth0 root".
+	ResetHooks []string `json:"resetHooks" yaml:"resetHooks"`
+	// Parallelism is the scenario-wide default for Event.Parallelism, used by
+	// any event that doesn't set its own.
+	Parallelism int `json:"parallelism" yaml:"parallelism"`
+	// NetemDriver selects how pumba events apply netem effects (delay, loss,
+	// corrupt, duplicate, rate): "pumba" runs them through a per-command
+	// Pumba/containerd helper container (the default), "netlink" programs
+	// the target's qdiscs directly over its network namespace, falling back
+	// to "pumba" for commands it can't express (stop/pause) or if the
+	// kernel/netns doesn't support the required qdisc. Empty means "pumba".
+	// An Event.NetemDriver override takes precedence over this.
+	NetemDriver string `json:"netemDriver" yaml:"netemDriver"`
+	// Capture configures the pcap captures NetworkController streams out of
+	// each host in Hosts while the scenario runs. The zero value captures
+	// every packet on every interface, untruncated and unfiltered.
+	Capture CaptureConfig `json:"capture" yaml:"capture"`
+	// Readiness gates scenario execution on per-node healthchecks run once
+	// the topology is deployed, via network.WaitForTopologyReady. The zero
+	// value (no probes) runs no gate, so events start immediately after
+	// deploy as they always have.
+	Readiness ReadinessConfig `json:"readiness" yaml:"readiness"`
+	// Subnets configures the pools an ipam.Allocator should draw this
+	// scenario's IPv4/IPv6 subnets from, letting operators steer deploys
+	// away from ranges already used elsewhere on the host. The zero value
+	// leaves the choice to whichever SubnetAllocator the caller configured.
+	Subnets SubnetConfig `json:"subnets" yaml:"subnets"`
+	// VtyshMode selects how vtysh events reach each host: "oneshot" forks a
+	// fresh vtysh process per event (the default), "session" keeps one
+	// vtysh process per container running across events via a
+	// events.VtyshSessionPool, cutting per-event fork/exec overhead. Falls
+	// back to "oneshot" if the container runtime doesn't support interactive
+	// exec sessions (e.g. Podman today). Empty means "oneshot".
+	VtyshMode string `json:"vtyshMode" yaml:"vtyshMode"`
+}
+
+// SubnetConfig lists the candidate subnet pools for a scenario's deploy.
+// It is read by callers that construct an ipam.Allocator; the zero value
+// (no pools) leaves the allocator on its own defaults (172.16.0.0/12 for
+// IPv4, 3fff:172:20::/48 for IPv6).
+type SubnetConfig struct {
+	// IPv4Pools are the CIDR ranges to draw IPv4 subnets from, in order.
+	IPv4Pools []string `json:"ipv4Pools" yaml:"ipv4Pools"`
+	// IPv6Pools are the CIDR ranges to draw IPv6 /64s from, in order.
+	IPv6Pools []string `json:"ipv6Pools" yaml:"ipv6Pools"`
+}
+
+// CaptureConfig controls how the network package captures tcpdump traffic
+// for a scenario. The zero value captures every packet, untruncated and
+// unfiltered, with no rotation or compression.
+type CaptureConfig struct {
+	// Snaplen is the number of bytes captured per packet (tcpdump -s). Zero
+	// uses tcpdump's own default.
+	Snaplen int `json:"snaplen" yaml:"snaplen"`
+	// Filter is a BPF filter expression (e.g. "tcp port 179") applied to
+	// every capture. Empty captures all traffic.
+	Filter string `json:"filter" yaml:"filter"`
+	// RotateSize, in bytes, rotates the capture into a new file once the
+	// current one reaches this size (tcpdump -C, which takes megabytes and
+	// rounds up). Zero disables rotation.
+	RotateSize int64 `json:"rotateSize" yaml:"rotateSize"`
+	// Gzip compresses each pcap file on the fly as it's streamed out of the
+	// container, appending ".gz" to its filename.
+	Gzip bool `json:"gzip" yaml:"gzip"`
+}
+
+// ReadinessProbeKind selects how a ReadinessProbe checks a node, mirroring
+// the checks a container healthcheck would run.
+type ReadinessProbeKind string
+
+const (
+	// ReadinessProbePing execs "ping" against Target from inside the node's
+	// container. This is the default when Kind is empty.
+	ReadinessProbePing ReadinessProbeKind = "ping"
+	// ReadinessProbeTCP execs a TCP connect (via nc) to Target, a
+	// "host:port" pair, from inside the node's container.
+	ReadinessProbeTCP ReadinessProbeKind = "tcp"
+	// ReadinessProbeCommand runs Target as a shell command inside the
+	// node's container; the probe passes on exit code 0.
+	ReadinessProbeCommand ReadinessProbeKind = "command"
+	// ReadinessProbeRoute looks up Target, a destination prefix, in the
+	// node's route table via "ip route get".
+	ReadinessProbeRoute ReadinessProbeKind = "route"
+)
+
+// ReadinessProbe checks that Host has reached a ready state before any
+// scenario event runs.
+type ReadinessProbe struct {
+	// Host is the node this probe gates, matching a name in Scenario.Hosts.
+	Host string `json:"host" yaml:"host"`
+	// Kind selects the check this probe runs. Empty defaults to
+	// ReadinessProbePing.
+	Kind ReadinessProbeKind `json:"kind" yaml:"kind"`
+	// Target is the probe's argument: an address for "ping", a "host:port"
+	// pair for "tcp", a shell command for "command", or a destination
+	// prefix for "route".
+	Target string `json:"target" yaml:"target"`
+}
+
+// ReadinessConfig configures network.WaitForTopologyReady's scenario-wide
+// healthcheck gate, modeled on Docker HEALTHCHECK: each probe is retried
+// every Interval, failures during StartPeriod don't count against Retries,
+// and a node is declared unready once Retries consecutive attempts fail
+// after StartPeriod has elapsed.
+type ReadinessConfig struct {
+	// Probes are the per-node healthchecks to run. A host with no matching
+	// probe isn't gated.
+	Probes []ReadinessProbe `json:"probes" yaml:"probes"`
+	// Interval between probe attempts. Empty defaults to 2s.
+	Interval string `json:"interval" yaml:"interval"`
+	// Timeout bounds a single probe attempt. Empty defaults to 2s.
+	Timeout string `json:"timeout" yaml:"timeout"`
+	// Retries is how many consecutive failed attempts are tolerated before
+	// a node is declared unready. Zero defaults to 3.
+	Retries int `json:"retries" yaml:"retries"`
+	// StartPeriod is grace time after deploy during which failed probes
+	// don't count against Retries, mirroring Docker HEALTHCHECK's
+	// --start-period. Empty means no grace period.
+	StartPeriod string `json:"startPeriod" yaml:"startPeriod"`
 }
 
 var Scenar Scenario
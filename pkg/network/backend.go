@@ -0,0 +1,189 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/3atlab/netroub/pkg/runtime"
+	"github.com/docker/docker/api/types"
+	dockernetwork "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/sirupsen/logrus"
+)
+
+// Backend performs the actual containerlab deploy/destroy for a
+// NetworkController. NetworkController falls back to an unexported
+// CLIBackend (the original "sudo containerlab"-via-Runner behavior) when
+// its Backend field is left nil, so existing callers are unaffected; set
+// Backend to APIBackend to drive the Docker Engine API directly instead.
+type Backend interface {
+	// Deploy brings up labName's topology on networkName with the given
+	// subnets.
+	Deploy(ctx context.Context, labName, topoPath, networkName, ipv4Subnet, ipv6Subnet string) error
+	// Destroy tears labName down, removing networkName along with it.
+	Destroy(ctx context.Context, labName, networkName string) error
+}
+
+// CLIBackend is the original Backend: it drives "containerlab
+// deploy"/"containerlab destroy" through sudo via a runtime.CommandRunner.
+// Its calls are serialized on networkOpMu to avoid netlink race conditions
+// between concurrent containerlab processes. APIBackend's own Docker API
+// calls run outside networkOpMu, but it runs containerlab through an
+// embedded CLIBackend, so that part is serialized the same way.
+type CLIBackend struct {
+	Runner runtime.CommandRunner
+}
+
+// Deploy runs "sudo containerlab deploy" with labName/topoPath/networkName
+// and the given subnets.
+func (b *CLIBackend) Deploy(ctx context.Context, labName, topoPath, networkName, ipv4Subnet, ipv6Subnet string) error {
+	networkOpMu.Lock()
+	defer networkOpMu.Unlock()
+
+	// Log after acquiring lock so log order reflects actual execution order
+	logrus.Infof("Deploying network with lab name: %s", labName)
+
+	elevator, err := runtime.NewPrivilegeElevator()
+	if err != nil {
+		return err
+	}
+
+	name, args := elevator.Elevate("containerlab", "deploy",
+		"--name", labName,
+		"--topo", topoPath,
+		"--network", networkName,
+		"--ipv4-subnet", ipv4Subnet,
+		"--ipv6-subnet", ipv6Subnet)
+	output, err := b.Runner.Run(ctx, name, args...)
+	if err != nil {
+		return fmt.Errorf("containerlab deploy failed: %w, output: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	logrus.Debug(string(output))
+	return nil
+}
+
+// Destroy runs "sudo containerlab destroy --name labName --cleanup".
+// networkName is unused here: containerlab's own --cleanup removes it, the
+// same as before APIBackend existed.
+func (b *CLIBackend) Destroy(ctx context.Context, labName, networkName string) error {
+	networkOpMu.Lock()
+	defer networkOpMu.Unlock()
+
+	// Log after acquiring lock so log order reflects actual execution order
+	logrus.Infof("Destroying network with lab name: %s", labName)
+
+	elevator, err := runtime.NewPrivilegeElevator()
+	if err != nil {
+		return err
+	}
+
+	// Use --name only (without --topo) to avoid containerlab trying to
+	// create a clab instance with default network settings.
+	// --cleanup ensures Docker network is also removed.
+	name, args := elevator.Elevate("containerlab", "destroy",
+		"--name", labName,
+		"--cleanup")
+	output, err := b.Runner.Run(ctx, name, args...)
+	if err != nil {
+		return fmt.Errorf("containerlab destroy failed: %w, output: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	logrus.Debug(string(output))
+	return nil
+}
+
+// dockerNetworkClient is the subset of the Docker Engine SDK APIBackend
+// depends on, mirroring the narrow-interface-per-dependency pattern used by
+// pkg/executor/cleaner.go's dockerAPIClient and pkg/runtime/docker.go's
+// dockerExecClient, so tests can supply a fake without a real daemon.
+type dockerNetworkClient interface {
+	NetworkCreate(ctx context.Context, name string, options types.NetworkCreate) (types.NetworkCreateResponse, error)
+	NetworkInspect(ctx context.Context, network string, options types.NetworkInspectOptions) (types.NetworkResource, error)
+	NetworkRemove(ctx context.Context, networkID string) error
+}
+
+// newDockerNetworkClient constructs a Docker client from the environment
+// (DOCKER_HOST, DOCKER_TLS_VERIFY, DOCKER_CERT_PATH). It is a var so tests
+// can substitute a fake dockerNetworkClient.
+var newDockerNetworkClient = func() (dockerNetworkClient, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	return cli, nil
+}
+
+// APIBackend drives network lifecycle through the Docker Engine API
+// (client.NetworkCreate/NetworkRemove, with a caller-supplied network name
+// the same way libnetwork's NewNetwork lets a caller pick a network's
+// identity up front) instead of relying on containerlab's own "--network"
+// CLI flag and "--cleanup" teardown, returning structured errors instead of
+// parsed stderr for that part of deploy/destroy.
+//
+// containerlab does not currently ship an importable Go API this tree
+// vendors (there's no go.mod/vendor directory here to pull in
+// github.com/srl-labs/containerlab), so the node/link provisioning
+// "containerlab deploy" itself performs still runs through an embedded
+// CLIBackend; APIBackend only takes over the Docker-native pieces it can
+// own directly today. Once containerlab is vendored, its deploy/destroy
+// calls belong here instead of in the embedded CLIBackend.
+type APIBackend struct {
+	docker dockerNetworkClient
+	cli    *CLIBackend
+}
+
+// NewAPIBackend creates an APIBackend backed by the Docker daemon resolved
+// from the environment, still using runner (via the platform's
+// PrivilegeElevator) for the containerlab-specific part of deploy/destroy
+// it doesn't yet own directly.
+func NewAPIBackend(runner runtime.CommandRunner) (*APIBackend, error) {
+	docker, err := newDockerNetworkClient()
+	if err != nil {
+		return nil, err
+	}
+	return &APIBackend{docker: docker, cli: &CLIBackend{Runner: runner}}, nil
+}
+
+// Deploy creates networkName via the Docker API with ipv4Subnet/ipv6Subnet
+// before delegating to containerlab for the topology deploy itself, since
+// "containerlab deploy --network" expects the network to already exist
+// under its unique parallel-safe name.
+func (b *APIBackend) Deploy(ctx context.Context, labName, topoPath, networkName, ipv4Subnet, ipv6Subnet string) error {
+	_, err := b.docker.NetworkCreate(ctx, networkName, types.NetworkCreate{
+		Driver:     "bridge",
+		EnableIPv6: true,
+		IPAM: &dockernetwork.IPAM{
+			Config: []dockernetwork.IPAMConfig{
+				{Subnet: ipv4Subnet},
+				{Subnet: ipv6Subnet},
+			},
+		},
+	})
+	if err != nil && !strings.Contains(err.Error(), "already exists") {
+		return fmt.Errorf("failed to create network %s: %w", networkName, err)
+	}
+
+	return b.cli.Deploy(ctx, labName, topoPath, networkName, ipv4Subnet, ipv6Subnet)
+}
+
+// Destroy delegates to containerlab for the lab's containers, then removes
+// networkName via the Docker API directly, returning a structured error
+// instead of parsed stderr if it's still in use; an already-gone network
+// (e.g. containerlab's own --cleanup got to it first) is not an error.
+func (b *APIBackend) Destroy(ctx context.Context, labName, networkName string) error {
+	if err := b.cli.Destroy(ctx, labName, networkName); err != nil {
+		return err
+	}
+
+	inspect, err := b.docker.NetworkInspect(ctx, networkName, types.NetworkInspectOptions{})
+	if err != nil {
+		return nil
+	}
+
+	if err := b.docker.NetworkRemove(ctx, inspect.ID); err != nil {
+		return fmt.Errorf("failed to remove network %s: %w", networkName, err)
+	}
+	return nil
+}
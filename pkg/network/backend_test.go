@@ -0,0 +1,120 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDockerNetworkClient is a minimal in-memory stand-in for
+// dockerNetworkClient.
+type fakeDockerNetworkClient struct {
+	created     []string // network names NetworkCreate was called with
+	removed     []string // network IDs NetworkRemove was called with
+	createErr   error
+	inspectErr  error
+	inspectedID string
+}
+
+func (f *fakeDockerNetworkClient) NetworkCreate(ctx context.Context, name string, options types.NetworkCreate) (types.NetworkCreateResponse, error) {
+	f.created = append(f.created, name)
+	if f.createErr != nil {
+		return types.NetworkCreateResponse{}, f.createErr
+	}
+	return types.NetworkCreateResponse{ID: "net-" + name}, nil
+}
+
+func (f *fakeDockerNetworkClient) NetworkInspect(ctx context.Context, network string, options types.NetworkInspectOptions) (types.NetworkResource, error) {
+	if f.inspectErr != nil {
+		return types.NetworkResource{}, f.inspectErr
+	}
+	id := f.inspectedID
+	if id == "" {
+		id = "net-" + network
+	}
+	return types.NetworkResource{ID: id}, nil
+}
+
+func (f *fakeDockerNetworkClient) NetworkRemove(ctx context.Context, networkID string) error {
+	f.removed = append(f.removed, networkID)
+	return nil
+}
+
+func TestAPIBackend_Deploy_CreatesNetworkThenDelegatesToCLI(t *testing.T) {
+	mock := &mockRunner{}
+	docker := &fakeDockerNetworkClient{}
+	backend := &APIBackend{docker: docker, cli: &CLIBackend{Runner: mock}}
+
+	err := backend.Deploy(context.Background(), "test-lab", "/path/to/topo.yaml", "clab-test-lab", "172.20.0.0/24", "2001:db8::/64")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"clab-test-lab"}, docker.created)
+	assert.Equal(t, 1, len(mock.calls))
+	assert.True(t, mock.callContains("containerlab", "deploy", "--network", "clab-test-lab"))
+}
+
+func TestAPIBackend_Deploy_IgnoresAlreadyExistsError(t *testing.T) {
+	mock := &mockRunner{}
+	docker := &fakeDockerNetworkClient{createErr: errors.New("network clab-test-lab already exists")}
+	backend := &APIBackend{docker: docker, cli: &CLIBackend{Runner: mock}}
+
+	err := backend.Deploy(context.Background(), "test-lab", "/path/to/topo.yaml", "clab-test-lab", "172.20.0.0/24", "2001:db8::/64")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(mock.calls))
+}
+
+func TestAPIBackend_Deploy_PropagatesOtherCreateErrors(t *testing.T) {
+	mock := &mockRunner{}
+	docker := &fakeDockerNetworkClient{createErr: errors.New("pool overlaps with other one on this address space")}
+	backend := &APIBackend{docker: docker, cli: &CLIBackend{Runner: mock}}
+
+	err := backend.Deploy(context.Background(), "test-lab", "/path/to/topo.yaml", "clab-test-lab", "172.20.0.0/24", "2001:db8::/64")
+
+	assert.Error(t, err)
+	assert.Empty(t, mock.calls, "containerlab deploy should not run if the network couldn't be created")
+}
+
+func TestAPIBackend_Destroy_RemovesNetworkAfterCLIDestroy(t *testing.T) {
+	mock := &mockRunner{}
+	docker := &fakeDockerNetworkClient{}
+	backend := &APIBackend{docker: docker, cli: &CLIBackend{Runner: mock}}
+
+	err := backend.Destroy(context.Background(), "test-lab", "clab-test-lab")
+
+	require.NoError(t, err)
+	assert.True(t, mock.callContains("containerlab", "destroy", "--name", "test-lab"))
+	assert.Equal(t, []string{"net-clab-test-lab"}, docker.removed)
+}
+
+func TestAPIBackend_Destroy_NetworkAlreadyGoneIsNotAnError(t *testing.T) {
+	mock := &mockRunner{}
+	docker := &fakeDockerNetworkClient{inspectErr: errors.New("network clab-test-lab not found")}
+	backend := &APIBackend{docker: docker, cli: &CLIBackend{Runner: mock}}
+
+	err := backend.Destroy(context.Background(), "test-lab", "clab-test-lab")
+
+	assert.NoError(t, err)
+	assert.Empty(t, docker.removed)
+}
+
+func TestNetworkController_UsesConfiguredBackend(t *testing.T) {
+	mock := &mockRunner{}
+	backend := &CLIBackend{Runner: mock}
+	controller := &NetworkController{LabName: "test-lab", Backend: backend}
+
+	assert.Same(t, backend, controller.backend())
+}
+
+func TestNetworkController_DefaultsToCLIBackendFromRunner(t *testing.T) {
+	mock := &mockRunner{}
+	controller := &NetworkController{LabName: "test-lab", Runner: mock}
+
+	cli, ok := controller.backend().(*CLIBackend)
+	require.True(t, ok)
+	assert.Same(t, mock, cli.Runner)
+}
@@ -0,0 +1,71 @@
+package network
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/3atlab/netroub/pkg/model"
+)
+
+// tcpdumpArgs builds the tcpdump command line that writes binary pcap data
+// for iface to stdout (-w -), so it can be streamed out of the container via
+// ContainerRuntime.StreamExecOutput instead of redirected to a file.
+func tcpdumpArgs(cfg model.CaptureConfig, iface string) []string {
+	args := []string{"tcpdump", "-i", iface, "-U", "-w", "-"}
+	if cfg.Snaplen > 0 {
+		args = append(args, "-s", fmt.Sprintf("%d", cfg.Snaplen))
+	}
+	if cfg.RotateSize > 0 {
+		const mb = 1 << 20
+		rotateMB := (cfg.RotateSize + mb - 1) / mb
+		args = append(args, "-C", fmt.Sprintf("%d", rotateMB))
+	}
+	if cfg.Filter != "" {
+		args = append(args, cfg.Filter)
+	}
+	return args
+}
+
+// pcapCapture tracks the live per-interface tcpdump streams started for one
+// host, so StopPcapCapture can cancel them and wait for the writes they're
+// still flushing to finish.
+type pcapCapture struct {
+	cancel context.CancelFunc
+	done   []chan error
+}
+
+// pcapWriter opens dstPath for a capture's output, wrapping it in a gzip
+// writer (and appending ".gz" to the path) when gzipped is set.
+func pcapWriter(dstPath string, gzipped bool) (io.WriteCloser, error) {
+	if gzipped {
+		dstPath += ".gz"
+	}
+	f, err := os.Create(dstPath)
+	if err != nil {
+		return nil, err
+	}
+	if !gzipped {
+		return f, nil
+	}
+	return &gzipFileWriter{f: f, w: gzip.NewWriter(f)}, nil
+}
+
+// gzipFileWriter closes both the gzip stream and the underlying file it
+// wraps, flushing any buffered compressed data before the file is closed.
+type gzipFileWriter struct {
+	f *os.File
+	w *gzip.Writer
+}
+
+func (g *gzipFileWriter) Write(p []byte) (int, error) { return g.w.Write(p) }
+
+func (g *gzipFileWriter) Close() error {
+	if err := g.w.Close(); err != nil {
+		g.f.Close()
+		return err
+	}
+	return g.f.Close()
+}
@@ -2,11 +2,11 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
 	"sync"
 	"time"
 
@@ -28,15 +28,47 @@ type NetworkController struct {
 	Devices  *model.Data
 	LabName  string
 	Runner   runtime.CommandRunner
+
+	// ContainerRuntime performs tcpdump setup/collection, the same
+	// Docker/Podman abstraction events.EventExecutor uses, so scenarios on
+	// rootless Podman hosts can run captures without a Docker daemon.
+	ContainerRuntime runtime.ContainerRuntime
+
+	// Backend, if set, performs Deploy/Destroy instead of the default
+	// CLIBackend built from Runner. Set it to an APIBackend to create and
+	// remove the lab's Docker network through the Docker Engine API; its
+	// containerlab deploy/destroy step still runs through an embedded
+	// CLIBackend, so that step is serialized on networkOpMu all the same.
+	Backend Backend
+
+	// Capture, if set, performs SetupTcpdump's packet capture instead of the
+	// default ExecCapture built from ContainerRuntime. Set it to a
+	// NetnsCapture to capture from the host process instead of running
+	// tcpdump inside the container.
+	Capture PacketCapture
+
+	// Subnets, if set, allocates Deploy's IPv4/IPv6 subnets and releases
+	// them in Destroy instead of the default defaultSubnetAllocator (plain
+	// generateSubnet/generateIPv6Subnet). Set it to an *ipam.Allocator to
+	// persist allocations across scenario runs and avoid colliding with the
+	// host's existing Docker networks.
+	Subnets SubnetAllocator
+
+	capturesMu sync.Mutex
+	captures   map[string]*pcapCapture
 }
 
 // NewNetworkController creates a new NetworkController instance.
-func NewNetworkController(scenario *model.Scenario, devices *model.Data, labName string, runner runtime.CommandRunner) *NetworkController {
+// containerRuntime is used for container-level operations (tcpdump setup/
+// collection); runner is used only for the containerlab CLI itself (deploy/
+// destroy).
+func NewNetworkController(scenario *model.Scenario, devices *model.Data, labName string, runner runtime.CommandRunner, containerRuntime runtime.ContainerRuntime) *NetworkController {
 	return &NetworkController{
-		Scenario: scenario,
-		Devices:  devices,
-		LabName:  labName,
-		Runner:   runner,
+		Scenario:         scenario,
+		Devices:          devices,
+		LabName:          labName,
+		Runner:           runner,
+		ContainerRuntime: containerRuntime,
 	}
 }
 
@@ -45,153 +77,177 @@ func (c *NetworkController) ClabHostName(host string) string {
 	return "clab-" + c.LabName + "-" + host
 }
 
-// Deploy starts the containerlab network.
-// Deploy/Destroy operations are serialized via networkOpMu to prevent
-// netlink race conditions during parallel execution.
-func (c *NetworkController) Deploy() error {
+// backend returns c.Backend, or a CLIBackend wrapping c.Runner if unset, so
+// Deploy/Destroy always have one to call without every caller needing to
+// construct one themselves.
+func (c *NetworkController) backend() Backend {
+	if c.Backend != nil {
+		return c.Backend
+	}
+	return &CLIBackend{Runner: c.Runner}
+}
+
+// capture returns c.Capture, or an ExecCapture wrapping c.ContainerRuntime if
+// unset, so SetupTcpdump always has one to call without every caller
+// needing to construct one themselves.
+func (c *NetworkController) capture() PacketCapture {
+	if c.Capture != nil {
+		return c.Capture
+	}
+	return &ExecCapture{Runtime: c.ContainerRuntime}
+}
+
+// subnets returns c.Subnets, or a defaultSubnetAllocator if unset, so
+// Deploy/Destroy always have one to call without every caller needing to
+// construct one themselves.
+func (c *NetworkController) subnets() SubnetAllocator {
+	if c.Subnets != nil {
+		return c.Subnets
+	}
+	return defaultSubnetAllocator{}
+}
+
+// Deploy starts the containerlab network. Canceling ctx aborts the
+// in-flight containerlab process (SIGKILL, via CommandRunner.Run); it does
+// not roll back a deploy that already finished.
+// The containerlab deploy/destroy step is serialized via networkOpMu to
+// prevent netlink race conditions during parallel execution, with the
+// default CLIBackend and with an APIBackend alike (APIBackend runs it
+// through an embedded CLIBackend).
+func (c *NetworkController) Deploy(ctx context.Context) error {
 	// Get device count for subnet size calculation
 	deviceCount := len(c.Devices.Nodes)
 	if deviceCount == 0 {
 		deviceCount = 254 // Default to /24 if no devices loaded
 	}
 
-	// Generate unique IPv4 subnet based on device count and lab index
-	ipv4Subnet, err := generateSubnet(c.LabName, deviceCount)
-	if err != nil {
-		return fmt.Errorf("failed to allocate IPv4 subnet: %w", err)
-	}
-
-	// Generate unique IPv6 subnet for parallel execution
-	ipv6Subnet, err := generateIPv6Subnet(c.LabName)
+	ipv4Subnet, ipv6Subnet, err := c.subnets().Allocate(ctx, c.LabName, deviceCount)
 	if err != nil {
-		return fmt.Errorf("failed to allocate IPv6 subnet: %w", err)
+		return fmt.Errorf("failed to allocate subnets: %w", err)
 	}
 
-	// Serialize containerlab deploy to avoid netlink race conditions
-	networkOpMu.Lock()
-	defer networkOpMu.Unlock()
-
-	// Log after acquiring lock so log order reflects actual execution order
-	logrus.Infof("Deploying network with lab name: %s", c.LabName)
-
 	// Use unique network name for parallel execution
 	networkName := "clab-" + c.LabName
-	output, err := c.Runner.Run("sudo", "containerlab", "deploy",
-		"--name", c.LabName,
-		"--topo", c.Scenario.Topo,
-		"--network", networkName,
-		"--ipv4-subnet", ipv4Subnet,
-		"--ipv6-subnet", ipv6Subnet)
-	if err != nil {
-		return fmt.Errorf("containerlab deploy failed: %w, output: %s", err, strings.TrimSpace(string(output)))
-	}
-
-	logrus.Debug(string(output))
-	return nil
+	return c.backend().Deploy(ctx, c.LabName, c.Scenario.Topo, networkName, ipv4Subnet, ipv6Subnet)
 }
 
-// Destroy stops and removes the containerlab network.
-// Deploy/Destroy operations are serialized via networkOpMu to prevent
-// netlink race conditions during parallel execution.
-func (c *NetworkController) Destroy() error {
-	// Serialize containerlab destroy to avoid netlink race conditions
-	networkOpMu.Lock()
-	defer networkOpMu.Unlock()
-
-	// Log after acquiring lock so log order reflects actual execution order
-	logrus.Infof("Destroying network with lab name: %s", c.LabName)
-
-	// Use --name only (without --topo) to avoid containerlab trying to
-	// create a clab instance with default network settings.
-	// --cleanup ensures Docker network is also removed.
-	output, err := c.Runner.Run("sudo", "containerlab", "destroy",
-		"--name", c.LabName,
-		"--cleanup")
-	if err != nil {
-		return fmt.Errorf("containerlab destroy failed: %w, output: %s", err, strings.TrimSpace(string(output)))
+// Destroy stops and removes the containerlab network, then releases
+// whatever subnets Deploy allocated for it. Callers that need teardown to
+// run even after their own ctx was canceled (e.g. a deferred cleanup on
+// Ctrl-C) should pass a fresh context here instead of the canceled one, so
+// Destroy still gets a chance to run to completion.
+// The containerlab deploy/destroy step is serialized via networkOpMu to
+// prevent netlink race conditions during parallel execution, with the
+// default CLIBackend and with an APIBackend alike (APIBackend runs it
+// through an embedded CLIBackend).
+func (c *NetworkController) Destroy(ctx context.Context) error {
+	networkName := "clab-" + c.LabName
+	if err := c.backend().Destroy(ctx, c.LabName, networkName); err != nil {
+		return err
 	}
-
-	logrus.Debug(string(output))
-	return nil
+	return c.subnets().Release(ctx, c.LabName)
 }
 
-// SetupTcpdump sets up tcpdump on a host container.
-func (c *NetworkController) SetupTcpdump(node string) error {
+// SetupTcpdump starts a live pcap capture on each interface of node's
+// container, per c.Scenario.Capture, via c.capture() (ExecCapture's tcpdump
+// by default). The captured pcap data streams straight into a
+// tcpdump_<iface>.pcap file under the scenario's tcpdump log directory as
+// it's produced, instead of being redirected to a file inside the container
+// and copied out later. Call CollectTcpdumpLogs (or StopPcapCapture) to stop
+// it. The capture's own lifetime is bound to ctx: canceling it before the
+// capture is explicitly stopped ends the stream early, the same as calling
+// StopPcapCapture.
+func (c *NetworkController) SetupTcpdump(ctx context.Context, node string) error {
 	containerName := c.ClabHostName(node)
-	topoPath := c.findTopoPath() + "/" + node
-	scriptPath := topoPath + "/tcpdump.sh"
-
-	// Create directory if necessary
-	if err := os.MkdirAll(topoPath, 0755); err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", topoPath, err)
+	tcpdumpDir := filepath.Join(c.findTopoPath(), node, "tcpdump")
+	if err := os.MkdirAll(tcpdumpDir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", tcpdumpDir, err)
 	}
 
-	// Create the tcpdump.sh file
-	file, err := os.Create(scriptPath)
-	if err != nil {
-		return fmt.Errorf("failed to create tcpdump.sh: %w", err)
-	}
-	defer file.Close()
-
-	// Change permissions
-	if err := os.Chmod(scriptPath, 0775); err != nil {
-		return fmt.Errorf("failed to chmod tcpdump.sh: %w", err)
-	}
-
-	// Create tcpdump directory in container (use absolute path for consistency)
-	output, err := c.Runner.Run("sudo", "docker", "exec", "-d", containerName, "mkdir", "/tcpdump")
-	if err != nil {
-		return fmt.Errorf("failed to create tcpdump directory in container: %w, output: %s", err, string(output))
-	}
-	logrus.Debugf("Created tcpdump directory in %s", containerName)
-
-	// Write script header
-	if _, err := file.WriteString("#!/bin/sh \n"); err != nil {
-		return fmt.Errorf("failed to write tcpdump.sh: %w", err)
-	}
-
-	// Add tcpdump commands for each interface
 	nodeIndex := c.getDeviceIndex(node)
 	if nodeIndex < 0 {
 		return fmt.Errorf("device %s not found", node)
 	}
+
+	cfg := c.Scenario.Capture
+	captureCtx, cancel := context.WithCancel(ctx)
+	capture := &pcapCapture{cancel: cancel}
+
 	for _, inter := range c.Devices.Nodes[nodeIndex].Interfaces {
-		line := fmt.Sprintf("tcpdump -i %s -n -v > /tcpdump/tcpdump_%s.log & \n", inter.Name, inter.Name)
-		if _, err := file.WriteString(line); err != nil {
-			return fmt.Errorf("failed to write tcpdump.sh: %w", err)
+		dstPath := filepath.Join(tcpdumpDir, "tcpdump_"+inter.Name+".pcap")
+		w, err := pcapWriter(dstPath, cfg.Gzip)
+		if err != nil {
+			cancel()
+			return fmt.Errorf("failed to create pcap file for %s: %w", inter.Name, err)
 		}
-	}
 
-	// Copy script to container
-	output, err = c.Runner.Run("sudo", "docker", "cp", scriptPath, containerName+":/")
-	if err != nil {
-		return fmt.Errorf("failed to copy tcpdump.sh to container: %w, output: %s", err, string(output))
+		done := make(chan error, 1)
+		capture.done = append(capture.done, done)
+
+		go func(iface string, w io.WriteCloser) {
+			streamErr := c.capture().Start(captureCtx, containerName, iface, cfg, w)
+			closeErr := w.Close()
+			if streamErr == nil {
+				streamErr = closeErr
+			}
+			done <- streamErr
+		}(inter.Name, w)
 	}
-	logrus.Debugf("Copied tcpdump.sh to %s", containerName)
 
-	// Run the script (use absolute path since working directory may vary by container image)
-	output, err = c.Runner.Run("sudo", "docker", "exec", "-d", containerName, "/tcpdump.sh")
-	if err != nil {
-		return fmt.Errorf("failed to start tcpdump: %w, output: %s", err, string(output))
+	c.capturesMu.Lock()
+	if c.captures == nil {
+		c.captures = make(map[string]*pcapCapture)
 	}
-	logrus.Debugf("Started tcpdump on %s", containerName)
+	c.captures[node] = capture
+	c.capturesMu.Unlock()
 
+	logrus.Debugf("Started pcap capture on %s", containerName)
 	return nil
 }
 
-// CollectTcpdumpLogs copies tcpdump logs from containers to host.
-func (c *NetworkController) CollectTcpdumpLogs() error {
+// CollectTcpdumpLogs stops the pcap captures SetupTcpdump started for every
+// host in c.Scenario.Hosts, waiting for each interface's stream to flush its
+// .pcap file to disk. Canceling ctx stops waiting on streams that haven't
+// flushed yet; already-flushed hosts are unaffected.
+func (c *NetworkController) CollectTcpdumpLogs(ctx context.Context) error {
+	var firstErr error
 	for _, node := range c.Scenario.Hosts {
-		containerName := c.ClabHostName(node)
-		dstPath := filepath.Join(c.findTopoPath(), node) + "/"
+		if err := c.StopPcapCapture(ctx, node); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
 
-		output, err := c.Runner.Run("sudo", "docker", "cp", containerName+":/tcpdump", dstPath)
-		if err != nil {
-			return fmt.Errorf("failed to copy tcpdump directory from container %s to %s: %w, output: %s",
-				containerName, dstPath, err, strings.TrimSpace(string(output)))
+// StopPcapCapture stops the pcap capture SetupTcpdump started for node, if
+// any, and waits for its per-interface streams to finish. If ctx is
+// canceled before every stream has flushed, StopPcapCapture returns ctx.Err()
+// without waiting on the rest.
+func (c *NetworkController) StopPcapCapture(ctx context.Context, node string) error {
+	c.capturesMu.Lock()
+	capture, ok := c.captures[node]
+	if ok {
+		delete(c.captures, node)
+	}
+	c.capturesMu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	capture.cancel()
+
+	var firstErr error
+	for _, done := range capture.done {
+		select {
+		case err := <-done:
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
 		}
 	}
-	return nil
+	return firstErr
 }
 
 // MoveLogFiles moves collected log files to the scenario log directory.
@@ -302,7 +358,10 @@ func (c *NetworkController) moveControlLog(trialLogPath string) error {
 	return os.Remove(srcPath)
 }
 
-// moveTcpdumpLogs moves tcpdump logs for a device to the trial directory.
+// moveTcpdumpLogs moves a device's captured pcap files to the trial
+// directory. SetupTcpdump/CollectTcpdumpLogs stream captures straight into
+// the scenario's tcpdump directory as they run, so this is a plain copy, not
+// a wait for any in-progress capture.
 func (c *NetworkController) moveTcpdumpLogs(trialLogPath, device string) error {
 	tcpdumpDir := filepath.Join(trialLogPath, device, "tcpdump")
 	if err := os.MkdirAll(tcpdumpDir, 0777); err != nil {
@@ -314,12 +373,18 @@ func (c *NetworkController) moveTcpdumpLogs(trialLogPath, device string) error {
 		return fmt.Errorf("device %s not found", device)
 	}
 
+	ext := ".pcap"
+	if c.Scenario.Capture.Gzip {
+		ext = ".pcap.gz"
+	}
+
 	for _, inter := range c.Devices.Nodes[deviceIndex].Interfaces {
-		srcPath := filepath.Join(c.findTopoPath(), device, "tcpdump", "tcpdump_"+inter.Name+".log")
-		dstPath := filepath.Join(tcpdumpDir, "tcpdump_"+inter.Name+".log")
+		name := "tcpdump_" + inter.Name + ext
+		srcPath := filepath.Join(c.findTopoPath(), device, "tcpdump", name)
+		dstPath := filepath.Join(tcpdumpDir, name)
 
 		if err := copyFile(srcPath, dstPath); err != nil {
-			return fmt.Errorf("failed to copy tcpdump log: %w", err)
+			return fmt.Errorf("failed to copy pcap file: %w", err)
 		}
 	}
 
@@ -1,12 +1,17 @@
 package network
 
 import (
+	"context"
 	"errors"
+	"io"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/runtime"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // mockRunner records command calls for testing
@@ -16,7 +21,7 @@ type mockRunner struct {
 	output []byte     // output to return
 }
 
-func (m *mockRunner) Run(name string, args ...string) ([]byte, error) {
+func (m *mockRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
 	call := append([]string{name}, args...)
 	m.calls = append(m.calls, call)
 	return m.output, m.err
@@ -46,6 +51,47 @@ func (m *mockRunner) callContains(substrings ...string) bool {
 	return false
 }
 
+// mockCaptureRuntime records container-level calls for testing, standing
+// in for runtime.ContainerRuntime the way mockRunner stands in for
+// runtime.CommandRunner.
+type mockCaptureRuntime struct {
+	execCalls   [][]string // recorded Exec commands, one per call
+	copiedTo    []string   // "container:dstPath" for each CopyTo call
+	streamCalls [][]string // recorded StreamExecOutput commands, one per call
+	err         error      // error every call returns
+}
+
+func (m *mockCaptureRuntime) ContainerName(labName, host string) string {
+	return "clab-" + labName + "-" + host
+}
+
+func (m *mockCaptureRuntime) Exec(ctx context.Context, container string, cmd []string, opts runtime.ExecOptions) (string, string, int, error) {
+	m.execCalls = append(m.execCalls, append([]string{container}, cmd...))
+	return "", "", 0, m.err
+}
+
+func (m *mockCaptureRuntime) CopyTo(ctx context.Context, container, srcPath, dstPath string, opts runtime.CopyOptions) error {
+	m.copiedTo = append(m.copiedTo, container+":"+dstPath)
+	return m.err
+}
+
+func (m *mockCaptureRuntime) CopyFrom(ctx context.Context, container, srcPath, dstPath string) error {
+	return m.err
+}
+
+func (m *mockCaptureRuntime) StreamExecOutput(ctx context.Context, container string, cmd []string, opts runtime.ExecOptions, w io.Writer) error {
+	m.streamCalls = append(m.streamCalls, append([]string{container}, cmd...))
+	if m.err != nil {
+		return m.err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (m *mockCaptureRuntime) Inspect(ctx context.Context, container string) (runtime.ContainerInfo, error) {
+	return runtime.ContainerInfo{}, m.err
+}
+
 // --- NetworkController Tests ---
 
 func TestNetworkController_Deploy(t *testing.T) {
@@ -55,8 +101,8 @@ func TestNetworkController_Deploy(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	controller := NewNetworkController(scenario, devices, "test-lab", mock)
-	err := controller.Deploy()
+	controller := NewNetworkController(scenario, devices, "test-lab", mock, nil)
+	err := controller.Deploy(context.Background())
 
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(mock.calls))
@@ -75,8 +121,8 @@ func TestNetworkController_Deploy_Error(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	controller := NewNetworkController(scenario, devices, "test-lab", mock)
-	err := controller.Deploy()
+	controller := NewNetworkController(scenario, devices, "test-lab", mock, nil)
+	err := controller.Deploy(context.Background())
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "containerlab deploy failed")
@@ -89,8 +135,8 @@ func TestNetworkController_Destroy(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	controller := NewNetworkController(scenario, devices, "test-lab", mock)
-	err := controller.Destroy()
+	controller := NewNetworkController(scenario, devices, "test-lab", mock, nil)
+	err := controller.Destroy(context.Background())
 
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(mock.calls))
@@ -111,8 +157,8 @@ func TestNetworkController_Destroy_Error(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	controller := NewNetworkController(scenario, devices, "test-lab", mock)
-	err := controller.Destroy()
+	controller := NewNetworkController(scenario, devices, "test-lab", mock, nil)
+	err := controller.Destroy(context.Background())
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "containerlab destroy failed")
@@ -120,20 +166,73 @@ func TestNetworkController_Destroy_Error(t *testing.T) {
 
 func TestNetworkController_CollectTcpdumpLogs(t *testing.T) {
 	mock := &mockRunner{}
+	dockerMock := &mockCaptureRuntime{}
+	topoPath := filepath.Join(t.TempDir(), "topo.yaml")
 	scenario := &model.Scenario{
-		Topo:  "/path/to/topo.yaml",
+		Topo:  topoPath,
 		Hosts: []string{"r1", "r2"},
 	}
-	devices := &model.Data{}
+	devices := &model.Data{
+		Nodes: []model.Nodes{
+			{Name: "r1", Interfaces: []model.Interfaces{{Name: "eth0"}}},
+			{Name: "r2", Interfaces: []model.Interfaces{{Name: "eth0"}}},
+		},
+	}
 
-	controller := NewNetworkController(scenario, devices, "test-lab", mock)
-	err := controller.CollectTcpdumpLogs()
+	controller := NewNetworkController(scenario, devices, "test-lab", mock, dockerMock)
+	require.NoError(t, controller.SetupTcpdump(context.Background(), "r1"))
+	require.NoError(t, controller.SetupTcpdump(context.Background(), "r2"))
+
+	err := controller.CollectTcpdumpLogs(context.Background())
 
 	assert.NoError(t, err)
-	// docker cp for each host
-	assert.Equal(t, 2, len(mock.calls))
-	assert.True(t, mock.callContains("sudo", "docker", "cp", "clab-test-lab-r1:/tcpdump"))
-	assert.True(t, mock.callContains("sudo", "docker", "cp", "clab-test-lab-r2:/tcpdump"))
+	// one streamed capture per host
+	assert.ElementsMatch(t, []string{"clab-test-lab-r1", "clab-test-lab-r2"},
+		[]string{dockerMock.streamCalls[0][0], dockerMock.streamCalls[1][0]})
+}
+
+func TestNetworkController_SetupTcpdump(t *testing.T) {
+	mock := &mockRunner{}
+	dockerMock := &mockCaptureRuntime{}
+	topoDir := t.TempDir()
+	topoPath := filepath.Join(topoDir, "topo.yaml")
+	scenario := &model.Scenario{
+		Topo: topoPath,
+		Capture: model.CaptureConfig{
+			Snaplen: 128,
+			Filter:  "tcp port 179",
+		},
+	}
+	devices := &model.Data{
+		Nodes: []model.Nodes{
+			{Name: "r1", Interfaces: []model.Interfaces{{Name: "eth0"}, {Name: "eth1"}}},
+		},
+	}
+
+	controller := NewNetworkController(scenario, devices, "test-lab", mock, dockerMock)
+	err := controller.SetupTcpdump(context.Background(), "r1")
+	require.NoError(t, err)
+
+	require.NoError(t, controller.StopPcapCapture(context.Background(), "r1"))
+
+	require.Len(t, dockerMock.streamCalls, 2)
+	assert.Equal(t, []string{"clab-test-lab-r1", "tcpdump", "-i", "eth0", "-U", "-w", "-", "-s", "128", "tcp port 179"}, dockerMock.streamCalls[0])
+	assert.Equal(t, []string{"clab-test-lab-r1", "tcpdump", "-i", "eth1", "-U", "-w", "-", "-s", "128", "tcp port 179"}, dockerMock.streamCalls[1])
+
+	assert.FileExists(t, filepath.Join(topoDir, "r1", "tcpdump", "tcpdump_eth0.pcap"))
+	assert.FileExists(t, filepath.Join(topoDir, "r1", "tcpdump", "tcpdump_eth1.pcap"))
+}
+
+func TestNetworkController_SetupTcpdump_UnknownDevice(t *testing.T) {
+	mock := &mockRunner{}
+	dockerMock := &mockCaptureRuntime{}
+	scenario := &model.Scenario{Topo: filepath.Join(t.TempDir(), "topo.yaml")}
+	devices := &model.Data{}
+
+	controller := NewNetworkController(scenario, devices, "test-lab", mock, dockerMock)
+	err := controller.SetupTcpdump(context.Background(), "r1")
+
+	assert.Error(t, err)
 }
 
 func TestNetworkController_ClabHostName(t *testing.T) {
@@ -151,8 +250,8 @@ func TestNetworkController_Deploy_CustomLabName(t *testing.T) {
 	devices := &model.Data{}
 
 	// Test with custom lab name (for parallel execution)
-	controller := NewNetworkController(scenario, devices, "A1_delay_pause_001", mock)
-	err := controller.Deploy()
+	controller := NewNetworkController(scenario, devices, "A1_delay_pause_001", mock, nil)
+	err := controller.Deploy(context.Background())
 
 	assert.NoError(t, err)
 	assert.True(t, mock.callContains("--name", "A1_delay_pause_001"))
@@ -162,6 +261,7 @@ func TestNetworkController_Deploy_CustomLabName(t *testing.T) {
 
 func TestScenarioFlow_DeployExecuteDestroy(t *testing.T) {
 	mock := &mockRunner{}
+	dockerMock := &mockCaptureRuntime{}
 	scenario := &model.Scenario{
 		ScenarioName: "test-scenario",
 		Topo:         "/path/to/topo.yaml",
@@ -169,27 +269,24 @@ func TestScenarioFlow_DeployExecuteDestroy(t *testing.T) {
 	}
 	devices := &model.Data{}
 
-	controller := NewNetworkController(scenario, devices, "test-lab", mock)
+	controller := NewNetworkController(scenario, devices, "test-lab", mock, dockerMock)
 
 	// Simulate the flow: Deploy -> CollectLogs -> Destroy
-	err := controller.Deploy()
+	err := controller.Deploy(context.Background())
 	assert.NoError(t, err)
 
-	err = controller.CollectTcpdumpLogs()
+	err = controller.CollectTcpdumpLogs(context.Background())
 	assert.NoError(t, err)
 
-	err = controller.Destroy()
+	err = controller.Destroy(context.Background())
 	assert.NoError(t, err)
 
-	// Verify call order
-	assert.Equal(t, 3, len(mock.calls))
-
-	// First call: containerlab deploy
+	// Deploy/Destroy go through the containerlab CLI; tcpdump collection
+	// goes through the Docker client instead.
+	assert.Equal(t, 2, len(mock.calls))
 	assert.True(t, strings.Contains(strings.Join(mock.calls[0], " "), "containerlab deploy"))
-
-	// Second call: docker cp (collect tcpdump)
-	assert.True(t, strings.Contains(strings.Join(mock.calls[1], " "), "docker cp"))
-
-	// Third call: containerlab destroy
-	assert.True(t, strings.Contains(strings.Join(mock.calls[2], " "), "containerlab destroy"))
+	assert.True(t, strings.Contains(strings.Join(mock.calls[1], " "), "containerlab destroy"))
+	// No capture was started (SetupTcpdump was never called), so there's
+	// nothing for CollectTcpdumpLogs to stop.
+	assert.Empty(t, dockerMock.streamCalls)
 }
@@ -1,36 +1,47 @@
 package network
 
 import (
+	"context"
 	"crypto/tls"
-	"crypto/x509"
-
 	"fmt"
-	"log"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/runtime"
 	"github.com/alexei-led/pumba/pkg/chaos"
 	"github.com/alexei-led/pumba/pkg/container"
 
 	"github.com/pkg/errors"
-	"github.com/urfave/cli"
 )
 
+// DockerOptions carries the Docker connection flags (--docker-host/--tls*)
+// that CreateDockerClient and NewDockerEndpoint need, decoupled from
+// whichever CLI framework parses them so this package doesn't depend on it.
+type DockerOptions struct {
+	Host      string
+	TLSCACert string
+	TLSCert   string
+	TLSKey    string
+	TLSVerify bool
+}
+
+// EmulateNetwork deploys the scenario's topology through the platform's
+// runtime.ContainerBackend (sudo+containerlab on Linux) instead of shelling
+// out to "sudo containerlab" directly, so this at least fails with a clear
+// runtime.ErrUnsupportedPlatform instead of a missing-binary error on
+// platforms containerlab doesn't support.
 func EmulateNetwork() error {
+	backend, err := runtime.NewContainerBackend(runtime.NewExecRunner())
+	if err != nil {
+		return err
+	}
 
-	cmd := exec.Command("sudo", "containerlab", "deploy", "--topo", model.Scenar.Topo)
-	out, err := cmd.Output()
+	out, err := backend.Deploy(model.Scenar.Topo, "")
 	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
-			errMsg := string(exitError.Stderr)
-			log.Fatal(errMsg)
-		} else {
-			log.Fatal(err.Error())
-		}
+		return fmt.Errorf("containerlab deploy failed: %w, output: %s", err, strings.TrimSpace(out))
 	}
-	fmt.Println(string(out))
+	fmt.Println(out)
 	return nil
 }
 
@@ -53,82 +64,74 @@ func DestroyNetwork() error {
 		return err
 	}
 
-	cmd := exec.Command("sudo", "rm", "-rf", path+"/"+topoName)
-	out, err := cmd.Output()
+	elevator, err := runtime.NewPrivilegeElevator()
 	if err != nil {
-		fmt.Println("Error while suppressing file")
 		return err
 	}
-	fmt.Println(string(out))
+	runner := runtime.NewExecRunner()
 
-	cmd = exec.Command("sudo", "containerlab", "destroy", "--topo", model.Scenar.Topo)
-	out, err = cmd.Output()
+	name, args := elevator.Elevate("rm", "-rf", path+"/"+topoName)
+	out, err := runner.Run(context.Background(), name, args...)
 	if err != nil {
-		fmt.Println("Errore while destroy the emulated network")
+		fmt.Println("Error while suppressing file")
 		return err
 	}
 	fmt.Println(string(out))
-	return nil
-
-}
 
-func CreateDockerClient(c *cli.Context) error {
-	tlsCfg, err := tlsConfig(c)
+	backend, err := runtime.NewContainerBackend(runner)
 	if err != nil {
 		return err
 	}
-	chaos.DockerClient, err = container.NewClient("unix:///var/run/docker.sock", tlsCfg)
+	destroyOut, err := backend.Destroy(model.Scenar.Topo, "")
 	if err != nil {
-		return errors.Wrap(err, "could not create Docker client")
+		fmt.Println("Errore while destroy the emulated network")
+		return err
 	}
+	fmt.Println(destroyOut)
 	return nil
+
 }
 
-// tlsConfig translates the command-line options into a tls.Config struct
-func tlsConfig(c *cli.Context) (*tls.Config, error) {
-	var tlsCfg *tls.Config
-	var err error
-	caCertFlag := c.GlobalString("tlscacert")
-	certFlag := c.GlobalString("tlscert")
-	keyFlag := c.GlobalString("tlskey")
+// CreateDockerClient builds the pumba chaos client's Docker connection from
+// opts via NewDockerEndpoint, the same DockerEndpoint EventExecutor's
+// container runtime is built from (see runtime.NewContainerRuntimeWithEndpoint),
+// so both subsystems target the same daemon.
+func CreateDockerClient(opts DockerOptions) error {
+	endpoint := NewDockerEndpoint(opts)
 
-	if c.GlobalBool("tls") || c.GlobalBool("tlsverify") {
-		tlsCfg = &tls.Config{
-			InsecureSkipVerify: !c.GlobalBool("tlsverify"), //nolint:gosec
-		}
+	host := endpoint.Host
+	if host == "" {
+		host = "unix:///var/run/docker.sock"
+	}
 
-		// Load CA cert
-		if caCertFlag != "" {
-			var caCert []byte
-			if strings.HasPrefix(caCertFlag, "/") {
-				caCert, err = os.ReadFile(caCertFlag)
-				if err != nil {
-					return nil, errors.Wrap(err, "unable to read CA certificate")
-				}
-			} else {
-				caCert = []byte(caCertFlag)
-			}
-			caCertPool := x509.NewCertPool()
-			caCertPool.AppendCertsFromPEM(caCert)
-			tlsCfg.RootCAs = caCertPool
+	var tlsCfg *tls.Config
+	if endpoint.TLSCACert != "" || endpoint.TLSCert != "" || endpoint.TLSVerify {
+		var err error
+		tlsCfg, err = runtime.BuildTLSConfig(endpoint)
+		if err != nil {
+			return err
 		}
+	}
 
-		// Load client certificate
-		if certFlag != "" && keyFlag != "" {
-			var cert tls.Certificate
-			if strings.HasPrefix(certFlag, "/") && strings.HasPrefix(keyFlag, "/") {
-				cert, err = tls.LoadX509KeyPair(certFlag, keyFlag)
-				if err != nil {
-					return nil, errors.Wrap(err, "unable to load client certificate")
-				}
-			} else {
-				cert, err = tls.X509KeyPair([]byte(certFlag), []byte(keyFlag))
-				if err != nil {
-					return nil, errors.Wrap(err, "unable to load client certificate")
-				}
-			}
-			tlsCfg.Certificates = []tls.Certificate{cert}
-		}
+	var err error
+	chaos.DockerClient, err = container.NewClient(host, tlsCfg)
+	if err != nil {
+		return errors.Wrap(err, "could not create Docker client")
+	}
+	return nil
+}
+
+// NewDockerEndpoint translates opts into a runtime.DockerEndpoint, so
+// CreateDockerClient and runtime.NewContainerRuntimeWithEndpoint connect to
+// the same daemon. The zero DockerOptions (e.g. in tests that build a
+// ScenarioRunner without one) returns the zero DockerEndpoint, i.e. the
+// environment default.
+func NewDockerEndpoint(opts DockerOptions) runtime.DockerEndpoint {
+	return runtime.DockerEndpoint{
+		Host:      opts.Host,
+		TLSCACert: opts.TLSCACert,
+		TLSCert:   opts.TLSCert,
+		TLSKey:    opts.TLSKey,
+		TLSVerify: opts.TLSVerify,
 	}
-	return tlsCfg, nil
 }
@@ -0,0 +1,31 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDockerEndpoint(t *testing.T) {
+	opts := DockerOptions{
+		Host:      "tcp://remote:2376",
+		TLSCACert: "ca-pem",
+		TLSCert:   "cert-pem",
+		TLSKey:    "key-pem",
+		TLSVerify: true,
+	}
+
+	endpoint := NewDockerEndpoint(opts)
+
+	assert.Equal(t, "tcp://remote:2376", endpoint.Host)
+	assert.Equal(t, "ca-pem", endpoint.TLSCACert)
+	assert.Equal(t, "cert-pem", endpoint.TLSCert)
+	assert.Equal(t, "key-pem", endpoint.TLSKey)
+	assert.True(t, endpoint.TLSVerify)
+}
+
+func TestNewDockerEndpoint_Zero(t *testing.T) {
+	endpoint := NewDockerEndpoint(DockerOptions{})
+	assert.Equal(t, "", endpoint.Host)
+	assert.False(t, endpoint.TLSVerify)
+}
@@ -0,0 +1,148 @@
+// Package eventmon watches the Docker events API for containers dying
+// unexpectedly mid-scenario, so a crashed node is reported immediately
+// instead of only showing up once the scenario's final logs are inspected.
+package eventmon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// EventsClient is the subset of the Docker Engine SDK Watch depends on.
+type EventsClient interface {
+	Events(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error)
+}
+
+// LogsClient is the subset of the Docker Engine SDK DumpLogs depends on.
+type LogsClient interface {
+	ContainerLogs(ctx context.Context, container string, options types.ContainerLogsOptions) (io.ReadCloser, error)
+}
+
+// Client is the full set of Docker Engine SDK operations this package
+// depends on: streaming events and fetching a dead container's logs.
+type Client interface {
+	EventsClient
+	LogsClient
+}
+
+// NewClient constructs a Client from the environment (DOCKER_HOST,
+// DOCKER_TLS_VERIFY, DOCKER_CERT_PATH), the same way runtime.NewDockerClient
+// does for exec/copy operations.
+func NewClient() (Client, error) {
+	return client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+}
+
+// Event is a die, oom, or kill event for one of a lab's containers.
+type Event struct {
+	Container string // container name, e.g. "clab-<lab>-r1", leading "/" stripped
+	Status    string // "die", "oom", or "kill"
+	ExitCode  int    // only meaningful for Status == "die"
+}
+
+// Watcher forwards die/oom/kill events for a lab's containers until Stop is
+// called or the context Watch was given is canceled.
+type Watcher struct {
+	Events chan Event
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Watch subscribes to cli's Docker events stream filtered to labName's
+// containers (label containerlab=<labName>) and forwards die/oom/kill
+// events on the returned Watcher's Events channel until Stop is called or
+// ctx is canceled. Pumba's own chaos helper containers aren't deployed by
+// containerlab, so they never carry this label and the filter excludes
+// them without any extra bookkeeping here.
+func Watch(ctx context.Context, cli EventsClient, labName string) *Watcher {
+	watchCtx, cancel := context.WithCancel(ctx)
+
+	f := filters.NewArgs()
+	f.Add("type", "container")
+	f.Add("label", "containerlab="+labName)
+	f.Add("event", "die")
+	f.Add("event", "oom")
+	f.Add("event", "kill")
+
+	msgs, errs := cli.Events(watchCtx, types.EventsOptions{Filters: f})
+
+	w := &Watcher{
+		Events: make(chan Event),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go w.run(watchCtx, msgs, errs)
+	return w
+}
+
+func (w *Watcher) run(ctx context.Context, msgs <-chan events.Message, errs <-chan error) {
+	defer close(w.done)
+	defer close(w.Events)
+
+	for {
+		select {
+		case msg, ok := <-msgs:
+			if !ok {
+				return
+			}
+			exitCode, _ := strconv.Atoi(msg.Actor.Attributes["exitCode"])
+			event := Event{
+				Container: strings.TrimPrefix(msg.Actor.Attributes["name"], "/"),
+				Status:    msg.Status,
+				ExitCode:  exitCode,
+			}
+			select {
+			case w.Events <- event:
+			case <-ctx.Done():
+				return
+			}
+		case <-errs:
+			// The event stream itself broke (daemon restart, connection
+			// drop); there's nothing more to forward.
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop ends the watch and waits for its goroutine to exit, so the caller
+// knows Events is closed and drained before moving on.
+func (w *Watcher) Stop() {
+	w.cancel()
+	<-w.done
+}
+
+// DumpLogs writes container's stdout/stderr history to a "<container>.log"
+// file under logDir, for capturing a crashed node's own output before
+// containerlab removes it.
+func DumpLogs(ctx context.Context, cli LogsClient, container, logDir string) error {
+	rc, err := cli.ContainerLogs(ctx, container, types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true})
+	if err != nil {
+		return fmt.Errorf("failed to fetch logs for %s: %w", container, err)
+	}
+	defer rc.Close()
+
+	f, err := os.Create(filepath.Join(logDir, container+".log"))
+	if err != nil {
+		return fmt.Errorf("failed to create log file for %s: %w", container, err)
+	}
+	defer f.Close()
+
+	if _, err := stdcopy.StdCopy(f, f, rc); err != nil && err != io.EOF {
+		return fmt.Errorf("failed to read logs for %s: %w", container, err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package eventmon
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEventsClient is a minimal in-memory stand-in for EventsClient.
+type fakeEventsClient struct {
+	msgs chan events.Message
+	errs chan error
+}
+
+func newFakeEventsClient() *fakeEventsClient {
+	return &fakeEventsClient{msgs: make(chan events.Message, 8), errs: make(chan error, 1)}
+}
+
+func (f *fakeEventsClient) Events(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error) {
+	return f.msgs, f.errs
+}
+
+func TestWatch_ForwardsDieEvent(t *testing.T) {
+	cli := newFakeEventsClient()
+	w := Watch(context.Background(), cli, "lab1")
+	defer w.Stop()
+
+	cli.msgs <- events.Message{
+		Status: "die",
+		Actor: events.Actor{
+			Attributes: map[string]string{"name": "/clab-lab1-r1", "exitCode": "137"},
+		},
+	}
+
+	select {
+	case ev := <-w.Events:
+		assert.Equal(t, "clab-lab1-r1", ev.Container)
+		assert.Equal(t, "die", ev.Status)
+		assert.Equal(t, 137, ev.ExitCode)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
+func TestWatch_StopClosesEventsAndGoroutine(t *testing.T) {
+	cli := newFakeEventsClient()
+	w := Watch(context.Background(), cli, "lab1")
+
+	w.Stop()
+
+	_, ok := <-w.Events
+	assert.False(t, ok, "Events should be closed after Stop")
+}
+
+func TestWatch_ContextCancelEndsWatch(t *testing.T) {
+	cli := newFakeEventsClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	w := Watch(ctx, cli, "lab1")
+
+	cancel()
+
+	select {
+	case _, ok := <-w.Events:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Events to close")
+	}
+}
+
+// fakeLogsClient is a minimal in-memory stand-in for LogsClient.
+type fakeLogsClient struct {
+	frame []byte
+	err   error
+}
+
+func (f *fakeLogsClient) ContainerLogs(ctx context.Context, container string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return io.NopCloser(strings.NewReader(string(f.frame))), nil
+}
+
+// stdoutFrame builds a single stdcopy-framed stdout chunk, matching what
+// ContainerLogs returns for a container started without a TTY.
+func stdoutFrame(payload string) []byte {
+	header := make([]byte, 8)
+	header[0] = 1
+	header[4], header[5], header[6], header[7] = byte(len(payload)>>24), byte(len(payload)>>16), byte(len(payload)>>8), byte(len(payload))
+	return append(header, []byte(payload)...)
+}
+
+func TestDumpLogs_WritesContainerLogFile(t *testing.T) {
+	dir := t.TempDir()
+	cli := &fakeLogsClient{frame: stdoutFrame("boom\n")}
+
+	err := DumpLogs(context.Background(), cli, "clab-lab1-r1", dir)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "clab-lab1-r1.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "boom\n", string(content))
+}
+
+func TestDumpLogs_PropagatesFetchError(t *testing.T) {
+	cli := &fakeLogsClient{err: errors.New("no such container")}
+
+	err := DumpLogs(context.Background(), cli, "clab-lab1-r1", t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestWatch_StreamErrorEndsWatch(t *testing.T) {
+	cli := newFakeEventsClient()
+	w := Watch(context.Background(), cli, "lab1")
+
+	cli.errs <- errors.New("connection lost")
+
+	select {
+	case _, ok := <-w.Events:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Events to close")
+	}
+}
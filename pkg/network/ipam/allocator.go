@@ -0,0 +1,291 @@
+// Package ipam persists per-lab IPv4/IPv6 subnet assignments across
+// scenario runs, so long-lived netroub daemons deploying many labs over
+// time don't leak address space or collide with subnets already in use.
+package ipam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Config configures a new Allocator.
+type Config struct {
+	// StateDir is where allocations.json and its lock file are kept, so
+	// every netroub process pointed at the same dir shares one view of
+	// what's allocated.
+	StateDir string
+
+	// IPv4Pools are the CIDR ranges Allocate draws subnets from, in order.
+	// Empty defaults to 172.16.0.0/12, the range generateSubnet has always
+	// used.
+	IPv4Pools []string
+
+	// IPv6Pools are the CIDR ranges Allocate draws /64s from, in order.
+	// Empty defaults to 3fff:172:20::/48, the range generateIPv6Subnet has
+	// always used.
+	IPv6Pools []string
+}
+
+// Allocator persists IPv4/IPv6 subnet assignments to a JSON file under
+// StateDir, serialized across processes via a file lock, and probes the
+// host's existing Docker networks before handing one out so it doesn't
+// collide with them. It implements network.SubnetAllocator.
+type Allocator struct {
+	stateDir  string
+	ipv4Pools []*net.IPNet
+	ipv6Pools []*net.IPNet
+	docker    networkLister
+}
+
+// New builds an Allocator from cfg. It tries to reach a Docker daemon to
+// probe existing networks but doesn't fail if one isn't available (e.g. a
+// Podman-only host); Allocate just won't have anything to cross-check
+// against in that case.
+func New(cfg Config) (*Allocator, error) {
+	if cfg.StateDir == "" {
+		return nil, fmt.Errorf("ipam: StateDir is required")
+	}
+
+	ipv4Pools := cfg.IPv4Pools
+	if len(ipv4Pools) == 0 {
+		ipv4Pools = []string{"172.16.0.0/12"}
+	}
+	ipv6Pools := cfg.IPv6Pools
+	if len(ipv6Pools) == 0 {
+		ipv6Pools = []string{"3fff:172:20::/48"}
+	}
+
+	v4, err := parsePools(ipv4Pools)
+	if err != nil {
+		return nil, err
+	}
+	v6, err := parsePools(ipv6Pools)
+	if err != nil {
+		return nil, err
+	}
+
+	docker, err := newNetworkLister()
+	if err != nil {
+		logrus.Debugf("ipam: Docker unavailable, allocating without probing existing networks: %v", err)
+		docker = nil
+	}
+
+	return &Allocator{stateDir: cfg.StateDir, ipv4Pools: v4, ipv6Pools: v6, docker: docker}, nil
+}
+
+func parsePools(cidrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subnet pool %q: %w", cidr, err)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// state is Allocator's on-disk record of every lab's current subnets.
+type state struct {
+	Allocations map[string]allocation `json:"allocations"`
+}
+
+// allocation is one lab's assigned subnets.
+type allocation struct {
+	IPv4 string `json:"ipv4"`
+	IPv6 string `json:"ipv6"`
+}
+
+// Allocate returns labName's IPv4 and IPv6 subnets, sized to fit
+// deviceCount devices. A lab that already has an allocation (e.g. a
+// Scenario.Reuse run calling Deploy again) gets the same subnets back
+// instead of a fresh pair. The result is persisted before Allocate
+// returns, so a concurrent Allocate from another netroub process never
+// picks the same block.
+func (a *Allocator) Allocate(ctx context.Context, labName string, deviceCount int) (ipv4Subnet, ipv6Subnet string, err error) {
+	lock, err := lockFile(a.lockFilePath())
+	if err != nil {
+		return "", "", err
+	}
+	defer lock.Unlock()
+
+	s, err := a.loadState()
+	if err != nil {
+		return "", "", err
+	}
+
+	if existing, ok := s.Allocations[labName]; ok {
+		return existing.IPv4, existing.IPv6, nil
+	}
+
+	used := a.usedSubnets(ctx)
+	for _, alloc := range s.Allocations {
+		used = append(used, alloc.IPv4, alloc.IPv6)
+	}
+
+	ipv4Subnet, err = allocateBlock(a.ipv4Pools, subnetPrefix(deviceCount), used)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to allocate IPv4 subnet for %s: %w", labName, err)
+	}
+
+	ipv6Subnet, err = allocateBlock(a.ipv6Pools, 64, used)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to allocate IPv6 subnet for %s: %w", labName, err)
+	}
+
+	s.Allocations[labName] = allocation{IPv4: ipv4Subnet, IPv6: ipv6Subnet}
+	if err := a.saveState(s); err != nil {
+		return "", "", err
+	}
+
+	return ipv4Subnet, ipv6Subnet, nil
+}
+
+// Release frees labName's allocation, if any, so a later Allocate for a
+// different lab can reuse its subnets. Releasing a lab with no allocation
+// is not an error.
+func (a *Allocator) Release(ctx context.Context, labName string) error {
+	lock, err := lockFile(a.lockFilePath())
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	s, err := a.loadState()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := s.Allocations[labName]; !ok {
+		return nil
+	}
+	delete(s.Allocations, labName)
+
+	return a.saveState(s)
+}
+
+// usedSubnets returns the CIDRs Allocate should avoid beyond its own
+// recorded allocations: the host's existing Docker networks, if a.docker
+// could be reached. A probe failure is logged and treated as "nothing
+// else to avoid" rather than failing the allocation.
+func (a *Allocator) usedSubnets(ctx context.Context) []string {
+	if a.docker == nil {
+		return nil
+	}
+	subnets, err := probeUsedSubnets(ctx, a.docker)
+	if err != nil {
+		logrus.Debugf("ipam: failed to probe Docker networks, continuing without them: %v", err)
+		return nil
+	}
+	return subnets
+}
+
+func (a *Allocator) stateFilePath() string { return filepath.Join(a.stateDir, "allocations.json") }
+func (a *Allocator) lockFilePath() string  { return filepath.Join(a.stateDir, "allocations.lock") }
+
+func (a *Allocator) loadState() (*state, error) {
+	data, err := os.ReadFile(a.stateFilePath())
+	if os.IsNotExist(err) {
+		return &state{Allocations: map[string]allocation{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", a.stateFilePath(), err)
+	}
+
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", a.stateFilePath(), err)
+	}
+	if s.Allocations == nil {
+		s.Allocations = map[string]allocation{}
+	}
+	return &s, nil
+}
+
+func (a *Allocator) saveState(s *state) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal allocator state: %w", err)
+	}
+	if err := os.MkdirAll(a.stateDir, 0755); err != nil {
+		return fmt.Errorf("failed to create state dir %s: %w", a.stateDir, err)
+	}
+	if err := os.WriteFile(a.stateFilePath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", a.stateFilePath(), err)
+	}
+	return nil
+}
+
+// subnetPrefix returns the smallest CIDR prefix whose subnet fits
+// deviceCount devices plus one address for the Docker/containerlab
+// gateway. It mirrors network's unexported calculateSubnetSize; ipam is a
+// separate package and the calculation is only a handful of lines, so it's
+// duplicated here rather than exported across packages for it.
+func subnetPrefix(deviceCount int) int {
+	required := deviceCount + 1
+	for p := 30; p >= 8; p-- {
+		if (1<<(32-p))-2 >= required {
+			return p
+		}
+	}
+	return 8
+}
+
+// allocateBlock returns the first /prefix block, across pools in order,
+// that doesn't overlap any CIDR in used.
+func allocateBlock(pools []*net.IPNet, prefix int, used []string) (string, error) {
+	usedNets := make([]*net.IPNet, 0, len(used))
+	for _, cidr := range used {
+		if cidr == "" {
+			continue
+		}
+		if _, n, err := net.ParseCIDR(cidr); err == nil {
+			usedNets = append(usedNets, n)
+		}
+	}
+
+	for _, pool := range pools {
+		ones, bits := pool.Mask.Size()
+		if prefix < ones || prefix > bits {
+			continue
+		}
+
+		base := new(big.Int).SetBytes(pool.IP)
+		poolSize := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+		blockSize := new(big.Int).Lsh(big.NewInt(1), uint(bits-prefix))
+
+		for offset := big.NewInt(0); offset.Cmp(poolSize) < 0; offset.Add(offset, blockSize) {
+			addr := new(big.Int).Add(base, offset)
+			candidate := &net.IPNet{IP: bigIntToIP(addr, len(pool.IP)), Mask: net.CIDRMask(prefix, bits)}
+
+			if !overlapsAny(candidate, usedNets) {
+				return candidate.String(), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no free /%d subnet available in configured pools", prefix)
+}
+
+func bigIntToIP(i *big.Int, size int) net.IP {
+	b := i.Bytes()
+	ip := make(net.IP, size)
+	copy(ip[size-len(b):], b)
+	return ip
+}
+
+func overlapsAny(candidate *net.IPNet, nets []*net.IPNet) bool {
+	for _, n := range nets {
+		if n.Contains(candidate.IP) || candidate.Contains(n.IP) {
+			return true
+		}
+	}
+	return false
+}
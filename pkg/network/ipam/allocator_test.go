@@ -0,0 +1,123 @@
+package ipam
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	dockernetwork "github.com/docker/docker/api/types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNetworkLister is a minimal in-memory stand-in for networkLister.
+type fakeNetworkLister struct {
+	subnets []string // IPAM subnets to report, one per fake network
+	err     error
+}
+
+func (f *fakeNetworkLister) NetworkList(ctx context.Context, options types.NetworkListOptions) ([]types.NetworkResource, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var networks []types.NetworkResource
+	for _, subnet := range f.subnets {
+		networks = append(networks, types.NetworkResource{
+			IPAM: dockernetwork.IPAM{Config: []dockernetwork.IPAMConfig{{Subnet: subnet}}},
+		})
+	}
+	return networks, nil
+}
+
+func newTestAllocator(t *testing.T, docker networkLister) *Allocator {
+	t.Helper()
+	_, v4, err := net.ParseCIDR("172.16.0.0/12")
+	require.NoError(t, err)
+	_, v6, err := net.ParseCIDR("3fff:172:20::/48")
+	require.NoError(t, err)
+
+	return &Allocator{
+		stateDir:  t.TempDir(),
+		ipv4Pools: []*net.IPNet{v4},
+		ipv6Pools: []*net.IPNet{v6},
+		docker:    docker,
+	}
+}
+
+func TestAllocator_Allocate_ReturnsNonOverlappingSubnets(t *testing.T) {
+	a := newTestAllocator(t, nil)
+
+	ipv4A, ipv6A, err := a.Allocate(context.Background(), "lab-a", 16)
+	require.NoError(t, err)
+	ipv4B, ipv6B, err := a.Allocate(context.Background(), "lab-b", 16)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, ipv4A, ipv4B)
+	assert.NotEqual(t, ipv6A, ipv6B)
+}
+
+func TestAllocator_Allocate_IsIdempotentPerLab(t *testing.T) {
+	a := newTestAllocator(t, nil)
+
+	ipv4First, ipv6First, err := a.Allocate(context.Background(), "lab-a", 16)
+	require.NoError(t, err)
+	ipv4Second, ipv6Second, err := a.Allocate(context.Background(), "lab-a", 16)
+	require.NoError(t, err)
+
+	assert.Equal(t, ipv4First, ipv4Second)
+	assert.Equal(t, ipv6First, ipv6Second)
+}
+
+func TestAllocator_Allocate_AvoidsExistingDockerSubnets(t *testing.T) {
+	docker := &fakeNetworkLister{subnets: []string{"172.16.0.0/27"}}
+	a := newTestAllocator(t, docker)
+
+	ipv4, _, err := a.Allocate(context.Background(), "lab-a", 16)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, "172.16.0.0/27", ipv4)
+}
+
+func TestAllocator_Release_FreesSubnetForReuse(t *testing.T) {
+	a := newTestAllocator(t, nil)
+
+	ipv4First, _, err := a.Allocate(context.Background(), "lab-a", 16)
+	require.NoError(t, err)
+	require.NoError(t, a.Release(context.Background(), "lab-a"))
+
+	// lab-a no longer has a recorded allocation, so the same block is free
+	// again for a new lab.
+	ipv4Second, _, err := a.Allocate(context.Background(), "lab-b", 16)
+	require.NoError(t, err)
+	assert.Equal(t, ipv4First, ipv4Second)
+}
+
+func TestAllocator_Release_OfUnknownLabIsNotAnError(t *testing.T) {
+	a := newTestAllocator(t, nil)
+
+	assert.NoError(t, a.Release(context.Background(), "never-allocated"))
+}
+
+func TestAllocator_Allocate_PersistsAcrossInstances(t *testing.T) {
+	stateDir := t.TempDir()
+	_, v4, err := net.ParseCIDR("172.16.0.0/12")
+	require.NoError(t, err)
+	_, v6, err := net.ParseCIDR("3fff:172:20::/48")
+	require.NoError(t, err)
+
+	first := &Allocator{stateDir: stateDir, ipv4Pools: []*net.IPNet{v4}, ipv6Pools: []*net.IPNet{v6}}
+	ipv4First, _, err := first.Allocate(context.Background(), "lab-a", 16)
+	require.NoError(t, err)
+
+	second := &Allocator{stateDir: stateDir, ipv4Pools: []*net.IPNet{v4}, ipv6Pools: []*net.IPNet{v6}}
+	ipv4Second, _, err := second.Allocate(context.Background(), "lab-a", 16)
+	require.NoError(t, err)
+
+	assert.Equal(t, ipv4First, ipv4Second)
+}
+
+func TestSubnetPrefix(t *testing.T) {
+	prefix := subnetPrefix(16)
+	assert.Equal(t, 27, prefix)
+}
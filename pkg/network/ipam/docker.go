@@ -0,0 +1,50 @@
+package ipam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+)
+
+// networkLister is the subset of the Docker Engine SDK Allocator depends on
+// to probe existing networks before handing out a subnet, mirroring the
+// narrow-interface-per-dependency pattern used throughout this repo (e.g.
+// network.dockerNetworkClient, runtime.dockerExecClient), so tests can
+// supply a fake without a real daemon.
+type networkLister interface {
+	NetworkList(ctx context.Context, options types.NetworkListOptions) ([]types.NetworkResource, error)
+}
+
+// newNetworkLister constructs a Docker client from the environment
+// (DOCKER_HOST, DOCKER_TLS_VERIFY, DOCKER_CERT_PATH). It is a var so tests
+// and New can substitute a fake networkLister.
+var newNetworkLister = func() (networkLister, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	return cli, nil
+}
+
+// probeUsedSubnets returns the IPAM subnets of every Docker network
+// currently on the host, so Allocate can skip over them instead of handing
+// out one that collides with a network (containerlab's own "clab-*" ones
+// included) something else on the host is already using.
+func probeUsedSubnets(ctx context.Context, docker networkLister) ([]string, error) {
+	networks, err := docker.NetworkList(ctx, types.NetworkListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Docker networks: %w", err)
+	}
+
+	var subnets []string
+	for _, n := range networks {
+		for _, cfg := range n.IPAM.Config {
+			if cfg.Subnet != "" {
+				subnets = append(subnets, cfg.Subnet)
+			}
+		}
+	}
+	return subnets, nil
+}
@@ -0,0 +1,41 @@
+//go:build linux
+
+package ipam
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// fileLock is an exclusive, advisory, cross-process lock backed by
+// flock(2) on a dedicated lock file, so every netroub process sharing the
+// same StateDir serializes its Allocate/Release calls, the same way
+// network.networkOpMu serializes CLIBackend's deploy/destroy within one
+// process.
+type fileLock struct {
+	f *os.File
+}
+
+// lockFile opens (creating if needed) path and blocks until it holds an
+// exclusive flock on it.
+func lockFile(path string) (*fileLock, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file %s: %w", path, err)
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	return &fileLock{f: f}, nil
+}
+
+// Unlock releases the flock and closes the underlying file.
+func (l *fileLock) Unlock() error {
+	if err := syscall.Flock(int(l.f.Fd()), syscall.LOCK_UN); err != nil {
+		l.f.Close()
+		return err
+	}
+	return l.f.Close()
+}
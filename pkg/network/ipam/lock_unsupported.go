@@ -0,0 +1,16 @@
+//go:build !linux
+
+package ipam
+
+import "fmt"
+
+// fileLock is the non-Linux stand-in: netroub's cross-process locking only
+// targets Linux so far, the same scope pkg/runtime/platform_unsupported.go's
+// NewContainerBackend/NewPrivilegeElevator already have.
+type fileLock struct{}
+
+func lockFile(path string) (*fileLock, error) {
+	return nil, fmt.Errorf("ipam: cross-process file locking is not supported on this platform")
+}
+
+func (l *fileLock) Unlock() error { return nil }
@@ -1,19 +1,25 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"io"
-	"log"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/3atlab/netroub/pkg/logstore"
 	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/runtime"
 )
 
+// tcpdumpLogTimeout bounds each Docker exec/copy call TcpdumpLog and
+// GetTcpdumpLogs make, so a stuck daemon or container can't hang log
+// collection indefinitely.
+const tcpdumpLogTimeout = 30 * time.Second
+
 func SearchFiles(initalSizes map[string]int64, root string) ([]string, error) {
 	var files []string
 
@@ -39,85 +45,76 @@ func SearchFiles(initalSizes map[string]int64, root string) ([]string, error) {
 	return files, nil
 }
 
-func MoveLogFiles(logFiles []string) error {
-	//Retrieve the time for the name
-	t := time.Now()
-	//Generate a name for the directory
-	dirName := strconv.Itoa(int(t.Month())) + "_" + strconv.Itoa(t.Day()) + "_" + strconv.Itoa(t.Hour()) + ":" + strconv.Itoa(t.Minute()) + ":" + strconv.Itoa(t.Second()) + "_" + model.Scenar.ScenarioName
+// MoveLogFiles moves logFiles into a fresh run directory under the
+// scenario's log path, via logstore, and returns that directory's path so
+// callers (e.g. WriteRunManifest) can target it without recomputing it.
+func MoveLogFiles(logFiles []string) (string, error) {
+	store, err := logstore.NewStore(logstore.NewLocalBackend(), model.Scenar.LogPath, model.Scenar.ScenarioName)
+	if err != nil {
+		fmt.Println("Error while creating run directory")
+		return "", err
+	}
 
-	if _, err := os.Stat(model.Scenar.LogPath + "/" + model.Scenar.ScenarioName); os.IsNotExist(err) {
-		err = os.Mkdir(model.Scenar.LogPath+"/"+model.Scenar.ScenarioName, os.ModePerm)
-		if err != nil {
-			fmt.Println("Error while creating new directory")
-			return err
+	//Fill the run directory with the different logs generated
+	for i, path := range logFiles {
+		if err := copyIntoStore(store, "r"+strconv.Itoa(i+1), path); err != nil {
+			return "", err
 		}
 	}
 
-	//Create the directory for the scenario
-	err := os.Mkdir(model.Scenar.LogPath+"/"+model.Scenar.ScenarioName+"/"+dirName, os.ModePerm)
-	if err != nil {
-		fmt.Println("Error while creating log directory")
-		return err
+	if err := MoveControlLogs(store); err != nil {
+		return "", err
 	}
 
-	//Fill the directory with the different logs generated
-	for path := range logFiles {
-		err := os.Mkdir(model.Scenar.LogPath+"/"+model.Scenar.ScenarioName+"/"+dirName+"/r"+strconv.Itoa(path+1), os.ModePerm)
-		if err != nil {
-			fmt.Println("Error while creating device directory")
-			return err
-		}
-		src, err := os.Open(logFiles[path])
-		if err != nil {
-			fmt.Println("Error while opening log file")
-			return err
-		}
-		defer src.Close()
-		destFile := filepath.Join(model.Scenar.LogPath+"/"+model.Scenar.ScenarioName+"/"+dirName+"/r"+strconv.Itoa(path+1), filepath.Base(logFiles[path]))
-		dst, err := os.Create(destFile)
-		if err != nil {
-			fmt.Println("Error while creating new file")
-			return err
-		}
-
-		_, err = io.Copy(dst, src)
-		if err != nil {
-			fmt.Println("Error while copying log into the new file")
-			return err
+	for i := 0; i < len(logFiles); i++ {
+		if err := MoveTcpdumpLogs(store, "r"+strconv.Itoa(i+1), i); err != nil {
+			return "", err
 		}
 	}
 
-	err = MoveControlLogs(dirName)
+	return store.RunDir(), nil
+}
+
+// copyIntoStore copies srcPath into store under device, keeping srcPath's
+// base name as the artifact name.
+func copyIntoStore(store *logstore.Store, device, srcPath string) error {
+	src, err := os.Open(srcPath)
 	if err != nil {
+		fmt.Println("Error while opening log file")
 		return err
 	}
+	defer src.Close()
 
-	for i := 0; i < len(logFiles); i++ {
-
-		err = MoveTcpdumpLogs(dirName, "r"+strconv.Itoa(i+1), i)
-		if err != nil {
-			return err
-		}
-
+	dst, err := store.Writer(device, filepath.Base(srcPath))
+	if err != nil {
+		fmt.Println("Error while creating new file")
+		return err
 	}
+	defer dst.Close()
 
+	if _, err := io.Copy(dst, src); err != nil {
+		fmt.Println("Error while copying log into the new file")
+		return err
+	}
 	return nil
 }
 
-func MoveControlLogs(dirName string) error {
-	//Move the control log file in the created directory
+func MoveControlLogs(store *logstore.Store) error {
+	//Move the control log file into the run directory's root
 	control, err := os.Open("control.log")
 	if err != nil {
 		fmt.Println("Error while opening control log file")
 		return err
 	}
 	defer control.Close()
-	destFile := filepath.Join(model.Scenar.LogPath+"/"+model.Scenar.ScenarioName+"/"+dirName, filepath.Base("control.log"))
-	dst, err := os.Create(destFile)
+
+	dst, err := store.Writer("", "control.log")
 	if err != nil {
 		fmt.Println("Error while creating new control log file")
 		return err
 	}
+	defer dst.Close()
+
 	_, err = io.Copy(dst, control)
 	if err != nil {
 		fmt.Println("Error while copying control log into the new file")
@@ -130,53 +127,48 @@ func MoveControlLogs(dirName string) error {
 	return nil
 }
 
-func MoveTcpdumpLogs(dirName string, device string, index int) error {
-
-	err := os.Mkdir(model.Scenar.LogPath+"/"+model.Scenar.ScenarioName+"/"+dirName+"/"+device+"/tcpdump", 0777)
-	if err != nil {
-		return err
-	}
-
+func MoveTcpdumpLogs(store *logstore.Store, device string, index int) error {
 	for _, inter := range model.Devices.Nodes[index].Interfaces {
-		tcpdumpFile, err := os.Open(model.FindTopoPath() + device + "/tcpdump/tcpdump_" + inter.Name + ".log")
+		tcpdumpFile, err := os.Open(model.FindTopoPath() + device + "/tcpdump/tcpdump_" + inter.Name + ".pcap")
 		if err != nil {
 			fmt.Println("Error while opening tcpdump log file")
 			return err
 		}
 		defer tcpdumpFile.Close()
 
-		dst, err := os.Create(model.Scenar.LogPath + "/" + model.Scenar.ScenarioName + "/" + dirName + "/" + device + "/tcpdump/tcpdump_" + inter.Name + ".log")
+		dst, err := store.Writer(filepath.Join(device, "tcpdump"), "tcpdump_"+inter.Name+".pcap")
 		if err != nil {
 			fmt.Println("Error while creating new tcpdump log file")
 			return err
 		}
+		defer dst.Close()
+
 		_, err = io.Copy(dst, tcpdumpFile)
 		if err != nil {
 			fmt.Println("Error while copying tcpdump log into the new file")
 			return err
 		}
-
 	}
 
-	/*err = os.Remove(model.FindTopoPath() + device + "/tcpdump")
-	if err != nil {
-		return err
-	}*/
 	return nil
-
 }
 
 func GetTcpdumpLogs(nbFile int) error {
+	cli, err := runtime.NewDockerClient()
+	if err != nil {
+		fmt.Println("Error while creating Docker client")
+		return err
+	}
 
 	containerNameArray := strings.Split(model.Scenar.Event[0].Host, "-")
 	containerName := strings.Join(containerNameArray[:len(containerNameArray)-1], "-")
 
 	for i := 0; i < nbFile; i++ {
-		cmd := exec.Command("sudo", "docker", "cp", containerName+"-r"+strconv.Itoa(i+1)+":/tcpdump", model.FindTopoPath()+"r"+strconv.Itoa(i+1)+"/")
-		output, err := cmd.CombinedOutput()
+		ctx, cancel := context.WithTimeout(context.Background(), tcpdumpLogTimeout)
+		err := cli.CopyTreeFromContainer(ctx, containerName+"-r"+strconv.Itoa(i+1), "/tcpdump", model.FindTopoPath()+"r"+strconv.Itoa(i+1)+"/")
+		cancel()
 		if err != nil {
 			fmt.Println("Error while moving tcpdump directory")
-			log.Println(string(output))
 			return err
 		}
 	}
@@ -198,14 +190,20 @@ func FlushLogFiles(logFiles []string) error {
 func TcpdumpLog(index int) error {
 	containerNameArray := strings.Split(model.Scenar.Event[0].Host, "-")
 	containerName := strings.Join(containerNameArray[:len(containerNameArray)-1], "-")
-	// fmt.Println("Container Name: ", containerName) // Debug print
+	container := containerName + "-r" + strconv.Itoa(index+1)
+
+	cli, err := runtime.NewDockerClient()
+	if err != nil {
+		fmt.Println("Error while creating Docker client:", err)
+		return err
+	}
 
 	// Build directory path
 	topoPath := model.FindTopoPath() + "/r" + strconv.Itoa(index+1)
 	scriptPath := topoPath + "/tcpdump.sh"
 
 	// Create directory if necessary
-	err := os.MkdirAll(topoPath, 0755)
+	err = os.MkdirAll(topoPath, 0755)
 	if err != nil {
 		fmt.Println("Error while creating directory:", err)
 		return err
@@ -226,18 +224,15 @@ func TcpdumpLog(index int) error {
 		return err
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), tcpdumpLogTimeout)
+	defer cancel()
+
 	// Create the tcpdump directory in the container
-	cmd := exec.Command("sudo", "docker", "exec", "-d", containerName+"-r"+strconv.Itoa(index+1), "mkdir", "tcpdump")
-	var output []byte
-	output, err = cmd.CombinedOutput()
-	if err != nil{
+	if _, _, _, err := cli.ExecInContainer(ctx, container, []string{"mkdir", "tcpdump"}, runtime.ExecOptions{}); err != nil {
 		fmt.Println("Error while creating tcpdump directory:", err)
-		log.Println(string(output))
 		return err
 	}
 
-	
-
 	// Write the script in tcpdump.sh
 	_, err = file.WriteString("#!/bin/sh \n")
 	if err != nil {
@@ -245,9 +240,15 @@ func TcpdumpLog(index int) error {
 		return err
 	}
 
-	// Add tcpdump commands for each interface
+	// Add tcpdump commands for each interface. This writes binary pcap
+	// directly (-w) rather than the old "-n -v > ... .log" text dump, so the
+	// captures GetTcpdumpLogs copies out are consumable by tshark/gopacket.
+	// Unlike NetworkController.SetupTcpdump, this legacy path still detaches
+	// the capture inside the container and collects it later rather than
+	// streaming it to the host live: it has no long-lived context to hang a
+	// stream off, and redesigning that is out of scope here.
 	for _, inter := range model.Devices.Nodes[index].Interfaces {
-		_, err = file.WriteString("tcpdump -i " + inter.Name + " -n -v > tcpdump/tcpdump" + "_" + inter.Name + ".log & \n")
+		_, err = file.WriteString("tcpdump -i " + inter.Name + " -w tcpdump/tcpdump" + "_" + inter.Name + ".pcap & \n")
 		if err != nil {
 			fmt.Println("Error while writing in tcpdump.sh file:", err)
 			return err
@@ -255,20 +256,14 @@ func TcpdumpLog(index int) error {
 	}
 
 	// Copy the tcpdump.sh script into the container
-	cmd = exec.Command("sudo", "docker", "cp", scriptPath, containerName+"-r"+strconv.Itoa(index+1)+":/")
-	output, err = cmd.CombinedOutput()
-	if err != nil {
+	if err := cli.CopyToContainer(ctx, container, scriptPath, "/", runtime.CopyOptions{}); err != nil {
 		fmt.Println("Error while copying tcpdump script in the host container:", err)
-		log.Println(string(output))
 		return err
 	}
 
 	// Run the tcpdump.sh script in the container
-	cmd = exec.Command("sudo", "docker", "exec", "-d", containerName+"-r"+strconv.Itoa(index+1), "./tcpdump.sh")
-	output, err = cmd.CombinedOutput()
-	if err != nil {
+	if _, _, _, err := cli.ExecInContainer(ctx, container, []string{"./tcpdump.sh"}, runtime.ExecOptions{}); err != nil {
 		fmt.Println("Error while starting tcpdump:", err)
-		log.Println(string(output))
 		return err
 	}
 
@@ -0,0 +1,88 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDockerLogStreamer is a minimal in-memory stand-in for
+// dockerLogStreamer.
+type fakeDockerLogStreamer struct {
+	calls []string // container names StreamLogs was called with
+	err   error
+}
+
+func (f *fakeDockerLogStreamer) StreamLogs(ctx context.Context, container string, w io.Writer) error {
+	f.calls = append(f.calls, container)
+	if f.err != nil {
+		return f.err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestLogTailer_TailStdout_WithoutDockerIsAnError(t *testing.T) {
+	tailer := &LogTailer{}
+
+	err := tailer.TailStdout(context.Background(), "r1:stdout", "clab-test-lab-r1", filepath.Join(t.TempDir(), "r1.stdout.log"))
+
+	assert.Error(t, err)
+}
+
+func TestLogTailer_TailStdout_StreamsIntoFile(t *testing.T) {
+	docker := &fakeDockerLogStreamer{}
+	tailer := &LogTailer{Docker: docker}
+	dstPath := filepath.Join(t.TempDir(), "r1.stdout.log")
+
+	require.NoError(t, tailer.TailStdout(context.Background(), "r1:stdout", "clab-test-lab-r1", dstPath))
+	require.NoError(t, tailer.Close())
+
+	assert.Equal(t, []string{"clab-test-lab-r1"}, docker.calls)
+	assert.FileExists(t, dstPath)
+}
+
+func TestLogTailer_TailFile_StreamsViaContainerRuntime(t *testing.T) {
+	runtimeMock := &mockCaptureRuntime{}
+	tailer := &LogTailer{Runtime: runtimeMock}
+	dstPath := filepath.Join(t.TempDir(), "frr.log")
+
+	require.NoError(t, tailer.TailFile(context.Background(), "r1:/var/log/frr/frr.log", "clab-test-lab-r1", "/var/log/frr/frr.log", dstPath))
+	require.NoError(t, tailer.Close())
+
+	require.Len(t, runtimeMock.streamCalls, 1)
+	assert.Equal(t, []string{"clab-test-lab-r1", "tail", "-F", "-n", "+1", "/var/log/frr/frr.log"}, runtimeMock.streamCalls[0])
+}
+
+func TestLogTailer_Close_PropagatesStreamError(t *testing.T) {
+	docker := &fakeDockerLogStreamer{err: errors.New("daemon unreachable")}
+	tailer := &LogTailer{Docker: docker}
+
+	require.NoError(t, tailer.TailStdout(context.Background(), "r1:stdout", "clab-test-lab-r1", filepath.Join(t.TempDir(), "r1.stdout.log")))
+
+	// Give the tail goroutine a moment to observe the error before Close
+	// collects it.
+	time.Sleep(10 * time.Millisecond)
+
+	err := tailer.Close()
+	assert.Error(t, err)
+}
+
+func TestLogTailer_Flush_IsANoOp(t *testing.T) {
+	assert.NoError(t, (&LogTailer{}).Flush())
+}
+
+func TestLogTailer_TailFile_CreateError(t *testing.T) {
+	tailer := &LogTailer{Runtime: &mockCaptureRuntime{}}
+	dstPath := filepath.Join(t.TempDir(), "no-such-dir", "frr.log")
+
+	err := tailer.TailFile(context.Background(), "r1:bad", "clab-test-lab-r1", "/var/log/frr/frr.log", dstPath)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package network
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/3atlab/netroub/pkg/model/manifest"
+)
+
+// RunManifestInput collects the per-run facts WriteRunManifest can't derive
+// by walking the run directory itself.
+type RunManifestInput struct {
+	ScenarioPath string
+	TopologyPath string
+	RunStart     time.Time
+	RunEnd       time.Time
+	Hosts        []manifest.Host
+	Events       []manifest.Event
+}
+
+// WriteRunManifest hashes input.ScenarioPath, records the netroub repo's git
+// commit (best-effort, empty if netroub isn't running from a checkout),
+// walks runDir to record every collected artifact's size and sha256, and
+// writes the result as runDir/manifest.json so downstream analysis
+// pipelines can enumerate runs without walking directories by hand.
+func WriteRunManifest(runDir, scenarioName string, input RunManifestInput) error {
+	scenarioHash, err := sha256File(input.ScenarioPath)
+	if err != nil {
+		return fmt.Errorf("failed to hash scenario file: %w", err)
+	}
+
+	artifacts, err := collectArtifacts(runDir)
+	if err != nil {
+		return fmt.Errorf("failed to collect run artifacts: %w", err)
+	}
+
+	m := manifest.Manifest{
+		ScenarioName:   scenarioName,
+		ScenarioPath:   input.ScenarioPath,
+		ScenarioSHA256: scenarioHash,
+		TopologyPath:   input.TopologyPath,
+		NetroubCommit:  netroubCommit(),
+		RunStart:       input.RunStart,
+		RunEnd:         input.RunEnd,
+		Hosts:          input.Hosts,
+		Events:         input.Events,
+		Artifacts:      artifacts,
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run manifest: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(runDir, "manifest.json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write run manifest: %w", err)
+	}
+	return nil
+}
+
+// collectArtifacts walks runDir, recording every regular file (other than
+// the manifest itself) as a manifest.Artifact with a path relative to
+// runDir.
+func collectArtifacts(runDir string) ([]manifest.Artifact, error) {
+	var artifacts []manifest.Artifact
+	err := filepath.Walk(runDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Base(path) == "manifest.json" {
+			return nil
+		}
+
+		hash, err := sha256File(path)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(runDir, path)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, manifest.Artifact{
+			Path:   relPath,
+			Size:   info.Size(),
+			SHA256: hash,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+// sha256File returns the hex-encoded sha256 of path's contents.
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// netroubCommit returns the git HEAD commit of the netroub checkout netroub
+// is running from, or "" if the working directory isn't one (e.g. netroub
+// installed as a standalone binary).
+func netroubCommit() string {
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
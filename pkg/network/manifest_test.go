@@ -0,0 +1,68 @@
+package network
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/3atlab/netroub/pkg/model/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRunManifest(t *testing.T) {
+	runDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "r1.log"), []byte("hello"), 0644))
+
+	scenarioFile := filepath.Join(t.TempDir(), "scenario.json")
+	require.NoError(t, os.WriteFile(scenarioFile, []byte(`{"scenarioName":"test"}`), 0644))
+
+	start := time.Now()
+	end := start.Add(time.Second)
+
+	err := WriteRunManifest(runDir, "test", RunManifestInput{
+		ScenarioPath: scenarioFile,
+		TopologyPath: "/topo",
+		RunStart:     start,
+		RunEnd:       end,
+		Hosts:        []manifest.Host{{Host: "r1", Container: "clab-test-r1"}},
+		Events: []manifest.Event{
+			{Index: 0, Type: "dummy", StartTime: start, EndTime: end},
+		},
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(runDir, "manifest.json"))
+	require.NoError(t, err)
+
+	var m manifest.Manifest
+	require.NoError(t, json.Unmarshal(data, &m))
+
+	assert.Equal(t, "test", m.ScenarioName)
+	assert.Equal(t, scenarioFile, m.ScenarioPath)
+	assert.NotEmpty(t, m.ScenarioSHA256)
+	assert.Equal(t, "/topo", m.TopologyPath)
+	assert.Len(t, m.Hosts, 1)
+	assert.Len(t, m.Events, 1)
+	require.Len(t, m.Artifacts, 1)
+	assert.Equal(t, "r1.log", m.Artifacts[0].Path)
+	assert.Equal(t, int64(len("hello")), m.Artifacts[0].Size)
+	assert.NotEmpty(t, m.Artifacts[0].SHA256)
+}
+
+func TestWriteRunManifest_ExcludesItself(t *testing.T) {
+	runDir := t.TempDir()
+	scenarioFile := filepath.Join(t.TempDir(), "scenario.json")
+	require.NoError(t, os.WriteFile(scenarioFile, []byte(`{}`), 0644))
+
+	require.NoError(t, WriteRunManifest(runDir, "test", RunManifestInput{ScenarioPath: scenarioFile}))
+	require.NoError(t, WriteRunManifest(runDir, "test", RunManifestInput{ScenarioPath: scenarioFile}))
+
+	data, err := os.ReadFile(filepath.Join(runDir, "manifest.json"))
+	require.NoError(t, err)
+	var m manifest.Manifest
+	require.NoError(t, json.Unmarshal(data, &m))
+	assert.Empty(t, m.Artifacts)
+}
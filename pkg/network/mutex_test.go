@@ -1,6 +1,7 @@
 package network
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -27,7 +28,7 @@ func newSlowMockRunner(deployDelay, destroyDelay time.Duration) *slowMockRunner
 	}
 }
 
-func (m *slowMockRunner) Run(name string, args ...string) ([]byte, error) {
+func (m *slowMockRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
 	// Track concurrent operations
 	current := atomic.AddInt32(&m.concurrentOps, 1)
 	defer atomic.AddInt32(&m.concurrentOps, -1)
@@ -90,7 +91,7 @@ func TestNetworkMutex_SerializesDeployOperations(t *testing.T) {
 	for i := 0; i < 4; i++ {
 		scenario := &model.Scenario{Topo: "/path/to/topo.yaml"}
 		devices := &model.Data{}
-		controllers[i] = NewNetworkController(scenario, devices, "test-lab", mock)
+		controllers[i] = NewNetworkController(scenario, devices, "test-lab", mock, nil)
 	}
 
 	// Run all deploys concurrently
@@ -99,7 +100,7 @@ func TestNetworkMutex_SerializesDeployOperations(t *testing.T) {
 		wg.Add(1)
 		go func(idx int) {
 			defer wg.Done()
-			err := controllers[idx].Deploy()
+			err := controllers[idx].Deploy(context.Background())
 			assert.NoError(t, err)
 		}(i)
 	}
@@ -124,7 +125,7 @@ func TestNetworkMutex_SerializesDestroyOperations(t *testing.T) {
 	for i := 0; i < 4; i++ {
 		scenario := &model.Scenario{Topo: "/path/to/topo.yaml"}
 		devices := &model.Data{}
-		controllers[i] = NewNetworkController(scenario, devices, "test-lab", mock)
+		controllers[i] = NewNetworkController(scenario, devices, "test-lab", mock, nil)
 	}
 
 	// Run all destroys concurrently
@@ -133,7 +134,7 @@ func TestNetworkMutex_SerializesDestroyOperations(t *testing.T) {
 		wg.Add(1)
 		go func(idx int) {
 			defer wg.Done()
-			err := controllers[idx].Destroy()
+			err := controllers[idx].Destroy(context.Background())
 			assert.NoError(t, err)
 		}(i)
 	}
@@ -159,7 +160,7 @@ func TestNetworkMutex_SerializesMixedOperations(t *testing.T) {
 	for i := 0; i < numOps; i++ {
 		scenario := &model.Scenario{Topo: "/path/to/topo.yaml"}
 		devices := &model.Data{}
-		controllers[i] = NewNetworkController(scenario, devices, "test-lab", mock)
+		controllers[i] = NewNetworkController(scenario, devices, "test-lab", mock, nil)
 	}
 
 	// Run alternating deploy/destroy concurrently
@@ -169,10 +170,10 @@ func TestNetworkMutex_SerializesMixedOperations(t *testing.T) {
 		go func(idx int) {
 			defer wg.Done()
 			if idx%2 == 0 {
-				err := controllers[idx].Deploy()
+				err := controllers[idx].Deploy(context.Background())
 				assert.NoError(t, err)
 			} else {
-				err := controllers[idx].Destroy()
+				err := controllers[idx].Destroy(context.Background())
 				assert.NoError(t, err)
 			}
 		}(i)
@@ -198,7 +199,7 @@ func TestNetworkMutex_NoDeadlock(t *testing.T) {
 	for i := 0; i < numOps; i++ {
 		scenario := &model.Scenario{Topo: "/path/to/topo.yaml"}
 		devices := &model.Data{}
-		controllers[i] = NewNetworkController(scenario, devices, "test-lab", mock)
+		controllers[i] = NewNetworkController(scenario, devices, "test-lab", mock, nil)
 	}
 
 	// Use a timeout to detect deadlock
@@ -210,9 +211,9 @@ func TestNetworkMutex_NoDeadlock(t *testing.T) {
 			go func(idx int) {
 				defer wg.Done()
 				if idx%2 == 0 {
-					_ = controllers[idx].Deploy()
+					_ = controllers[idx].Deploy(context.Background())
 				} else {
-					_ = controllers[idx].Destroy()
+					_ = controllers[idx].Destroy(context.Background())
 				}
 			}(i)
 		}
@@ -241,7 +242,7 @@ func TestNetworkMutex_TotalDurationReflectsSerialization(t *testing.T) {
 	for i := 0; i < numOps; i++ {
 		scenario := &model.Scenario{Topo: "/path/to/topo.yaml"}
 		devices := &model.Data{}
-		controllers[i] = NewNetworkController(scenario, devices, "test-lab", mock)
+		controllers[i] = NewNetworkController(scenario, devices, "test-lab", mock, nil)
 	}
 
 	start := time.Now()
@@ -251,7 +252,7 @@ func TestNetworkMutex_TotalDurationReflectsSerialization(t *testing.T) {
 		wg.Add(1)
 		go func(idx int) {
 			defer wg.Done()
-			_ = controllers[idx].Deploy()
+			_ = controllers[idx].Deploy(context.Background())
 		}(i)
 	}
 	wg.Wait()
@@ -285,7 +286,7 @@ func newTimestampedMockRunner(opDuration time.Duration) *timestampedMockRunner {
 	}
 }
 
-func (m *timestampedMockRunner) Run(name string, args ...string) ([]byte, error) {
+func (m *timestampedMockRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
 	isDeploy := false
 	isDestroy := false
 	for _, arg := range args {
@@ -335,7 +336,7 @@ func TestNetworkMutex_NoOverlappingOperations(t *testing.T) {
 	for i := 0; i < numOps; i++ {
 		scenario := &model.Scenario{Topo: "/path/to/topo.yaml"}
 		devices := &model.Data{}
-		controllers[i] = NewNetworkController(scenario, devices, "test-lab", mock)
+		controllers[i] = NewNetworkController(scenario, devices, "test-lab", mock, nil)
 	}
 
 	var wg sync.WaitGroup
@@ -344,9 +345,9 @@ func TestNetworkMutex_NoOverlappingOperations(t *testing.T) {
 		go func(idx int) {
 			defer wg.Done()
 			if idx%2 == 0 {
-				_ = controllers[idx].Deploy()
+				_ = controllers[idx].Deploy(context.Background())
 			} else {
-				_ = controllers[idx].Destroy()
+				_ = controllers[idx].Destroy(context.Background())
 			}
 		}(i)
 	}
@@ -0,0 +1,147 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"io"
+	goruntime "runtime"
+
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/runtime"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/afpacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/vishvananda/netns"
+)
+
+// PacketCapture is the pluggable backend SetupTcpdump drives to capture one
+// container interface's traffic as pcap data written to w, until ctx is
+// canceled. NetworkController falls back to ExecCapture (tcpdump through
+// ContainerRuntime.StreamExecOutput, as introduced in chunk4-2) when its
+// Capture field is left nil; set Capture to a NetnsCapture to capture from
+// the host process instead, without needing tcpdump or a shell in the node
+// image.
+type PacketCapture interface {
+	Start(ctx context.Context, containerName, iface string, cfg model.CaptureConfig, w io.Writer) error
+}
+
+// ExecCapture is the default PacketCapture: it runs tcpdump inside the
+// container via ContainerRuntime.StreamExecOutput.
+type ExecCapture struct {
+	Runtime runtime.ContainerRuntime
+}
+
+// Start runs tcpdumpArgs(cfg, iface) inside containerName, streaming its
+// binary pcap stdout into w as it's produced.
+func (c *ExecCapture) Start(ctx context.Context, containerName, iface string, cfg model.CaptureConfig, w io.Writer) error {
+	return c.Runtime.StreamExecOutput(ctx, containerName, tcpdumpArgs(cfg, iface), runtime.ExecOptions{}, w)
+}
+
+// pidResolver resolves a container's PID on the host, so NetnsCapture can
+// reach its network namespace via /proc/<pid>/ns/net. It mirrors
+// events.pidResolver (also satisfied by *runtime.SDKDockerClient's
+// ContainerPID), kept as its own unexported interface here the same way
+// every other narrow-interface-per-file dependency in this tree is.
+type pidResolver interface {
+	ContainerPID(ctx context.Context, container string) (int, error)
+}
+
+// NetnsCapture captures iface's traffic from the netroub host process
+// itself, joining the container's network namespace (resolved from
+// /proc/<pid>/ns/net the same way events.netlinkDriver does) and reading
+// frames with gopacket/afpacket, written out as pcap via gopacket/pcapgo.
+// This needs neither tcpdump nor a shell inside the node image, and
+// captures full-fidelity frames rather than whatever tcpdump's own libpcap
+// build supports.
+//
+// Only Docker's SDK currently exposes a PID to resolve a namespace from
+// (PodmanRuntime has no ContainerPID yet), so Docker is the only
+// pidResolver in this tree today; NetnsCapture.Start is not wired up as
+// NetworkController's default Capture for that reason — callers that want
+// it set Capture explicitly and accept that restriction.
+//
+// Not done: cfg.RotateSize is not honored here (unlike ExecCapture, which
+// passes it through to tcpdump's own -C). Start writes the whole session
+// into w with no file rotation.
+type NetnsCapture struct {
+	Docker pidResolver
+}
+
+// Start joins containerName's network namespace, opens iface there with
+// afpacket, applies cfg.Filter as a BPF filter if set, and writes every
+// captured frame to w as pcap until ctx is canceled.
+func (c *NetnsCapture) Start(ctx context.Context, containerName, iface string, cfg model.CaptureConfig, w io.Writer) error {
+	pid, err := c.Docker.ContainerPID(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("resolving netns for %s: %w", containerName, err)
+	}
+
+	handle, err := openTPacketInNetns(pid, iface)
+	if err != nil {
+		return fmt.Errorf("opening %s in %s's netns: %w", iface, containerName, err)
+	}
+	defer handle.Close()
+
+	if cfg.Filter != "" {
+		if err := handle.SetBPFFilter(cfg.Filter); err != nil {
+			return fmt.Errorf("applying BPF filter %q: %w", cfg.Filter, err)
+		}
+	}
+
+	snaplen := cfg.Snaplen
+	if snaplen <= 0 {
+		snaplen = 262144 // tcpdump's own default
+	}
+	pcapWriter := pcapgo.NewWriter(w)
+	if err := pcapWriter.WriteFileHeader(uint32(snaplen), layers.LinkTypeEthernet); err != nil {
+		return fmt.Errorf("writing pcap header: %w", err)
+	}
+
+	packets := gopacket.NewPacketSource(handle, layers.LinkTypeEthernet).Packets()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case pkt, ok := <-packets:
+			if !ok {
+				return nil
+			}
+			if err := pcapWriter.WritePacket(pkt.Metadata().CaptureInfo, pkt.Data()); err != nil {
+				return fmt.Errorf("writing packet: %w", err)
+			}
+		}
+	}
+}
+
+// openTPacketInNetns opens an afpacket TPacket handle bound to iface inside
+// pid's network namespace. Unlike netlink.NewHandleAt (which the netlink
+// library lets operate against a foreign namespace handle directly),
+// afpacket has no namespace-scoped constructor, so this locks the calling
+// goroutine to its OS thread, switches that thread into the namespace just
+// long enough to open the socket, and switches it back before returning —
+// the opened raw socket keeps capturing in the namespace it was created in
+// even after the thread returns to the root one.
+func openTPacketInNetns(pid int, iface string) (*afpacket.TPacket, error) {
+	goruntime.LockOSThread()
+	defer goruntime.UnlockOSThread()
+
+	origNs, err := netns.Get()
+	if err != nil {
+		return nil, fmt.Errorf("getting current network namespace: %w", err)
+	}
+	defer origNs.Close()
+
+	targetNs, err := netns.GetFromPid(pid)
+	if err != nil {
+		return nil, fmt.Errorf("opening network namespace for pid %d: %w", pid, err)
+	}
+	defer targetNs.Close()
+
+	if err := netns.Set(targetNs); err != nil {
+		return nil, fmt.Errorf("joining network namespace for pid %d: %w", pid, err)
+	}
+	defer netns.Set(origNs)
+
+	return afpacket.NewTPacket(afpacket.OptInterface(iface))
+}
@@ -0,0 +1,56 @@
+package network
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePacketCapture is a minimal in-memory stand-in for PacketCapture.
+type fakePacketCapture struct {
+	calls []string // container names Start was called with
+}
+
+func (f *fakePacketCapture) Start(ctx context.Context, containerName, iface string, cfg model.CaptureConfig, w io.Writer) error {
+	f.calls = append(f.calls, containerName)
+	return nil
+}
+
+func TestNetworkController_UsesConfiguredCapture(t *testing.T) {
+	capture := &fakePacketCapture{}
+	controller := &NetworkController{LabName: "test-lab", Capture: capture}
+
+	assert.Same(t, capture, controller.capture())
+}
+
+func TestNetworkController_DefaultsToExecCaptureFromContainerRuntime(t *testing.T) {
+	dockerMock := &mockCaptureRuntime{}
+	controller := &NetworkController{LabName: "test-lab", ContainerRuntime: dockerMock}
+
+	exec, ok := controller.capture().(*ExecCapture)
+	require.True(t, ok)
+	assert.Same(t, dockerMock, exec.Runtime)
+}
+
+// fakePidResolver is a minimal in-memory stand-in for pidResolver.
+type fakePidResolver struct {
+	pid int
+	err error
+}
+
+func (f *fakePidResolver) ContainerPID(ctx context.Context, container string) (int, error) {
+	return f.pid, f.err
+}
+
+func TestNetnsCapture_Start_PropagatesPIDResolutionError(t *testing.T) {
+	capture := &NetnsCapture{Docker: &fakePidResolver{err: assert.AnError}}
+
+	err := capture.Start(context.Background(), "clab-test-lab-r1", "eth0", model.CaptureConfig{}, io.Discard)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "resolving netns")
+}
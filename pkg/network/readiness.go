@@ -0,0 +1,146 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/runtime"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultReadinessInterval = 2 * time.Second
+	defaultReadinessTimeout  = 2 * time.Second
+	defaultReadinessRetries  = 3
+)
+
+// WaitForTopologyReady runs cfg's per-node readiness probes against
+// containerRuntime, modeled on Docker HEALTHCHECK: each probe is retried
+// every cfg.Interval, failures during cfg.StartPeriod don't count against
+// cfg.Retries, and a node is declared unready (failing the scenario before
+// any event runs) once cfg.Retries consecutive attempts fail after
+// cfg.StartPeriod has elapsed. hostContainer maps a probe's Host to the
+// container name to exec into. An empty cfg.Probes runs no gate.
+func WaitForTopologyReady(ctx context.Context, cfg model.ReadinessConfig, containerRuntime runtime.ContainerRuntime, hostContainer func(host string) string) error {
+	if len(cfg.Probes) == 0 {
+		return nil
+	}
+
+	interval, err := readinessDurationOrDefault(cfg.Interval, defaultReadinessInterval)
+	if err != nil {
+		return fmt.Errorf("invalid readiness interval %q: %w", cfg.Interval, err)
+	}
+	timeout, err := readinessDurationOrDefault(cfg.Timeout, defaultReadinessTimeout)
+	if err != nil {
+		return fmt.Errorf("invalid readiness timeout %q: %w", cfg.Timeout, err)
+	}
+	startPeriod, err := readinessDurationOrDefault(cfg.StartPeriod, 0)
+	if err != nil {
+		return fmt.Errorf("invalid readiness startPeriod %q: %w", cfg.StartPeriod, err)
+	}
+	retries := cfg.Retries
+	if retries <= 0 {
+		retries = defaultReadinessRetries
+	}
+
+	start := time.Now()
+	for _, probe := range cfg.Probes {
+		container := hostContainer(probe.Host)
+		if err := waitForProbe(ctx, containerRuntime, container, probe, interval, timeout, retries, start, startPeriod); err != nil {
+			return fmt.Errorf("readiness check failed for %s: %w", probe.Host, err)
+		}
+	}
+	return nil
+}
+
+// readinessDurationOrDefault parses s as a duration, or returns def if s is
+// empty.
+func readinessDurationOrDefault(s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// waitForProbe retries probe against container every interval, logging each
+// attempt through logrus, until it succeeds or retries consecutive failures
+// accumulate after startPeriod (measured from start) has elapsed.
+func waitForProbe(ctx context.Context, containerRuntime runtime.ContainerRuntime, container string, probe model.ReadinessProbe, interval, timeout time.Duration, retries int, start time.Time, startPeriod time.Duration) error {
+	kind := probe.Kind
+	if kind == "" {
+		kind = model.ReadinessProbePing
+	}
+
+	consecutiveFailures := 0
+	var lastErr error
+	for {
+		probeCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := runProbe(probeCtx, containerRuntime, container, kind, probe.Target, timeout)
+		cancel()
+
+		if err == nil {
+			logrus.Debugf("readiness: %s (%s probe) is ready", container, kind)
+			return nil
+		}
+		lastErr = err
+
+		if time.Since(start) < startPeriod {
+			logrus.Debugf("readiness: %s (%s probe) not ready yet, within start period: %v", container, kind, err)
+		} else {
+			consecutiveFailures++
+			logrus.Warnf("readiness: %s (%s probe) failed (%d/%d): %v", container, kind, consecutiveFailures, retries, err)
+			if consecutiveFailures >= retries {
+				return fmt.Errorf("exceeded %d retries, last error: %w", retries, lastErr)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// runProbe runs a single attempt of kind against container, returning a
+// non-nil error if the probe didn't pass.
+func runProbe(ctx context.Context, containerRuntime runtime.ContainerRuntime, container string, kind model.ReadinessProbeKind, target string, timeout time.Duration) error {
+	timeoutSec := int(timeout.Seconds())
+	if timeoutSec < 1 {
+		timeoutSec = 1
+	}
+
+	var cmd []string
+	switch kind {
+	case model.ReadinessProbePing:
+		cmd = []string{"ping", "-c", "1", "-W", fmt.Sprintf("%d", timeoutSec), target}
+	case model.ReadinessProbeTCP:
+		host, port, err := net.SplitHostPort(target)
+		if err != nil {
+			return fmt.Errorf("invalid tcp target %q: %w", target, err)
+		}
+		cmd = []string{"nc", "-z", "-w", fmt.Sprintf("%d", timeoutSec), host, port}
+	case model.ReadinessProbeCommand:
+		if target == "" {
+			return fmt.Errorf("command probe requires a target")
+		}
+		cmd = []string{"/bin/sh", "-c", target}
+	case model.ReadinessProbeRoute:
+		cmd = []string{"ip", "route", "get", target}
+	default:
+		return fmt.Errorf("unknown readiness probe kind %q", kind)
+	}
+
+	_, stderr, exitCode, err := containerRuntime.Exec(ctx, container, cmd, runtime.ExecOptions{})
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("command %q exited %d: %s", strings.Join(cmd, " "), exitCode, strings.TrimSpace(stderr))
+	}
+	return nil
+}
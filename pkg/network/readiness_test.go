@@ -0,0 +1,199 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockContainerRuntime records Exec calls and lets a test script canned
+// results per call for testing readiness probes.
+type mockContainerRuntime struct {
+	execCalls [][]string
+	// results is consumed in order, one per Exec call; once exhausted the
+	// last entry repeats.
+	results []mockExecResult
+}
+
+type mockExecResult struct {
+	exitCode int
+	err      error
+}
+
+func (m *mockContainerRuntime) ContainerName(labName, host string) string {
+	return "clab-" + labName + "-" + host
+}
+
+func (m *mockContainerRuntime) Exec(ctx context.Context, container string, cmd []string, opts runtime.ExecOptions) (string, string, int, error) {
+	m.execCalls = append(m.execCalls, append([]string{container}, cmd...))
+	result := mockExecResult{}
+	if len(m.results) > 0 {
+		idx := len(m.execCalls) - 1
+		if idx >= len(m.results) {
+			idx = len(m.results) - 1
+		}
+		result = m.results[idx]
+	}
+	return "", "", result.exitCode, result.err
+}
+
+func (m *mockContainerRuntime) CopyTo(ctx context.Context, container, srcPath, dstPath string, opts runtime.CopyOptions) error {
+	return nil
+}
+
+func (m *mockContainerRuntime) CopyFrom(ctx context.Context, container, srcPath, dstPath string) error {
+	return nil
+}
+
+func (m *mockContainerRuntime) StreamExecOutput(ctx context.Context, container string, cmd []string, opts runtime.ExecOptions, w io.Writer) error {
+	return nil
+}
+
+func (m *mockContainerRuntime) Inspect(ctx context.Context, container string) (runtime.ContainerInfo, error) {
+	return runtime.ContainerInfo{}, nil
+}
+
+func TestWaitForTopologyReady_NoProbes(t *testing.T) {
+	cr := &mockContainerRuntime{}
+	err := WaitForTopologyReady(context.Background(), model.ReadinessConfig{}, cr, func(h string) string { return h })
+	assert.NoError(t, err)
+	assert.Empty(t, cr.execCalls)
+}
+
+func TestWaitForTopologyReady_Succeeds(t *testing.T) {
+	cr := &mockContainerRuntime{results: []mockExecResult{{exitCode: 0}}}
+	cfg := model.ReadinessConfig{
+		Interval: "1ms",
+		Timeout:  "10ms",
+		Probes: []model.ReadinessProbe{
+			{Host: "r1", Kind: model.ReadinessProbePing, Target: "10.0.0.2"},
+		},
+	}
+
+	err := WaitForTopologyReady(context.Background(), cfg, cr, func(h string) string { return "clab-lab-" + h })
+	require.NoError(t, err)
+	require.Len(t, cr.execCalls, 1)
+	assert.Equal(t, []string{"clab-lab-r1", "ping", "-c", "1", "-W", "1", "10.0.0.2"}, cr.execCalls[0])
+}
+
+func TestWaitForTopologyReady_RetriesThenSucceeds(t *testing.T) {
+	cr := &mockContainerRuntime{results: []mockExecResult{
+		{exitCode: 1},
+		{exitCode: 1},
+		{exitCode: 0},
+	}}
+	cfg := model.ReadinessConfig{
+		Interval: "1ms",
+		Timeout:  "10ms",
+		Retries:  5,
+		Probes: []model.ReadinessProbe{
+			{Host: "r1", Target: "10.0.0.2"},
+		},
+	}
+
+	err := WaitForTopologyReady(context.Background(), cfg, cr, func(h string) string { return h })
+	require.NoError(t, err)
+	assert.Len(t, cr.execCalls, 3)
+}
+
+func TestWaitForTopologyReady_ExceedsRetries(t *testing.T) {
+	cr := &mockContainerRuntime{results: []mockExecResult{{exitCode: 1}}}
+	cfg := model.ReadinessConfig{
+		Interval: "1ms",
+		Timeout:  "10ms",
+		Retries:  2,
+		Probes: []model.ReadinessProbe{
+			{Host: "r1", Target: "10.0.0.2"},
+		},
+	}
+
+	err := WaitForTopologyReady(context.Background(), cfg, cr, func(h string) string { return h })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "r1")
+	assert.Len(t, cr.execCalls, 2)
+}
+
+func TestWaitForTopologyReady_StartPeriodIgnoresFailures(t *testing.T) {
+	cr := &mockContainerRuntime{results: []mockExecResult{
+		{exitCode: 1},
+		{exitCode: 1},
+		{exitCode: 0},
+	}}
+	cfg := model.ReadinessConfig{
+		Interval:    "1ms",
+		Timeout:     "10ms",
+		Retries:     1,
+		StartPeriod: "50ms",
+		Probes: []model.ReadinessProbe{
+			{Host: "r1", Target: "10.0.0.2"},
+		},
+	}
+
+	err := WaitForTopologyReady(context.Background(), cfg, cr, func(h string) string { return h })
+	require.NoError(t, err)
+	assert.Len(t, cr.execCalls, 3)
+}
+
+func TestWaitForTopologyReady_TCPProbe(t *testing.T) {
+	cr := &mockContainerRuntime{results: []mockExecResult{{exitCode: 0}}}
+	cfg := model.ReadinessConfig{
+		Probes: []model.ReadinessProbe{
+			{Host: "r1", Kind: model.ReadinessProbeTCP, Target: "10.0.0.2:179"},
+		},
+	}
+
+	err := WaitForTopologyReady(context.Background(), cfg, cr, func(h string) string { return h })
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r1", "nc", "-z", "-w", "2", "10.0.0.2", "179"}, cr.execCalls[0])
+}
+
+func TestWaitForTopologyReady_InvalidInterval(t *testing.T) {
+	cr := &mockContainerRuntime{}
+	cfg := model.ReadinessConfig{
+		Interval: "not-a-duration",
+		Probes:   []model.ReadinessProbe{{Host: "r1", Target: "10.0.0.2"}},
+	}
+
+	err := WaitForTopologyReady(context.Background(), cfg, cr, func(h string) string { return h })
+	assert.Error(t, err)
+}
+
+func TestWaitForTopologyReady_ExecError(t *testing.T) {
+	cr := &mockContainerRuntime{results: []mockExecResult{{err: errors.New("daemon unreachable")}}}
+	cfg := model.ReadinessConfig{
+		Interval: "1ms",
+		Timeout:  "10ms",
+		Retries:  1,
+		Probes:   []model.ReadinessProbe{{Host: "r1", Target: "10.0.0.2"}},
+	}
+
+	err := WaitForTopologyReady(context.Background(), cfg, cr, func(h string) string { return h })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "daemon unreachable")
+}
+
+func TestWaitForTopologyReady_ContextCanceled(t *testing.T) {
+	cr := &mockContainerRuntime{results: []mockExecResult{{exitCode: 1}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg := model.ReadinessConfig{
+		Interval: "50ms",
+		Timeout:  "10ms",
+		Retries:  1000,
+		Probes:   []model.ReadinessProbe{{Host: "r1", Target: "10.0.0.2"}},
+	}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+
+	err := WaitForTopologyReady(ctx, cfg, cr, func(h string) string { return h })
+	require.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ipv4Base is the start of 172.16.0.0/12, the private range generateSubnet
+// carves per-lab subnets out of.
+var ipv4Base = net.IPv4(172, 16, 0, 0).To4()
+
+// ipv4RangeSize is the number of addresses in 172.16.0.0/12.
+const ipv4RangeSize = 1 << 20
+
+// calculateSubnetSize returns the smallest CIDR prefix whose subnet can fit
+// deviceCount devices plus one address for the Docker/containerlab gateway,
+// along with how many usable addresses (the subnet's size minus its network
+// and broadcast addresses) that prefix provides.
+func calculateSubnetSize(deviceCount int) (prefix, usable int) {
+	required := deviceCount + 1
+	for p := 30; p >= 8; p-- {
+		u := (1 << (32 - p)) - 2
+		if u >= required {
+			return p, u
+		}
+	}
+	return 8, (1 << 24) - 2
+}
+
+// extractLabIndex returns the numeric suffix after labName's last
+// underscore (e.g. "baseline_005" -> 5), or 0 if it has none, so that
+// parallel runs of scenarios named like "baseline_NNN" each land on a
+// distinct subnet offset.
+func extractLabIndex(labName string) int {
+	i := strings.LastIndex(labName, "_")
+	if i < 0 || i == len(labName)-1 {
+		return 0
+	}
+	index, err := strconv.Atoi(labName[i+1:])
+	if err != nil {
+		return 0
+	}
+	return index
+}
+
+// generateSubnet picks an IPv4 subnet within 172.16.0.0/12 for labName,
+// sized by calculateSubnetSize to fit deviceCount devices and offset by
+// labName's lab index so parallel runs don't collide. It returns an error
+// if that offset would run past the end of the /12 range.
+func generateSubnet(labName string, deviceCount int) (string, error) {
+	prefix, _ := calculateSubnetSize(deviceCount)
+	blockSize := 1 << (32 - prefix)
+	index := extractLabIndex(labName)
+	offset := index * blockSize
+
+	if offset+blockSize > ipv4RangeSize {
+		return "", fmt.Errorf("lab index %d with /%d subnets exceeds 172.16.0.0/12 range", index, prefix)
+	}
+
+	base := uint32(ipv4Base[0])<<24 | uint32(ipv4Base[1])<<16 | uint32(ipv4Base[2])<<8 | uint32(ipv4Base[3])
+	network := base + uint32(offset)
+	ip := net.IPv4(byte(network>>24), byte(network>>16), byte(network>>8), byte(network))
+
+	return fmt.Sprintf("%s/%d", ip.String(), prefix), nil
+}
+
+// generateIPv6Subnet picks a /64 under the 3fff:172:20::/48 block for
+// labName, keyed off the same lab index generateSubnet uses so the two
+// stay in step for a given lab name.
+func generateIPv6Subnet(labName string) (string, error) {
+	index := extractLabIndex(labName)
+	return fmt.Sprintf("3fff:172:20:%x::/64", index), nil
+}
@@ -0,0 +1,42 @@
+package network
+
+import "context"
+
+// SubnetAllocator picks per-lab IPv4/IPv6 subnets for Deploy and reclaims
+// them in Destroy. NetworkController falls back to an unexported
+// defaultSubnetAllocator (generateSubnet/generateIPv6Subnet, keyed purely
+// off LabName, with no persistence or external-conflict checking) when its
+// Subnets field is left nil; set Subnets to an *ipam.Allocator to persist
+// allocations across scenario runs and avoid colliding with the host's
+// existing Docker networks.
+type SubnetAllocator interface {
+	// Allocate returns the IPv4 and IPv6 subnets labName's deploy should
+	// use, sized to fit deviceCount devices.
+	Allocate(ctx context.Context, labName string, deviceCount int) (ipv4Subnet, ipv6Subnet string, err error)
+	// Release frees whatever Allocate assigned labName, if anything.
+	Release(ctx context.Context, labName string) error
+}
+
+// defaultSubnetAllocator is NetworkController's Subnets fallback: the
+// original generateSubnet/generateIPv6Subnet behavior. It has nothing to
+// release since it never persists an allocation in the first place.
+type defaultSubnetAllocator struct{}
+
+// Allocate derives labName's subnets from generateSubnet/generateIPv6Subnet,
+// the same as Deploy always has.
+func (defaultSubnetAllocator) Allocate(ctx context.Context, labName string, deviceCount int) (ipv4Subnet, ipv6Subnet string, err error) {
+	ipv4Subnet, err = generateSubnet(labName, deviceCount)
+	if err != nil {
+		return "", "", err
+	}
+	ipv6Subnet, err = generateIPv6Subnet(labName)
+	if err != nil {
+		return "", "", err
+	}
+	return ipv4Subnet, ipv6Subnet, nil
+}
+
+// Release is a no-op: generateSubnet/generateIPv6Subnet derive a lab's
+// subnets from its name on every call rather than handing out a finite
+// pool, so there is nothing to free.
+func (defaultSubnetAllocator) Release(ctx context.Context, labName string) error { return nil }
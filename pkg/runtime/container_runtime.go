@@ -0,0 +1,148 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Engine identifies which container engine a ContainerRuntime talks to.
+type Engine string
+
+const (
+	// EngineDocker execs/copies via the Docker Engine SDK. This is the
+	// default.
+	EngineDocker Engine = "docker"
+
+	// EnginePodman execs/copies via the Podman REST API bindings, for
+	// rootless Podman setups where no Docker daemon is available.
+	EnginePodman Engine = "podman"
+)
+
+// DefaultEngine is used when a scenario does not specify one.
+const DefaultEngine = EngineDocker
+
+// ContainerInfo is the result of a ContainerRuntime.Inspect call.
+type ContainerInfo struct {
+	Running bool
+	Image   string
+	// HealthStatus is the container's Docker healthcheck status (e.g.
+	// "healthy", "unhealthy", "starting"), or "" if the container has no
+	// healthcheck configured.
+	HealthStatus string
+}
+
+// CopyOptions configures how CopyTo applies the copied file's owner and
+// permission bits.
+type CopyOptions struct {
+	// Owner, if it parses as "uid:gid" (e.g. "1000:1000", see
+	// ParseNumericOwner), is applied via the copy's tar archive header,
+	// avoiding a separate chown round-trip. A named owner (e.g. "frr:frr")
+	// can't be resolved to a uid/gid without asking the container, so CopyTo
+	// leaves it unapplied — callers must chown separately for those.
+	Owner string
+	// Mode, if set (e.g. "644"), is applied via the tar archive header
+	// instead of a separate chmod round-trip. Empty keeps the source file's
+	// own permission bits.
+	Mode string
+}
+
+// ContainerRuntime abstracts exec/copy/inspect operations against a single
+// container, and the naming convention used to turn a lab name and host
+// into that container's name, across container engines (Docker, Podman).
+// EventExecutor depends on this instead of a specific engine's CLI or SDK.
+type ContainerRuntime interface {
+	// ContainerName returns the container name for host within the lab
+	// labName, e.g. "clab-<labName>-<host>".
+	ContainerName(labName, host string) string
+
+	// Exec runs cmd inside container and returns its stdout, stderr and
+	// exit code.
+	Exec(ctx context.Context, container string, cmd []string, opts ExecOptions) (stdout, stderr string, exitCode int, err error)
+
+	// CopyTo copies the file at srcPath on the host into container at
+	// dstPath, applying opts.Owner/opts.Mode where CopyOptions documents
+	// that it can without a follow-up exec.
+	CopyTo(ctx context.Context, container, srcPath, dstPath string, opts CopyOptions) error
+
+	// CopyFrom copies the file at srcPath inside container to dstPath on
+	// the host.
+	CopyFrom(ctx context.Context, container, srcPath, dstPath string) error
+
+	// StreamExecOutput runs cmd inside container and streams its stdout to
+	// w as it's produced, rather than buffering the full output in memory
+	// like Exec. Suitable for long-running or high-volume commands such as
+	// a live tcpdump capture.
+	StreamExecOutput(ctx context.Context, container string, cmd []string, opts ExecOptions, w io.Writer) error
+
+	// Inspect reports whether container exists, is running, and its
+	// healthcheck status.
+	Inspect(ctx context.Context, container string) (ContainerInfo, error)
+}
+
+// DockerEndpoint configures how to reach the Docker daemon, so that
+// EventExecutor and the pumba chaos client (network.CreateDockerClient) can
+// share one source of truth instead of each hardcoding the local socket.
+// The zero value reaches the local daemon via the environment (DOCKER_HOST,
+// or /var/run/docker.sock), matching the pre-existing behavior.
+type DockerEndpoint struct {
+	// Host is the daemon's URL, e.g. "tcp://host:2376" or "ssh://user@host".
+	// Empty uses the environment.
+	Host string
+	// TLSCACert, TLSCert, TLSKey are each either a filesystem path or the
+	// raw PEM content, mirroring the --tlscacert/--tlscert/--tlskey flags
+	// network.CreateDockerClient already reads.
+	TLSCACert string
+	TLSCert   string
+	TLSKey    string
+	// TLSVerify additionally verifies the daemon's certificate against
+	// TLSCACert. Without it, TLS is still used when cert material is set,
+	// but the daemon's certificate is not verified.
+	TLSVerify bool
+}
+
+// NewContainerRuntime returns the ContainerRuntime implementation for the
+// given engine, reached via the environment (local socket or DOCKER_HOST).
+func NewContainerRuntime(engine Engine) (ContainerRuntime, error) {
+	return NewContainerRuntimeWithEndpoint(engine, DockerEndpoint{})
+}
+
+// NewContainerRuntimeWithEndpoint returns the ContainerRuntime implementation
+// for the given engine, connecting to endpoint instead of the environment
+// default. Podman ignores endpoint and always connects via PODMAN_SOCKET, since
+// TLS/remote support for it hasn't been requested yet.
+func NewContainerRuntimeWithEndpoint(engine Engine, endpoint DockerEndpoint) (ContainerRuntime, error) {
+	switch engine {
+	case "", EngineDocker:
+		return NewDockerRuntimeWithEndpoint(endpoint)
+	case EnginePodman:
+		return NewPodmanRuntime()
+	default:
+		return nil, fmt.Errorf("unknown container engine %q", engine)
+	}
+}
+
+// containerlabName applies the container naming convention containerlab uses
+// regardless of which engine it deploys on top of.
+func containerlabName(labName, host string) string {
+	return "clab-" + labName + "-" + host
+}
+
+// ParseNumericOwner parses owner as "uid:gid" (e.g. "1000:1000"), reporting
+// ok=false if it isn't two colon-separated non-negative integers — as with a
+// named owner like "frr:frr", which CopyOptions.Owner can't resolve to a
+// uid/gid without asking the container.
+func ParseNumericOwner(owner string) (uid, gid int, ok bool) {
+	uidStr, gidStr, found := strings.Cut(owner, ":")
+	if !found {
+		return 0, 0, false
+	}
+	uid, uerr := strconv.Atoi(uidStr)
+	gid, gerr := strconv.Atoi(gidStr)
+	if uerr != nil || gerr != nil || uid < 0 || gid < 0 {
+		return 0, 0, false
+	}
+	return uid, gid, true
+}
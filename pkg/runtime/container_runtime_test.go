@@ -0,0 +1,42 @@
+package runtime
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewContainerRuntime_UnknownEngine(t *testing.T) {
+	_, err := NewContainerRuntime(Engine("lxc"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "lxc")
+}
+
+func TestNewContainerRuntime_DefaultsToDocker(t *testing.T) {
+	rt, err := NewContainerRuntime("")
+	assert.NoError(t, err)
+	assert.IsType(t, &DockerRuntime{}, rt)
+}
+
+func TestNewContainerRuntimeWithEndpoint_PodmanIgnoresEndpoint(t *testing.T) {
+	os.Setenv("PODMAN_SOCKET", "unix:///tmp/does-not-exist.sock")
+	defer os.Unsetenv("PODMAN_SOCKET")
+
+	rt, err := NewContainerRuntimeWithEndpoint(EnginePodman, DockerEndpoint{Host: "tcp://example.com:2376"})
+	assert.NoError(t, err)
+	assert.IsType(t, &PodmanRuntime{}, rt)
+}
+
+func TestParseNumericOwner(t *testing.T) {
+	uid, gid, ok := ParseNumericOwner("1000:1000")
+	assert.True(t, ok)
+	assert.Equal(t, 1000, uid)
+	assert.Equal(t, 1000, gid)
+
+	_, _, ok = ParseNumericOwner("frr:frr")
+	assert.False(t, ok)
+
+	_, _, ok = ParseNumericOwner("1000")
+	assert.False(t, ok)
+}
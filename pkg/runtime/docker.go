@@ -0,0 +1,612 @@
+package runtime
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// ExecOptions configures ExecInContainer.
+type ExecOptions struct {
+	// User runs the command as this user (uid, uid:gid, or username) instead
+	// of the container's default. Empty uses the container default.
+	User string
+}
+
+// DockerClient abstracts the Docker operations EventExecutor needs to run
+// commands and copy files in and out of containers, so that callers pass
+// argv directly to the daemon instead of shelling out to the docker CLI.
+// Implementations can talk to a real daemon or, in tests, a fake.
+type DockerClient interface {
+	// ExecInContainer runs cmd inside container and returns its stdout,
+	// stderr and exit code. A non-zero exit code is reported as an error in
+	// addition to being returned, so callers that only check err still see
+	// the failure.
+	ExecInContainer(ctx context.Context, container string, cmd []string, opts ExecOptions) (stdout, stderr string, exitCode int, err error)
+
+	// CopyToContainer copies the file at srcPath on the host into container
+	// at dstPath. dstPath may name the destination file or, if it ends in
+	// "/", the destination directory. opts.Owner/opts.Mode are applied via
+	// the copy's tar archive header where possible (see CopyOptions).
+	CopyToContainer(ctx context.Context, container, srcPath, dstPath string, opts CopyOptions) error
+
+	// CopyFromContainer copies the file at srcPath inside container to
+	// dstPath on the host. dstPath may name the destination file or, if it
+	// ends in "/", the destination directory.
+	CopyFromContainer(ctx context.Context, container, srcPath, dstPath string) error
+
+	// CopyTreeFromContainer copies the directory at srcPath inside container
+	// to dstDir on the host, preserving srcPath's internal structure.
+	CopyTreeFromContainer(ctx context.Context, container, srcPath, dstDir string) error
+
+	// StreamExecOutput runs cmd inside container and streams its stdout to
+	// w as it's produced, rather than buffering the full output in memory
+	// like ExecInContainer. It blocks until cmd exits, ctx is canceled (in
+	// which case the exec is detached and w stops receiving data), or the
+	// stream otherwise ends. Suitable for long-running or high-volume
+	// commands such as a live tcpdump capture.
+	StreamExecOutput(ctx context.Context, container string, cmd []string, opts ExecOptions, w io.Writer) error
+}
+
+// dockerExecClient is the subset of the Docker Engine SDK SDKDockerClient
+// depends on. It exists so tests can supply a fake without a real daemon.
+type dockerExecClient interface {
+	ContainerExecCreate(ctx context.Context, container string, config types.ExecConfig) (types.IDResponse, error)
+	ContainerExecAttach(ctx context.Context, execID string, config types.ExecStartCheck) (types.HijackedResponse, error)
+	ContainerExecInspect(ctx context.Context, execID string) (types.ContainerExecInspect, error)
+	CopyToContainer(ctx context.Context, container, path string, content io.Reader, options types.CopyToContainerOptions) error
+	CopyFromContainer(ctx context.Context, container, srcPath string) (io.ReadCloser, types.ContainerPathStat, error)
+	ContainerInspect(ctx context.Context, container string) (types.ContainerJSON, error)
+	ContainerLogs(ctx context.Context, container string, options types.ContainerLogsOptions) (io.ReadCloser, error)
+}
+
+// newDockerExecClient constructs a Docker client from the environment
+// (DOCKER_HOST, DOCKER_TLS_VERIFY, DOCKER_CERT_PATH). It is a var so tests
+// can substitute a fake dockerExecClient.
+var newDockerExecClient = func() (dockerExecClient, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	return cli, nil
+}
+
+// newDockerExecClientWithEndpoint constructs a Docker client from endpoint
+// instead of the environment. It is a var so tests can substitute a fake
+// dockerExecClient.
+var newDockerExecClientWithEndpoint = func(endpoint DockerEndpoint) (dockerExecClient, error) {
+	opts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
+	if endpoint.Host != "" {
+		opts = append(opts, client.WithHost(endpoint.Host))
+	}
+	if endpoint.TLSCACert != "" || endpoint.TLSCert != "" {
+		tlsCfg, err := BuildTLSConfig(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, client.WithHTTPClient(&http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}}))
+	}
+
+	cli, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Docker client for %s: %w", endpoint.Host, err)
+	}
+	return cli, nil
+}
+
+// BuildTLSConfig builds a tls.Config from endpoint's TLS material, for
+// mutually-authenticated TLS against a remote Docker daemon. network.CreateDockerClient
+// builds the same thing from its DockerOptions via network.NewDockerEndpoint, so
+// the cert-loading logic lives here once rather than in both packages.
+func BuildTLSConfig(endpoint DockerEndpoint) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		InsecureSkipVerify: !endpoint.TLSVerify, //nolint:gosec
+	}
+
+	if endpoint.TLSCACert != "" {
+		caCert, err := readPEMOrPath(endpoint.TLSCACert)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA certificate: %w", err)
+		}
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM(caCert)
+		tlsCfg.RootCAs = caCertPool
+	}
+
+	if endpoint.TLSCert != "" && endpoint.TLSKey != "" {
+		cert, err := loadX509KeyPairOrPEM(endpoint.TLSCert, endpoint.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
+
+// readPEMOrPath returns certOrPath's content as-is if it looks like PEM
+// content, or reads it as a filesystem path otherwise.
+func readPEMOrPath(certOrPath string) ([]byte, error) {
+	if strings.HasPrefix(certOrPath, "/") {
+		return os.ReadFile(certOrPath)
+	}
+	return []byte(certOrPath), nil
+}
+
+// loadX509KeyPairOrPEM loads a client certificate/key pair from filesystem
+// paths, or from raw PEM content if certOrPath/keyOrPath don't look like
+// paths.
+func loadX509KeyPairOrPEM(certOrPath, keyOrPath string) (tls.Certificate, error) {
+	if strings.HasPrefix(certOrPath, "/") && strings.HasPrefix(keyOrPath, "/") {
+		return tls.LoadX509KeyPair(certOrPath, keyOrPath)
+	}
+	return tls.X509KeyPair([]byte(certOrPath), []byte(keyOrPath))
+}
+
+// SDKDockerClient is the production DockerClient, backed by the Docker
+// Engine Go SDK.
+type SDKDockerClient struct {
+	cli dockerExecClient
+}
+
+// NewDockerClient creates an SDKDockerClient backed by the Docker daemon
+// resolved from the environment.
+func NewDockerClient() (*SDKDockerClient, error) {
+	cli, err := newDockerExecClient()
+	if err != nil {
+		return nil, err
+	}
+	return &SDKDockerClient{cli: cli}, nil
+}
+
+// NewDockerClientWithEndpoint creates an SDKDockerClient connected to
+// endpoint instead of the environment default, for a remote or
+// mutually-TLS-authenticated daemon.
+func NewDockerClientWithEndpoint(endpoint DockerEndpoint) (*SDKDockerClient, error) {
+	cli, err := newDockerExecClientWithEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &SDKDockerClient{cli: cli}, nil
+}
+
+// ExecInContainer runs cmd inside container via the Docker exec API.
+func (d *SDKDockerClient) ExecInContainer(ctx context.Context, container string, cmd []string, opts ExecOptions) (string, string, int, error) {
+	execID, err := d.cli.ContainerExecCreate(ctx, container, types.ExecConfig{
+		Cmd:          cmd,
+		User:         opts.User,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to create exec for %s: %w", container, err)
+	}
+
+	resp, err := d.cli.ContainerExecAttach(ctx, execID.ID, types.ExecStartCheck{})
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to attach exec for %s: %w", container, err)
+	}
+	defer resp.Close()
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, resp.Reader); err != nil && err != io.EOF {
+		return "", "", 0, fmt.Errorf("failed to read exec output from %s: %w", container, err)
+	}
+
+	inspect, err := d.cli.ContainerExecInspect(ctx, execID.ID)
+	if err != nil {
+		return stdout.String(), stderr.String(), 0, fmt.Errorf("failed to inspect exec for %s: %w", container, err)
+	}
+
+	if inspect.ExitCode != 0 {
+		return stdout.String(), stderr.String(), inspect.ExitCode, fmt.Errorf("command %q in %s exited with code %d: %s",
+			strings.Join(cmd, " "), container, inspect.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), stderr.String(), inspect.ExitCode, nil
+}
+
+// execConn adapts a HijackedResponse into an io.ReadWriteCloser for
+// StartInteractive: writes go straight to the exec's stdin, and reads come
+// from a pipe fed by a background stdcopy.StdCopy goroutine, since the
+// HijackedResponse multiplexes stdout/stderr over one stream the same way
+// ExecInContainer's one-shot read does (stderr is discarded here; a
+// long-lived interactive session like events.VtyshSession has no per-call
+// boundary to attribute stderr output to).
+type execConn struct {
+	resp types.HijackedResponse
+	pr   *io.PipeReader
+}
+
+func (c *execConn) Read(p []byte) (int, error)  { return c.pr.Read(p) }
+func (c *execConn) Write(p []byte) (int, error) { return c.resp.Conn.Write(p) }
+func (c *execConn) Close() error {
+	c.resp.Close()
+	return nil
+}
+
+// StartInteractive runs cmd inside container with stdin attached and
+// leaves it running, for a long-lived interactive session (see
+// events.VtyshSession) instead of one process per command. Close the
+// returned connection to terminate it.
+func (d *SDKDockerClient) StartInteractive(ctx context.Context, container string, cmd []string) (io.ReadWriteCloser, error) {
+	execID, err := d.cli.ContainerExecCreate(ctx, container, types.ExecConfig{
+		Cmd:          cmd,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create interactive exec for %s: %w", container, err)
+	}
+
+	resp, err := d.cli.ContainerExecAttach(ctx, execID.ID, types.ExecStartCheck{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach interactive exec for %s: %w", container, err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, copyErr := stdcopy.StdCopy(pw, io.Discard, resp.Reader)
+		pw.CloseWithError(copyErr)
+	}()
+
+	return &execConn{resp: resp, pr: pr}, nil
+}
+
+// StreamExecOutput runs cmd inside container and copies its stdout to w as
+// it arrives via stdcopy.StdCopy, instead of buffering the whole output like
+// ExecInContainer does. Canceling ctx closes the exec's attached connection,
+// which stops the copy (and, since the remote command's stdout pipe then
+// breaks, usually terminates it too).
+func (d *SDKDockerClient) StreamExecOutput(ctx context.Context, container string, cmd []string, opts ExecOptions, w io.Writer) error {
+	execID, err := d.cli.ContainerExecCreate(ctx, container, types.ExecConfig{
+		Cmd:          cmd,
+		User:         opts.User,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create exec for %s: %w", container, err)
+	}
+
+	resp, err := d.cli.ContainerExecAttach(ctx, execID.ID, types.ExecStartCheck{})
+	if err != nil {
+		return fmt.Errorf("failed to attach exec for %s: %w", container, err)
+	}
+	defer resp.Close()
+
+	copyDone := make(chan error, 1)
+	var stderr bytes.Buffer
+	go func() {
+		_, err := stdcopy.StdCopy(w, &stderr, resp.Reader)
+		copyDone <- err
+	}()
+
+	select {
+	case <-ctx.Done():
+		// Close the attached connection so StdCopy's read unblocks and the
+		// copy goroutine exits before we return, rather than leaving it
+		// writing to w concurrently with the caller reusing or closing w.
+		resp.Close()
+		<-copyDone
+		return nil
+	case err := <-copyDone:
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("failed to stream exec output from %s: %w", container, err)
+		}
+	}
+
+	inspect, err := d.cli.ContainerExecInspect(ctx, execID.ID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect exec for %s: %w", container, err)
+	}
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("command %q in %s exited with code %d: %s",
+			strings.Join(cmd, " "), container, inspect.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// CopyToContainer tars srcPath and streams it into container at dstPath via
+// the Docker copy API, setting the archive entry's mode (and, for a numeric
+// owner, uid/gid) from opts so the daemon applies them as part of the copy
+// instead of a follow-up chmod/chown exec.
+func (d *SDKDockerClient) CopyToContainer(ctx context.Context, container, srcPath, dstPath string, opts CopyOptions) error {
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", srcPath, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("copying directories to a container is not supported: %s", srcPath)
+	}
+
+	data, err := os.ReadFile(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", srcPath, err)
+	}
+
+	mode := info.Mode()
+	if opts.Mode != "" {
+		parsed, err := strconv.ParseUint(opts.Mode, 8, 32)
+		if err != nil {
+			return fmt.Errorf("invalid mode %q: %w", opts.Mode, err)
+		}
+		mode = os.FileMode(parsed)
+	}
+
+	var uid, gid int
+	if opts.Owner != "" {
+		uid, gid, _ = ParseNumericOwner(opts.Owner)
+	}
+
+	dstDir, nameInArchive := splitContainerDest(dstPath, filepath.Base(srcPath))
+
+	tarball, err := tarSingleFile(nameInArchive, data, mode, uid, gid)
+	if err != nil {
+		return fmt.Errorf("failed to build tar archive for %s: %w", srcPath, err)
+	}
+
+	if err := d.cli.CopyToContainer(ctx, container, dstDir, tarball, types.CopyToContainerOptions{}); err != nil {
+		return fmt.Errorf("failed to copy %s to %s:%s: %w", srcPath, container, dstPath, err)
+	}
+	return nil
+}
+
+// CopyFromContainer streams srcPath out of container via the Docker copy
+// API and writes it to dstPath on the host.
+func (d *SDKDockerClient) CopyFromContainer(ctx context.Context, container, srcPath, dstPath string) error {
+	reader, _, err := d.cli.CopyFromContainer(ctx, container, srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s:%s from container: %w", container, srcPath, err)
+	}
+	defer reader.Close()
+
+	hostDir, hostName := splitContainerDest(dstPath, filepath.Base(srcPath))
+	if err := os.MkdirAll(hostDir, 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory %s: %w", hostDir, err)
+	}
+
+	if err := untarSingleFile(reader, filepath.Join(hostDir, hostName)); err != nil {
+		return fmt.Errorf("failed to extract %s from %s: %w", srcPath, container, err)
+	}
+	return nil
+}
+
+// CopyTreeFromContainer streams the directory at srcPath out of container
+// via the Docker copy API and extracts it under dstDir, mirroring the tree
+// docker cp would leave behind (dstDir/<base of srcPath>/...).
+func (d *SDKDockerClient) CopyTreeFromContainer(ctx context.Context, container, srcPath, dstDir string) error {
+	reader, _, err := d.cli.CopyFromContainer(ctx, container, srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s:%s from container: %w", container, srcPath, err)
+	}
+	defer reader.Close()
+
+	if err := os.MkdirAll(dstDir, 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory %s: %w", dstDir, err)
+	}
+
+	if err := untarAll(reader, dstDir); err != nil {
+		return fmt.Errorf("failed to extract %s from %s: %w", srcPath, container, err)
+	}
+	return nil
+}
+
+// Inspect reports whether container exists, is running, and its
+// healthcheck status.
+func (d *SDKDockerClient) Inspect(ctx context.Context, container string) (ContainerInfo, error) {
+	info, err := d.cli.ContainerInspect(ctx, container)
+	if err != nil {
+		return ContainerInfo{}, fmt.Errorf("failed to inspect %s: %w", container, err)
+	}
+
+	running := info.State != nil && info.State.Running
+	var healthStatus string
+	if info.State != nil && info.State.Health != nil {
+		healthStatus = info.State.Health.Status
+	}
+	return ContainerInfo{Running: running, Image: info.Image, HealthStatus: healthStatus}, nil
+}
+
+// ContainerPID returns container's PID on the host, for callers that need to
+// reach into its network namespace directly (e.g. /proc/<pid>/ns/net)
+// instead of going through docker exec.
+func (d *SDKDockerClient) ContainerPID(ctx context.Context, container string) (int, error) {
+	info, err := d.cli.ContainerInspect(ctx, container)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect %s: %w", container, err)
+	}
+	if info.State == nil || info.State.Pid == 0 {
+		return 0, fmt.Errorf("container %s has no running process", container)
+	}
+	return info.State.Pid, nil
+}
+
+// StreamLogs follows container's stdout/stderr via the Docker logs API,
+// copying its demultiplexed output to w as it's produced until ctx is
+// canceled or the container stops logging. It's the stdout/stderr half of
+// LogTailer; the other half, tailing files inside the container, instead
+// goes through StreamExecOutput so it works on any ContainerRuntime rather
+// than just Docker.
+func (d *SDKDockerClient) StreamLogs(ctx context.Context, container string, w io.Writer) error {
+	reader, err := d.cli.ContainerLogs(ctx, container, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream logs for %s: %w", container, err)
+	}
+	defer reader.Close()
+
+	if _, err := stdcopy.StdCopy(w, w, reader); err != nil && err != io.EOF {
+		return fmt.Errorf("failed to read logs from %s: %w", container, err)
+	}
+	return nil
+}
+
+// DockerRuntime is the ContainerRuntime implementation for plain Docker (and
+// containerlab deployed on top of it), naming containers "clab-<lab>-<host>".
+type DockerRuntime struct {
+	*SDKDockerClient
+}
+
+// NewDockerRuntime creates a DockerRuntime backed by the Docker daemon
+// resolved from the environment.
+func NewDockerRuntime() (*DockerRuntime, error) {
+	cli, err := NewDockerClient()
+	if err != nil {
+		return nil, err
+	}
+	return &DockerRuntime{SDKDockerClient: cli}, nil
+}
+
+// NewDockerRuntimeWithEndpoint creates a DockerRuntime connected to endpoint
+// instead of the environment default, so scenarios can target a remote
+// containerlab host over TLS (tcp://host:2376, ssh://user@host) the same way
+// network.CreateDockerClient already does for the pumba chaos client.
+func NewDockerRuntimeWithEndpoint(endpoint DockerEndpoint) (*DockerRuntime, error) {
+	cli, err := NewDockerClientWithEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &DockerRuntime{SDKDockerClient: cli}, nil
+}
+
+// ContainerName returns the containerlab container name for host.
+func (r *DockerRuntime) ContainerName(labName, host string) string {
+	return containerlabName(labName, host)
+}
+
+// Exec delegates to SDKDockerClient.ExecInContainer, adapting it to the
+// ContainerRuntime method name.
+func (r *DockerRuntime) Exec(ctx context.Context, container string, cmd []string, opts ExecOptions) (string, string, int, error) {
+	return r.ExecInContainer(ctx, container, cmd, opts)
+}
+
+// CopyTo delegates to SDKDockerClient.CopyToContainer, adapting it to the
+// ContainerRuntime method name.
+func (r *DockerRuntime) CopyTo(ctx context.Context, container, srcPath, dstPath string, opts CopyOptions) error {
+	return r.CopyToContainer(ctx, container, srcPath, dstPath, opts)
+}
+
+// CopyFrom delegates to SDKDockerClient.CopyFromContainer, adapting it to
+// the ContainerRuntime method name.
+func (r *DockerRuntime) CopyFrom(ctx context.Context, container, srcPath, dstPath string) error {
+	return r.CopyFromContainer(ctx, container, srcPath, dstPath)
+}
+
+// splitContainerDest splits a "maybe-a-directory" destination path into its
+// containing directory and the file name to use within it. A path ending
+// in "/" is treated as a directory and defaultName is used for the file;
+// otherwise the path's own base name is used.
+func splitContainerDest(dstPath, defaultName string) (dir, name string) {
+	if strings.HasSuffix(dstPath, "/") {
+		return strings.TrimSuffix(dstPath, "/"), defaultName
+	}
+	return filepath.Dir(dstPath), filepath.Base(dstPath)
+}
+
+// tarSingleFile builds a tar archive containing a single regular file named
+// name with the given content, mode, and owner, as required by the Docker
+// CopyToContainer API.
+func tarSingleFile(name string, content []byte, mode os.FileMode, uid, gid int) (io.Reader, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	hdr := &tar.Header{
+		Name: name,
+		Mode: int64(mode.Perm()),
+		Size: int64(len(content)),
+		Uid:  uid,
+		Gid:  gid,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write(content); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// untarSingleFile reads a tar stream as returned by CopyFromContainer and
+// writes the content of its first regular file entry to dstPath.
+func untarSingleFile(r io.Reader, dstPath string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return fmt.Errorf("no file found in archive")
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		out, err := os.Create(dstPath)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+
+		if _, err := io.Copy(out, tr); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+// untarAll reads a tar stream as returned by CopyFromContainer and extracts
+// every regular file entry into dstDir, creating parent directories as
+// needed.
+func untarAll(r io.Reader, dstDir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		dstPath := filepath.Join(dstDir, hdr.Name)
+		if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+			return err
+		}
+
+		out, err := os.Create(dstPath)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, tr); err != nil {
+			out.Close()
+			return err
+		}
+		out.Close()
+	}
+}
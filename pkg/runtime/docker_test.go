@@ -0,0 +1,427 @@
+package runtime
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDockerExecClient is a minimal in-memory stand-in for dockerExecClient.
+type fakeDockerExecClient struct {
+	execCmd      []string
+	execInspect  types.ContainerExecInspect
+	stdout       string
+	stderr       string
+	createErr    error
+	attachErr    error
+	inspectErr   error
+	copyToCalls  []copyToCall
+	copyToErr    error
+	copyFromPath string
+	copyFromTar  []byte
+	copyFromErr  error
+
+	containerJSON       types.ContainerJSON
+	inspectContainerErr error
+
+	logs    string
+	logsErr error
+}
+
+type copyToCall struct {
+	container string
+	path      string
+	content   []byte
+}
+
+func (f *fakeDockerExecClient) ContainerExecCreate(ctx context.Context, container string, config types.ExecConfig) (types.IDResponse, error) {
+	if f.createErr != nil {
+		return types.IDResponse{}, f.createErr
+	}
+	f.execCmd = config.Cmd
+	return types.IDResponse{ID: "exec1"}, nil
+}
+
+func (f *fakeDockerExecClient) ContainerExecAttach(ctx context.Context, execID string, config types.ExecStartCheck) (types.HijackedResponse, error) {
+	if f.attachErr != nil {
+		return types.HijackedResponse{}, f.attachErr
+	}
+	conn, _ := net.Pipe()
+	return types.HijackedResponse{
+		Conn:   conn,
+		Reader: bufio.NewReader(bytes.NewReader(encodeStdcopyFrames(f.stdout, f.stderr))),
+	}, nil
+}
+
+func (f *fakeDockerExecClient) ContainerExecInspect(ctx context.Context, execID string) (types.ContainerExecInspect, error) {
+	if f.inspectErr != nil {
+		return types.ContainerExecInspect{}, f.inspectErr
+	}
+	return f.execInspect, nil
+}
+
+func (f *fakeDockerExecClient) CopyToContainer(ctx context.Context, container, path string, content io.Reader, options types.CopyToContainerOptions) error {
+	if f.copyToErr != nil {
+		return f.copyToErr
+	}
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	f.copyToCalls = append(f.copyToCalls, copyToCall{container: container, path: path, content: data})
+	return nil
+}
+
+func (f *fakeDockerExecClient) CopyFromContainer(ctx context.Context, container, srcPath string) (io.ReadCloser, types.ContainerPathStat, error) {
+	if f.copyFromErr != nil {
+		return nil, types.ContainerPathStat{}, f.copyFromErr
+	}
+	f.copyFromPath = srcPath
+	return io.NopCloser(bytes.NewReader(f.copyFromTar)), types.ContainerPathStat{}, nil
+}
+
+func (f *fakeDockerExecClient) ContainerInspect(ctx context.Context, container string) (types.ContainerJSON, error) {
+	if f.inspectContainerErr != nil {
+		return types.ContainerJSON{}, f.inspectContainerErr
+	}
+	return f.containerJSON, nil
+}
+
+func (f *fakeDockerExecClient) ContainerLogs(ctx context.Context, container string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+	if f.logsErr != nil {
+		return nil, f.logsErr
+	}
+	return io.NopCloser(bytes.NewReader(encodeStdcopyFrames(f.logs, ""))), nil
+}
+
+// encodeStdcopyFrames builds a Docker multiplexed exec stream (stdcopy
+// format) carrying a single stdout frame and a single stderr frame.
+func encodeStdcopyFrames(stdout, stderr string) []byte {
+	var buf bytes.Buffer
+	writeFrame(&buf, 1, stdout)
+	writeFrame(&buf, 2, stderr)
+	return buf.Bytes()
+}
+
+func writeFrame(buf *bytes.Buffer, streamType byte, payload string) {
+	if payload == "" {
+		return
+	}
+	header := make([]byte, 8)
+	header[0] = streamType
+	binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+	buf.Write(header)
+	buf.WriteString(payload)
+}
+
+// tarWithFile builds a tar archive containing a single file, for faking
+// CopyFromContainer responses.
+func tarWithFile(name, content string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}
+	if err := tw.WriteHeader(hdr); err != nil {
+		panic(err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		panic(err)
+	}
+	if err := tw.Close(); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+func withFakeDockerExecClient(t *testing.T, fake *fakeDockerExecClient) {
+	t.Helper()
+	original := newDockerExecClient
+	newDockerExecClient = func() (dockerExecClient, error) { return fake, nil }
+	t.Cleanup(func() { newDockerExecClient = original })
+}
+
+func TestSDKDockerClient_ExecInContainer_Success(t *testing.T) {
+	fake := &fakeDockerExecClient{
+		stdout:      "hello\n",
+		execInspect: types.ContainerExecInspect{ExitCode: 0},
+	}
+	withFakeDockerExecClient(t, fake)
+
+	cli, err := NewDockerClient()
+	require.NoError(t, err)
+
+	stdout, _, exitCode, err := cli.ExecInContainer(context.Background(), "clab-test-r1", []string{"echo", "hello"}, ExecOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hello\n", stdout)
+	assert.Equal(t, 0, exitCode)
+	assert.Equal(t, []string{"echo", "hello"}, fake.execCmd)
+}
+
+func TestSDKDockerClient_ExecInContainer_NonZeroExit(t *testing.T) {
+	fake := &fakeDockerExecClient{
+		stderr:      "boom\n",
+		execInspect: types.ContainerExecInspect{ExitCode: 1},
+	}
+	withFakeDockerExecClient(t, fake)
+
+	cli, err := NewDockerClient()
+	require.NoError(t, err)
+
+	_, stderr, exitCode, err := cli.ExecInContainer(context.Background(), "clab-test-r1", []string{"false"}, ExecOptions{})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, exitCode)
+	assert.Equal(t, "boom\n", stderr)
+	assert.Contains(t, err.Error(), "exited with code 1")
+}
+
+func TestSDKDockerClient_ExecInContainer_CreateError(t *testing.T) {
+	fake := &fakeDockerExecClient{createErr: errors.New("no such container")}
+	withFakeDockerExecClient(t, fake)
+
+	cli, err := NewDockerClient()
+	require.NoError(t, err)
+
+	_, _, _, err = cli.ExecInContainer(context.Background(), "clab-test-r1", []string{"true"}, ExecOptions{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no such container")
+}
+
+func TestSDKDockerClient_CopyToContainer(t *testing.T) {
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "config.conf")
+	require.NoError(t, os.WriteFile(srcPath, []byte("interface eth0"), 0644))
+
+	fake := &fakeDockerExecClient{}
+	withFakeDockerExecClient(t, fake)
+
+	cli, err := NewDockerClient()
+	require.NoError(t, err)
+
+	err = cli.CopyToContainer(context.Background(), "clab-test-r1", srcPath, "/etc/frr/", CopyOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, fake.copyToCalls, 1)
+	assert.Equal(t, "clab-test-r1", fake.copyToCalls[0].container)
+	assert.Equal(t, "/etc/frr", fake.copyToCalls[0].path)
+
+	tr := tar.NewReader(bytes.NewReader(fake.copyToCalls[0].content))
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "config.conf", hdr.Name)
+	content, err := io.ReadAll(tr)
+	require.NoError(t, err)
+	assert.Equal(t, "interface eth0", string(content))
+}
+
+func TestSDKDockerClient_CopyToContainer_AppliesModeAndNumericOwner(t *testing.T) {
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "config.conf")
+	require.NoError(t, os.WriteFile(srcPath, []byte("interface eth0"), 0644))
+
+	fake := &fakeDockerExecClient{}
+	withFakeDockerExecClient(t, fake)
+
+	cli, err := NewDockerClient()
+	require.NoError(t, err)
+
+	err = cli.CopyToContainer(context.Background(), "clab-test-r1", srcPath, "/etc/frr/", CopyOptions{Mode: "600", Owner: "1000:1000"})
+	require.NoError(t, err)
+
+	require.Len(t, fake.copyToCalls, 1)
+	tr := tar.NewReader(bytes.NewReader(fake.copyToCalls[0].content))
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0600), hdr.Mode)
+	assert.Equal(t, 1000, hdr.Uid)
+	assert.Equal(t, 1000, hdr.Gid)
+}
+
+func TestSDKDockerClient_CopyToContainer_NamedOwnerLeavesUidGidUnset(t *testing.T) {
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "config.conf")
+	require.NoError(t, os.WriteFile(srcPath, []byte("interface eth0"), 0644))
+
+	fake := &fakeDockerExecClient{}
+	withFakeDockerExecClient(t, fake)
+
+	cli, err := NewDockerClient()
+	require.NoError(t, err)
+
+	err = cli.CopyToContainer(context.Background(), "clab-test-r1", srcPath, "/etc/frr/", CopyOptions{Owner: "frr:frr"})
+	require.NoError(t, err)
+
+	require.Len(t, fake.copyToCalls, 1)
+	tr := tar.NewReader(bytes.NewReader(fake.copyToCalls[0].content))
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 0, hdr.Uid)
+	assert.Equal(t, 0, hdr.Gid)
+}
+
+func TestSDKDockerClient_CopyFromContainer(t *testing.T) {
+	dir := t.TempDir()
+	dstPath := filepath.Join(dir, "frr.log")
+
+	fake := &fakeDockerExecClient{copyFromTar: tarWithFile("frr.log", "log line 1\n")}
+	withFakeDockerExecClient(t, fake)
+
+	cli, err := NewDockerClient()
+	require.NoError(t, err)
+
+	err = cli.CopyFromContainer(context.Background(), "clab-test-r1", "/var/log/frr/frr.log", dstPath)
+	require.NoError(t, err)
+	assert.Equal(t, "/var/log/frr/frr.log", fake.copyFromPath)
+
+	content, err := os.ReadFile(dstPath)
+	require.NoError(t, err)
+	assert.Equal(t, "log line 1\n", string(content))
+}
+
+func TestSDKDockerClient_Inspect(t *testing.T) {
+	fake := &fakeDockerExecClient{
+		containerJSON: types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{
+				Image: "frrouting/frr:latest",
+				State: &types.ContainerState{Running: true},
+			},
+		},
+	}
+	withFakeDockerExecClient(t, fake)
+
+	cli, err := NewDockerClient()
+	require.NoError(t, err)
+
+	info, err := cli.Inspect(context.Background(), "clab-test-r1")
+	require.NoError(t, err)
+	assert.True(t, info.Running)
+	assert.Equal(t, "frrouting/frr:latest", info.Image)
+	assert.Equal(t, "", info.HealthStatus)
+}
+
+func TestSDKDockerClient_Inspect_HealthStatus(t *testing.T) {
+	fake := &fakeDockerExecClient{
+		containerJSON: types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{
+				Image: "frrouting/frr:latest",
+				State: &types.ContainerState{
+					Running: true,
+					Health:  &types.Health{Status: "healthy"},
+				},
+			},
+		},
+	}
+	withFakeDockerExecClient(t, fake)
+
+	cli, err := NewDockerClient()
+	require.NoError(t, err)
+
+	info, err := cli.Inspect(context.Background(), "clab-test-r1")
+	require.NoError(t, err)
+	assert.Equal(t, "healthy", info.HealthStatus)
+}
+
+func TestSDKDockerClient_StreamLogs(t *testing.T) {
+	fake := &fakeDockerExecClient{logs: "booting FRR\nBGP neighbor up\n"}
+	withFakeDockerExecClient(t, fake)
+
+	cli, err := NewDockerClient()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	err = cli.StreamLogs(context.Background(), "clab-test-r1", &out)
+	require.NoError(t, err)
+	assert.Equal(t, "booting FRR\nBGP neighbor up\n", out.String())
+}
+
+func TestSDKDockerClient_StreamLogs_Error(t *testing.T) {
+	fake := &fakeDockerExecClient{logsErr: errors.New("no such container")}
+	withFakeDockerExecClient(t, fake)
+
+	cli, err := NewDockerClient()
+	require.NoError(t, err)
+
+	err = cli.StreamLogs(context.Background(), "clab-test-r1", &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestSplitContainerDest(t *testing.T) {
+	dir, name := splitContainerDest("/etc/frr/", "config.conf")
+	assert.Equal(t, "/etc/frr", dir)
+	assert.Equal(t, "config.conf", name)
+
+	dir, name = splitContainerDest("/etc/frr/frr.conf", "config.conf")
+	assert.Equal(t, "/etc/frr", dir)
+	assert.Equal(t, "frr.conf", name)
+}
+
+func TestBuildTLSConfig_NoCerts(t *testing.T) {
+	tlsCfg, err := BuildTLSConfig(DockerEndpoint{})
+	require.NoError(t, err)
+	assert.True(t, tlsCfg.InsecureSkipVerify)
+}
+
+func TestBuildTLSConfig_VerifyLoadsCACertAndClientCert(t *testing.T) {
+	certPEM, keyPEM := generateTestCertPEM(t)
+
+	tlsCfg, err := BuildTLSConfig(DockerEndpoint{
+		TLSCACert: string(certPEM),
+		TLSCert:   string(certPEM),
+		TLSKey:    string(keyPEM),
+		TLSVerify: true,
+	})
+	require.NoError(t, err)
+	assert.False(t, tlsCfg.InsecureSkipVerify)
+	require.Len(t, tlsCfg.Certificates, 1)
+	assert.NotNil(t, tlsCfg.RootCAs)
+}
+
+func TestNewDockerClientWithEndpoint_InvalidTLSMaterial(t *testing.T) {
+	_, err := NewDockerClientWithEndpoint(DockerEndpoint{
+		TLSCert: "not a valid cert",
+		TLSKey:  "not a valid key",
+	})
+	assert.Error(t, err)
+}
+
+// generateTestCertPEM creates a throwaway self-signed certificate/key pair
+// for exercising BuildTLSConfig's PEM-parsing paths, good enough as both a
+// "CA" and a "client cert" since the test never does a real TLS handshake.
+func generateTestCertPEM(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "netroub-test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPEM, keyPEM
+}
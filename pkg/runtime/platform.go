@@ -0,0 +1,52 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedPlatform is the sentinel error wrapped by
+// UnsupportedPlatformError, so callers can check
+// errors.Is(err, runtime.ErrUnsupportedPlatform) without caring which
+// operation failed.
+var ErrUnsupportedPlatform = errors.New("not supported on this platform")
+
+// UnsupportedPlatformError reports that Op needs a platform feature (sudo,
+// containerlab) this build's target OS doesn't provide. NewPrivilegeElevator
+// and NewContainerBackend return one on every platform but Linux.
+type UnsupportedPlatformError struct {
+	Op string
+}
+
+func (e *UnsupportedPlatformError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Op, ErrUnsupportedPlatform)
+}
+
+func (e *UnsupportedPlatformError) Unwrap() error { return ErrUnsupportedPlatform }
+
+// PrivilegeElevator prepends whatever prefix a platform needs to run a
+// command with elevated privileges (sudo, on Linux) ahead of a
+// CommandRunner invocation, so callers that need root (containerlab, a
+// "docker exec" run as another user) don't hardcode "sudo" themselves.
+type PrivilegeElevator interface {
+	// Elevate returns the name and args CommandRunner should invoke in
+	// order to run name/args with elevated privileges.
+	Elevate(name string, args ...string) (elevatedName string, elevatedArgs []string)
+}
+
+// ContainerBackend abstracts bringing a containerlab topology up and down.
+// It is the OS-level counterpart to ContainerRuntime's per-container
+// exec/copy/inspect operations: network.EmulateNetwork/DestroyNetwork go
+// through it instead of shelling out to "containerlab" directly, so they at
+// least build — and fail with a clear ErrUnsupportedPlatform instead of a
+// missing-binary error — on platforms containerlab itself doesn't support.
+type ContainerBackend interface {
+	// Deploy runs containerlab deploy for the topology at topoPath, naming
+	// the lab labName (empty lets containerlab derive one from topoPath),
+	// and returns its combined output.
+	Deploy(topoPath, labName string) (output string, err error)
+
+	// Destroy runs containerlab destroy for the topology at topoPath/labName
+	// and returns its combined output.
+	Destroy(topoPath, labName string) (output string, err error)
+}
@@ -0,0 +1,54 @@
+//go:build linux
+
+package runtime
+
+import "context"
+
+// sudoElevator is the Linux PrivilegeElevator: it prepends "sudo", the only
+// elevation mechanism netroub has ever needed.
+type sudoElevator struct{}
+
+// NewPrivilegeElevator returns the platform's PrivilegeElevator. On Linux
+// this always succeeds and elevates via sudo.
+func NewPrivilegeElevator() (PrivilegeElevator, error) {
+	return sudoElevator{}, nil
+}
+
+func (sudoElevator) Elevate(name string, args ...string) (string, []string) {
+	return "sudo", append([]string{name}, args...)
+}
+
+// clabBackend is the Linux ContainerBackend: sudo+containerlab run through a
+// CommandRunner.
+type clabBackend struct {
+	runner   CommandRunner
+	elevator PrivilegeElevator
+}
+
+// NewContainerBackend returns the platform's ContainerBackend, running
+// containerlab through runner. On Linux this always succeeds.
+func NewContainerBackend(runner CommandRunner) (ContainerBackend, error) {
+	elevator, err := NewPrivilegeElevator()
+	if err != nil {
+		return nil, err
+	}
+	return &clabBackend{runner: runner, elevator: elevator}, nil
+}
+
+func (b *clabBackend) Deploy(topoPath, labName string) (string, error) {
+	return b.run("deploy", topoPath, labName)
+}
+
+func (b *clabBackend) Destroy(topoPath, labName string) (string, error) {
+	return b.run("destroy", topoPath, labName)
+}
+
+func (b *clabBackend) run(subcommand, topoPath, labName string) (string, error) {
+	args := []string{"containerlab", subcommand, "--topo", topoPath}
+	if labName != "" {
+		args = append(args, "--name", labName)
+	}
+	name, elevatedArgs := b.elevator.Elevate(args[0], args[1:]...)
+	output, err := b.runner.Run(context.Background(), name, elevatedArgs...)
+	return string(output), err
+}
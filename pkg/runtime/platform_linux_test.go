@@ -0,0 +1,62 @@
+//go:build linux
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrivilegeElevator_Linux(t *testing.T) {
+	elevator, err := NewPrivilegeElevator()
+	require.NoError(t, err)
+
+	name, args := elevator.Elevate("containerlab", "deploy", "--topo", "topo.yaml")
+	assert.Equal(t, "sudo", name)
+	assert.Equal(t, []string{"containerlab", "deploy", "--topo", "topo.yaml"}, args)
+}
+
+func TestContainerBackend_Linux(t *testing.T) {
+	mock := &mockCommandRunner{output: []byte("deployed")}
+	backend, err := NewContainerBackend(mock)
+	require.NoError(t, err)
+
+	out, err := backend.Deploy("topo.yaml", "test-lab")
+	require.NoError(t, err)
+	assert.Equal(t, "deployed", out)
+	assert.Equal(t, []string{"sudo", "containerlab", "deploy", "--topo", "topo.yaml", "--name", "test-lab"}, mock.calls[0])
+
+	_, err = backend.Destroy("topo.yaml", "test-lab")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sudo", "containerlab", "destroy", "--topo", "topo.yaml", "--name", "test-lab"}, mock.calls[1])
+}
+
+func TestContainerBackend_Linux_Error(t *testing.T) {
+	mock := &mockCommandRunner{err: errors.New("containerlab not found")}
+	backend, err := NewContainerBackend(mock)
+	require.NoError(t, err)
+
+	_, err = backend.Deploy("topo.yaml", "")
+	assert.Error(t, err)
+}
+
+// mockCommandRunner records Run calls for testing CommandRunner-backed code.
+type mockCommandRunner struct {
+	calls  [][]string
+	output []byte
+	err    error
+}
+
+func (m *mockCommandRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	m.calls = append(m.calls, append([]string{name}, args...))
+	return m.output, m.err
+}
+
+func (m *mockCommandRunner) RunDetached(name string, args ...string) error {
+	m.calls = append(m.calls, append([]string{name}, args...))
+	return m.err
+}
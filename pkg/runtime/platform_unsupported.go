@@ -0,0 +1,17 @@
+//go:build !linux
+
+package runtime
+
+// NewPrivilegeElevator reports ErrUnsupportedPlatform: netroub's privilege
+// elevation only targets Linux's sudo so far.
+func NewPrivilegeElevator() (PrivilegeElevator, error) {
+	return nil, &UnsupportedPlatformError{Op: "privilege elevation"}
+}
+
+// NewContainerBackend reports ErrUnsupportedPlatform: containerlab itself
+// only targets Linux network namespaces, so there's no backend to provide
+// here. Callers should fall back to validating the scenario rather than
+// deploying it.
+func NewContainerBackend(runner CommandRunner) (ContainerBackend, error) {
+	return nil, &UnsupportedPlatformError{Op: "containerlab"}
+}
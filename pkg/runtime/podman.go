@@ -0,0 +1,228 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/containers/podman/v4/pkg/api/handlers"
+	"github.com/containers/podman/v4/pkg/bindings"
+	"github.com/containers/podman/v4/pkg/bindings/containers"
+)
+
+// PodmanSocket is the default rootless Podman API socket, used when
+// PODMAN_SOCKET is not set in the environment.
+const PodmanSocket = "unix:///run/user/%d/podman/podman.sock"
+
+// PodmanRuntime is the ContainerRuntime implementation for Podman, speaking
+// the Podman REST API over a Unix domain socket via the podman bindings.
+// It uses the same "clab-<lab>-<host>" naming as Docker, since containerlab
+// names containers the same way regardless of which engine it runs on.
+type PodmanRuntime struct {
+	conn context.Context // a bindings connection context, per the podman bindings convention
+}
+
+// NewPodmanRuntime connects to the Podman API socket resolved from
+// PODMAN_SOCKET, defaulting to the current user's rootless socket.
+func NewPodmanRuntime() (*PodmanRuntime, error) {
+	uri := os.Getenv("PODMAN_SOCKET")
+	if uri == "" {
+		uri = fmt.Sprintf(PodmanSocket, os.Getuid())
+	}
+
+	conn, err := bindings.NewConnection(context.Background(), uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Podman at %s: %w", uri, err)
+	}
+	return &PodmanRuntime{conn: conn}, nil
+}
+
+// ContainerName returns the containerlab container name for host.
+func (r *PodmanRuntime) ContainerName(labName, host string) string {
+	return containerlabName(labName, host)
+}
+
+// Exec runs cmd inside container via the Podman exec API.
+func (r *PodmanRuntime) Exec(ctx context.Context, container string, cmd []string, opts ExecOptions) (string, string, int, error) {
+	execConfig := handlersExecCreateConfig(cmd, opts)
+	sessionID, err := containers.ExecCreate(r.conn, container, &execConfig)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to create exec for %s: %w", container, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	attachOpts := new(containers.ExecStartAndAttachOptions).WithOutputStream(nopWriteCloser{&stdout}).WithErrorStream(nopWriteCloser{&stderr}).WithAttachOutput(true).WithAttachError(true)
+	if err := containers.ExecStartAndAttach(r.conn, sessionID, attachOpts); err != nil {
+		return "", "", 0, fmt.Errorf("failed to attach exec for %s: %w", container, err)
+	}
+
+	inspect, err := containers.ExecInspect(r.conn, sessionID, nil)
+	if err != nil {
+		return stdout.String(), stderr.String(), 0, fmt.Errorf("failed to inspect exec for %s: %w", container, err)
+	}
+
+	if inspect.ExitCode != 0 {
+		return stdout.String(), stderr.String(), inspect.ExitCode, fmt.Errorf("command in %s exited with code %d: %s",
+			container, inspect.ExitCode, stderr.String())
+	}
+	return stdout.String(), stderr.String(), inspect.ExitCode, nil
+}
+
+// CopyTo copies srcPath on the host into container at dstPath, applying
+// opts.Owner/opts.Mode via the tar archive header where CopyOptions
+// documents that it can without a follow-up exec.
+func (r *PodmanRuntime) CopyTo(ctx context.Context, container, srcPath, dstPath string, opts CopyOptions) error {
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", srcPath, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("copying directories to a container is not supported: %s", srcPath)
+	}
+
+	data, err := os.ReadFile(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", srcPath, err)
+	}
+
+	mode := info.Mode()
+	if opts.Mode != "" {
+		parsed, err := strconv.ParseUint(opts.Mode, 8, 32)
+		if err != nil {
+			return fmt.Errorf("invalid mode %q: %w", opts.Mode, err)
+		}
+		mode = os.FileMode(parsed)
+	}
+
+	var uid, gid int
+	if opts.Owner != "" {
+		uid, gid, _ = ParseNumericOwner(opts.Owner)
+	}
+
+	dstDir, nameInArchive := splitContainerDest(dstPath, info.Name())
+	tarball, err := tarSingleFile(nameInArchive, data, mode, uid, gid)
+	if err != nil {
+		return fmt.Errorf("failed to build tar archive for %s: %w", srcPath, err)
+	}
+
+	copyFunc, err := containers.CopyFromArchive(r.conn, container, dstDir, tarball)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s to %s:%s: %w", srcPath, container, dstPath, err)
+	}
+	return copyFunc()
+}
+
+// CopyFrom copies srcPath inside container to dstPath on the host.
+func (r *PodmanRuntime) CopyFrom(ctx context.Context, container, srcPath, dstPath string) error {
+	var tarball bytes.Buffer
+	copyFunc, err := containers.CopyToArchive(r.conn, container, srcPath, &tarball)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s:%s from container: %w", container, srcPath, err)
+	}
+	if err := copyFunc(); err != nil {
+		return fmt.Errorf("failed to copy %s:%s from container: %w", container, srcPath, err)
+	}
+
+	hostDir, hostName := splitContainerDestFallback(dstPath, srcPath)
+	if err := os.MkdirAll(hostDir, 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory %s: %w", hostDir, err)
+	}
+	return untarSingleFile(&tarball, fmt.Sprintf("%s/%s", hostDir, hostName))
+}
+
+// StreamExecOutput runs cmd inside container and streams its stdout to w as
+// it's produced, via the same exec-create/attach path Exec uses, but
+// writing straight to w instead of buffering stdout for a final return.
+// Unlike SDKDockerClient.StreamExecOutput, canceling ctx does not interrupt
+// an in-flight attach: the Podman bindings' ExecStartAndAttach blocks until
+// cmd exits on its own, so callers that need to cut a stream short (e.g.
+// StopPcapCapture) must end it some other way, such as killing the process
+// that's producing it.
+func (r *PodmanRuntime) StreamExecOutput(ctx context.Context, container string, cmd []string, opts ExecOptions, w io.Writer) error {
+	execConfig := handlersExecCreateConfig(cmd, opts)
+	sessionID, err := containers.ExecCreate(r.conn, container, &execConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create exec for %s: %w", container, err)
+	}
+
+	var stderr bytes.Buffer
+	attachOpts := new(containers.ExecStartAndAttachOptions).WithOutputStream(nopWriter{w}).WithErrorStream(nopWriteCloser{&stderr}).WithAttachOutput(true).WithAttachError(true)
+	if err := containers.ExecStartAndAttach(r.conn, sessionID, attachOpts); err != nil {
+		return fmt.Errorf("failed to attach exec for %s: %w", container, err)
+	}
+
+	inspect, err := containers.ExecInspect(r.conn, sessionID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to inspect exec for %s: %w", container, err)
+	}
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("command in %s exited with code %d: %s", container, inspect.ExitCode, stderr.String())
+	}
+	return nil
+}
+
+// Inspect reports whether container exists, is running, and its
+// healthcheck status.
+func (r *PodmanRuntime) Inspect(ctx context.Context, container string) (ContainerInfo, error) {
+	data, err := containers.Inspect(r.conn, container, nil)
+	if err != nil {
+		return ContainerInfo{}, fmt.Errorf("failed to inspect %s: %w", container, err)
+	}
+
+	running := data.State != nil && data.State.Running
+	var healthStatus string
+	if data.State != nil {
+		healthStatus = data.State.Health.Status
+	}
+	return ContainerInfo{Running: running, Image: data.Image, HealthStatus: healthStatus}, nil
+}
+
+// nopWriteCloser adapts a bytes.Buffer to the io.WriteCloser the podman
+// bindings' attach options require.
+type nopWriteCloser struct {
+	*bytes.Buffer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// nopWriter adapts any io.Writer to the io.WriteCloser the podman bindings'
+// attach options require, without closing the underlying writer — callers
+// like NetworkController.SetupTcpdump own w's lifecycle themselves.
+type nopWriter struct{ io.Writer }
+
+func (nopWriter) Close() error { return nil }
+
+// handlersExecCreateConfig builds the podman exec config for cmd/opts. It is
+// split out so Exec stays readable despite the bindings' verbose options.
+func handlersExecCreateConfig(cmd []string, opts ExecOptions) handlers.ExecCreateConfig {
+	return handlers.ExecCreateConfig{
+		Cmd:          cmd,
+		User:         opts.User,
+		AttachStdout: true,
+		AttachStderr: true,
+	}
+}
+
+// splitContainerDestFallback is splitContainerDest with defaultName taken
+// from the base name of a container-side source path rather than a local
+// file, for CopyFrom where there is no os.FileInfo to ask.
+func splitContainerDestFallback(dstPath, srcPath string) (dir, name string) {
+	return splitContainerDest(dstPath, baseName(srcPath))
+}
+
+// baseName returns the final path element of p, tolerating the "/" path
+// separator podman/container paths always use regardless of host OS.
+func baseName(p string) string {
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i] == '/' {
+			return p[i+1:]
+		}
+	}
+	return p
+}
+
+var _ io.WriteCloser = nopWriteCloser{}
+var _ io.WriteCloser = nopWriter{}
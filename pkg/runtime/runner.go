@@ -3,6 +3,7 @@
 package runtime
 
 import (
+	"context"
 	"os/exec"
 )
 
@@ -10,7 +11,10 @@ import (
 // Implementations can execute real commands or provide mock behavior for testing.
 type CommandRunner interface {
 	// Run executes a command and returns combined stdout/stderr output.
-	Run(name string, args ...string) ([]byte, error)
+	// Canceling ctx kills the process (SIGKILL) before waiting on it, the
+	// same way the ContainerRuntime/DockerClient implementations react to
+	// cancellation.
+	Run(ctx context.Context, name string, args ...string) ([]byte, error)
 
 	// RunDetached executes a command in the background (detached mode).
 	// It does not wait for the command to complete.
@@ -27,8 +31,8 @@ func NewExecRunner() *ExecRunner {
 }
 
 // Run executes a command and returns combined stdout/stderr output.
-func (r *ExecRunner) Run(name string, args ...string) ([]byte, error) {
-	cmd := exec.Command(name, args...)
+func (r *ExecRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
 	return cmd.CombinedOutput()
 }
 
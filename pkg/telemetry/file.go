@@ -0,0 +1,18 @@
+package telemetry
+
+import (
+	"fmt"
+	"os"
+)
+
+// NewFileSink returns a Sink that appends NDJSON records to the file at
+// path, creating it (and any missing parent directories are the caller's
+// responsibility, matching os.Create) if it does not exist. Close closes
+// the underlying file.
+func NewFileSink(path string) (Sink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening telemetry file %s: %w", path, err)
+	}
+	return &ndjsonSink{w: f, closer: f}, nil
+}
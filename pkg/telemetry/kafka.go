@@ -0,0 +1,43 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// kafkaSink publishes Records to a Kafka topic, keyed by RunID so a
+// consumer can partition/order by task, the same way distributed test
+// runners stream per-run event logs.
+type kafkaSink struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaSink returns a Sink that publishes NDJSON records to topic on
+// brokers.
+func NewKafkaSink(brokers []string, topic string) Sink {
+	return &kafkaSink{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    topic,
+			Balancer: &kafka.Hash{},
+		},
+	}
+}
+
+func (s *kafkaSink) Publish(ctx context.Context, rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshaling telemetry record: %w", err)
+	}
+	return s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(rec.RunID),
+		Value: data,
+	})
+}
+
+func (s *kafkaSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,50 @@
+package telemetry
+
+import "fmt"
+
+// Kind selects a Sink implementation, settable via a scenario's Telemetry
+// field or the CLI's --telemetry flag.
+type Kind string
+
+const (
+	// KindNone disables telemetry. This is the default.
+	KindNone Kind = ""
+	// KindStdout writes NDJSON records to stdout.
+	KindStdout Kind = "stdout"
+	// KindFile appends NDJSON records to Config.FilePath.
+	KindFile Kind = "file"
+	// KindKafka publishes NDJSON records to Config.KafkaTopic on
+	// Config.KafkaBrokers.
+	KindKafka Kind = "kafka"
+)
+
+// Config selects and configures a Sink for NewSink.
+type Config struct {
+	Kind         Kind     `json:"kind,omitempty" yaml:"kind,omitempty"`
+	FilePath     string   `json:"filePath,omitempty" yaml:"filePath,omitempty"`
+	KafkaBrokers []string `json:"kafkaBrokers,omitempty" yaml:"kafkaBrokers,omitempty"`
+	KafkaTopic   string   `json:"kafkaTopic,omitempty" yaml:"kafkaTopic,omitempty"`
+}
+
+// NewSink builds the Sink cfg selects. KindNone (the zero Config) returns
+// NoopSink.
+func NewSink(cfg Config) (Sink, error) {
+	switch cfg.Kind {
+	case KindNone:
+		return NoopSink{}, nil
+	case KindStdout:
+		return NewStdoutSink(), nil
+	case KindFile:
+		if cfg.FilePath == "" {
+			return nil, fmt.Errorf("telemetry: file sink requires filePath")
+		}
+		return NewFileSink(cfg.FilePath)
+	case KindKafka:
+		if cfg.KafkaTopic == "" || len(cfg.KafkaBrokers) == 0 {
+			return nil, fmt.Errorf("telemetry: kafka sink requires kafkaBrokers and kafkaTopic")
+		}
+		return NewKafkaSink(cfg.KafkaBrokers, cfg.KafkaTopic), nil
+	default:
+		return nil, fmt.Errorf("telemetry: unknown sink kind %q", cfg.Kind)
+	}
+}
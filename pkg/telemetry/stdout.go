@@ -0,0 +1,44 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// ndjsonSink writes each Record as one line of JSON to w, guarding w with a
+// mutex since Publish can be called concurrently (events fan out across
+// hosts via EventExecutor.forEachHost).
+type ndjsonSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer // nil for a sink that does not own w (e.g. stdout)
+}
+
+// NewStdoutSink returns a Sink that writes NDJSON records to os.Stdout.
+func NewStdoutSink() Sink {
+	return &ndjsonSink{w: os.Stdout}
+}
+
+func (s *ndjsonSink) Publish(ctx context.Context, rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshaling telemetry record: %w", err)
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(data)
+	return err
+}
+
+func (s *ndjsonSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
@@ -0,0 +1,63 @@
+// Package telemetry streams scenario-lifecycle events to an external sink
+// (stdout, a file, Kafka, ...), as structured Records, instead of the
+// logrus-only output and end-of-run summary logging/testing has relied on
+// until now (e.g. grepping control.log for "Succeeded: 3").
+package telemetry
+
+import (
+	"context"
+	"time"
+)
+
+// RecordType names a point in a scenario run's lifecycle a Sink receives a
+// Record for.
+type RecordType string
+
+const (
+	RecordTaskStarted   RecordType = "task_started"
+	RecordDeployDone    RecordType = "deploy_done"
+	RecordEventStarted  RecordType = "event_started"
+	RecordEventFinished RecordType = "event_finished"
+	RecordDestroyDone   RecordType = "destroy_done"
+	RecordTaskFinished  RecordType = "task_finished"
+)
+
+// Record is one lifecycle point for a task (RunID), published to a Sink.
+// Fields that do not apply to Type are left zero (e.g. EventType/Host are
+// empty outside RecordEventStarted/RecordEventFinished).
+type Record struct {
+	Type RecordType `json:"type"`
+	Time time.Time  `json:"time"`
+	// RunID identifies the task this record belongs to, matching
+	// executor.Task.RunID.
+	RunID string `json:"runId"`
+	// ScenarioPath is the scenario file the task is running.
+	ScenarioPath string `json:"scenarioPath,omitempty"`
+	// EventIndex, EventType, and Host describe a RecordEventStarted/
+	// RecordEventFinished record's event.
+	EventIndex int    `json:"eventIndex,omitempty"`
+	EventType  string `json:"eventType,omitempty"`
+	Host       string `json:"host,omitempty"`
+	// Duration is how long the event/task/deploy/destroy phase took, set on
+	// the "done"/"finished" record of a pair.
+	Duration time.Duration `json:"durationNs,omitempty"`
+	// Error is the phase's failure, if any, as a plain string so Record
+	// stays a plain data type regardless of the underlying error's type.
+	Error string `json:"error,omitempty"`
+}
+
+// Sink publishes Records somewhere (stdout, a file, a message broker) for
+// an external dashboard or test to consume a scenario's progress as it
+// happens, instead of only after the fact.
+type Sink interface {
+	Publish(ctx context.Context, rec Record) error
+	Close() error
+}
+
+// NoopSink discards every Record. It is the default a caller's sink()
+// helper falls back to when no Sink is configured, so telemetry stays
+// entirely optional.
+type NoopSink struct{}
+
+func (NoopSink) Publish(ctx context.Context, rec Record) error { return nil }
+func (NoopSink) Close() error                                  { return nil }
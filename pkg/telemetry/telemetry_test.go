@@ -0,0 +1,96 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNdjsonSink_PublishWritesOneLinePerRecord(t *testing.T) {
+	var buf bytes.Buffer
+	s := &ndjsonSink{w: &buf}
+
+	require.NoError(t, s.Publish(context.Background(), Record{Type: RecordTaskStarted, RunID: "run-1"}))
+	require.NoError(t, s.Publish(context.Background(), Record{Type: RecordTaskFinished, RunID: "run-1"}))
+
+	lines := bytes.Split(bytes.TrimRight(buf.Bytes(), "\n"), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var rec Record
+	require.NoError(t, json.Unmarshal(lines[0], &rec))
+	assert.Equal(t, RecordTaskStarted, rec.Type)
+	assert.Equal(t, "run-1", rec.RunID)
+}
+
+func TestNdjsonSink_CloseIsNoopWithoutCloser(t *testing.T) {
+	s := &ndjsonSink{w: &bytes.Buffer{}}
+	assert.NoError(t, s.Close())
+}
+
+func TestNewFileSink_AppendsAcrossCallsAndClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.ndjson")
+
+	s1, err := NewFileSink(path)
+	require.NoError(t, err)
+	require.NoError(t, s1.Publish(context.Background(), Record{Type: RecordDeployDone, RunID: "run-1"}))
+	require.NoError(t, s1.Close())
+
+	s2, err := NewFileSink(path)
+	require.NoError(t, err)
+	require.NoError(t, s2.Publish(context.Background(), Record{Type: RecordDestroyDone, RunID: "run-1"}))
+	require.NoError(t, s2.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimRight(content, "\n"), []byte("\n"))
+	require.Len(t, lines, 2)
+}
+
+func TestNewSink_None(t *testing.T) {
+	s, err := NewSink(Config{})
+	require.NoError(t, err)
+	assert.IsType(t, NoopSink{}, s)
+}
+
+func TestNewSink_Stdout(t *testing.T) {
+	s, err := NewSink(Config{Kind: KindStdout})
+	require.NoError(t, err)
+	assert.IsType(t, &ndjsonSink{}, s)
+}
+
+func TestNewSink_FileRequiresFilePath(t *testing.T) {
+	_, err := NewSink(Config{Kind: KindFile})
+	assert.Error(t, err)
+}
+
+func TestNewSink_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.ndjson")
+	s, err := NewSink(Config{Kind: KindFile, FilePath: path})
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+}
+
+func TestNewSink_KafkaRequiresBrokersAndTopic(t *testing.T) {
+	_, err := NewSink(Config{Kind: KindKafka})
+	assert.Error(t, err)
+
+	_, err = NewSink(Config{Kind: KindKafka, KafkaBrokers: []string{"localhost:9092"}})
+	assert.Error(t, err)
+}
+
+func TestNewSink_UnknownKind(t *testing.T) {
+	_, err := NewSink(Config{Kind: "nats"})
+	assert.Error(t, err)
+}
+
+func TestNoopSink_DiscardsRecords(t *testing.T) {
+	s := NoopSink{}
+	assert.NoError(t, s.Publish(context.Background(), Record{Type: RecordTaskStarted}))
+	assert.NoError(t, s.Close())
+}
@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"os/exec"
@@ -9,6 +10,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/3atlab/netroub/pkg/model"
+	"github.com/3atlab/netroub/pkg/network"
+	"github.com/3atlab/netroub/pkg/runtime"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -112,24 +116,39 @@ func cleanupTestEnvironment(t *testing.T) {
 	t.Log("Test environment cleanup completed")
 }
 
+// waitForNetworkReady gates the test on network.WaitForTopologyReady instead
+// of polling `docker exec ... ping` by hand: every host probes
+// "192.168.1.2" (r2's address in the minimal test topology), retried for up
+// to 60s, matching this test's prior ad-hoc timeout/interval.
 func waitForNetworkReady(t *testing.T, hosts []string) {
 	t.Log("Waiting for network to be ready...")
-	
-	timeout := 60 * time.Second
-	interval := 2 * time.Second
-	
+
+	containerRuntime, err := runtime.NewContainerRuntime(runtime.DefaultEngine)
+	require.NoError(t, err, "Failed to create container runtime")
+
+	cfg := model.ReadinessConfig{
+		Interval: "2s",
+		Timeout:  "1s",
+		Retries:  30, // 30 * 2s interval ~= the previous 60s timeout
+		Probes:   make([]model.ReadinessProbe, 0, len(hosts)),
+	}
+	for _, host := range hosts {
+		cfg.Probes = append(cfg.Probes, model.ReadinessProbe{
+			Host:   host,
+			Kind:   model.ReadinessProbePing,
+			Target: "192.168.1.2",
+		})
+	}
+
+	// Containers in this test topology are exec'd into by their bare host
+	// name (e.g. "r1"), not containerlab's "clab-<lab>-<host>" convention.
+	err = network.WaitForTopologyReady(context.Background(), cfg, containerRuntime, func(host string) string { return host })
+	require.NoError(t, err, "Network did not become ready")
+
 	for _, host := range hosts {
-		require.Eventually(t, func() bool {
-			// Check if container is running and network is up
-			cmd := exec.Command("docker", "exec", host, 
-				"ping", "-c", "1", "-W", "1", "192.168.1.2")
-			err := cmd.Run()
-			return err == nil
-		}, timeout, interval, "Host %s network not ready", host)
-		
 		t.Logf("Host %s is ready", host)
 	}
-	
+
 	// Additional stabilization time
 	time.Sleep(5 * time.Second)
 	t.Log("Network is ready")
@@ -178,7 +178,7 @@ func executeScenario(t *testing.T, scenarioFile string) {
 	projectRoot := getProjectRoot(t)
 	netroubPath := filepath.Join(projectRoot, "netroub")
 
-	cmd := exec.Command("sudo", netroubPath, scenarioFile)
+	cmd := exec.Command("sudo", netroubPath, "run", scenarioFile)
 	cmd.Dir = projectRoot
 
 	output, err := cmd.CombinedOutput()